@@ -0,0 +1,115 @@
+package actions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/git"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+	"github.com/speakeasy-api/sdk-generation-action/internal/run"
+	"github.com/speakeasy-api/sdk-generation-action/internal/versionbumps"
+	"github.com/speakeasy-api/versioning-reports/versioning"
+)
+
+// requiresMajorBumpApproval returns whether the changes require a major version bump that major_bump_approval holds back.
+// Manual bumps through version_bump or set_version are already deliberate so don't require approval.
+func requiresMajorBumpApproval(info versionbumps.VersioningInfo) bool {
+	if environment.GetMajorBumpApproval() == environment.MajorBumpApprovalOff || info.ManualBump || environment.GetVersionBump() != "" || environment.SetVersion() != "" {
+		return false
+	}
+
+	return versionbumps.HighestBump(info.VersionReport) == versioning.BumpMajor
+}
+
+func majorBumpApprovalTitle() string {
+	return "Approve major version bump - " + environment.GetWorkflowName()
+}
+
+// checkMajorBumpApproval returns whether the major version bump was approved through the approval issue when
+// major_bump_approval is issue, opening the issue if it hasn't been yet. Otherwise the bump is approved by re-running
+// the workflow with version_bump major, so it's never approved here.
+func checkMajorBumpApproval(g *git.Git, runRes *run.RunResult, outputs map[string]string) (bool, error) {
+	if environment.GetMajorBumpApproval() != environment.MajorBumpApprovalIssue {
+		return false, nil
+	}
+	if !ci.IsGitHub() {
+		logging.Info("Approval issues are only supported on GitHub, re-run the workflow with version_bump major to approve the major version bump")
+		return false, nil
+	}
+
+	title := majorBumpApprovalTitle()
+	issue, err := g.FindApprovalIssue(title)
+	if err != nil {
+		return false, err
+	}
+
+	if issue == nil {
+		url, err := g.OpenApprovalIssue(title, majorBumpApprovalBody(runRes))
+		if err != nil {
+			return false, err
+		}
+		outputs["approval_issue_url"] = url
+		return false, nil
+	}
+
+	outputs["approval_issue_url"] = issue.GetHTMLURL()
+	if !git.IsApproved(issue) {
+		logging.Info("Waiting for the major version bump to be approved in issue #%d", issue.GetNumber())
+		return false, nil
+	}
+
+	logging.Info("The major version bump was approved in issue #%d", issue.GetNumber())
+	if err := g.CloseIssue(issue.GetNumber(), fmt.Sprintf("Generating the approved major version bump in [this workflow run](%s).", workflowRunURL())); err != nil {
+		logging.Info("Failed to close approval issue: %v", err)
+	}
+
+	return true, nil
+}
+
+func majorBumpApprovalBody(runRes *run.RunResult) string {
+	lines := []string{
+		"The changes to the SDKs require a major version bump, which is held back until approved.",
+		"",
+		fmt.Sprintf("Add the `%s` label to this issue to generate the changes on the next run, or run the workflow with `version_bump: major`.", git.ApprovedLabel),
+		"",
+	}
+	lines = append(lines, versionBumpTable(runRes.VersioningInfo.VersionReport)...)
+
+	if runRes.OpenAPIChangeSummary != "" {
+		lines = append(lines, "", "<details>", "<summary>OpenAPI changes</summary>", "", runRes.OpenAPIChangeSummary, "", "</details>")
+	}
+
+	links := []string{}
+	if runRes.ChangesReportURL != "" {
+		links = append(links, fmt.Sprintf("[OpenAPI changes report](%s)", runRes.ChangesReportURL))
+	}
+	links = append(links, fmt.Sprintf("[Workflow run](%s)", workflowRunURL()))
+	lines = append(lines, "", strings.Join(links, " | "))
+
+	return strings.Join(lines, "\n")
+}
+
+// versionBumpTable returns the lines of a markdown table of the targets' version bumps
+func versionBumpTable(report *versioning.MergedVersionReport) []string {
+	lines := []string{
+		"| Target | Bump | New version |",
+		"| --- | --- | --- |",
+	}
+
+	reports := []versioning.VersionReport{}
+	if report != nil {
+		reports = append(reports, report.Reports...)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Key < reports[j].Key })
+	for _, r := range reports {
+		if r.BumpType == "" || r.BumpType == versioning.BumpNone {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("| %s | %s | %s |", r.Key, r.BumpType, r.NewVersion))
+	}
+
+	return lines
+}
@@ -0,0 +1,52 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/run"
+	"github.com/speakeasy-api/sdk-generation-action/internal/versionbumps"
+	"github.com/speakeasy-api/versioning-reports/versioning"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiresMajorBumpApproval(t *testing.T) {
+	major := versionbumps.VersioningInfo{VersionReport: &versioning.MergedVersionReport{Reports: []versioning.VersionReport{
+		{Key: "python", BumpType: versioning.BumpMinor},
+		{Key: "typescript", BumpType: versioning.BumpMajor},
+	}}}
+	minor := versionbumps.VersioningInfo{VersionReport: &versioning.MergedVersionReport{Reports: []versioning.VersionReport{
+		{Key: "python", BumpType: versioning.BumpMinor},
+	}}}
+
+	t.Setenv("INPUT_VERSION_BUMP", "")
+	t.Setenv("INPUT_SET_VERSION", "")
+
+	t.Setenv("INPUT_MAJOR_BUMP_APPROVAL", "")
+	assert.False(t, requiresMajorBumpApproval(major))
+
+	t.Setenv("INPUT_MAJOR_BUMP_APPROVAL", "issue")
+	assert.True(t, requiresMajorBumpApproval(major))
+	assert.False(t, requiresMajorBumpApproval(minor))
+	assert.False(t, requiresMajorBumpApproval(versionbumps.VersioningInfo{ManualBump: true, VersionReport: major.VersionReport}))
+
+	t.Setenv("INPUT_VERSION_BUMP", "major")
+	assert.False(t, requiresMajorBumpApproval(major))
+}
+
+func TestMajorBumpApprovalBody(t *testing.T) {
+	t.Setenv("GITHUB_SERVER_URL", "https://github.com")
+	t.Setenv("GITHUB_REPOSITORY", "speakeasy-api/petstore")
+	t.Setenv("GITHUB_RUN_ID", "42")
+
+	body := majorBumpApprovalBody(&run.RunResult{
+		VersioningInfo: versionbumps.VersioningInfo{VersionReport: &versioning.MergedVersionReport{Reports: []versioning.VersionReport{
+			{Key: "typescript", BumpType: versioning.BumpMajor, NewVersion: "2.0.0"},
+			{Key: "python", BumpType: versioning.BumpNone},
+			{Key: "go", BumpType: versioning.BumpMinor, NewVersion: "1.3.0"},
+		}}},
+	})
+
+	assert.Contains(t, body, "Add the `approved` label to this issue")
+	assert.Contains(t, body, "| go | minor | 1.3.0 |\n| typescript | major | 2.0.0 |\n\n[Workflow run](https://github.com/speakeasy-api/petstore/actions/runs/42)")
+	assert.NotContains(t, body, "| python |")
+}
@@ -0,0 +1,81 @@
+package actions
+
+import (
+	"path"
+	"time"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/git"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+	"github.com/speakeasy-api/sdk-generation-action/pkg/releases"
+)
+
+// nextGeneration returns when changes are next generated given the min_release_interval, the zero time if they can be
+// generated now. The last generation is the commit that last changed RELEASES.md or its pending entries.
+func nextGeneration(last time.Time, interval time.Duration, now time.Time) time.Time {
+	if interval == 0 || last.IsZero() {
+		return time.Time{}
+	}
+
+	if next := last.Add(interval); now.Before(next) {
+		return next
+	}
+
+	return time.Time{}
+}
+
+// nextGeneratorUpgrade returns when the next generator upgrade PR can be opened given the generator_upgrade_interval
+// and when the last one was opened, the zero time if it can be opened now
+func nextGeneratorUpgrade(last time.Time, interval time.Duration, now time.Time) time.Time {
+	if last.IsZero() {
+		return time.Time{}
+	}
+
+	if next := last.Add(interval); now.Before(next) {
+		return next
+	}
+
+	return time.Time{}
+}
+
+// lastGeneration returns the time of the commit that last changed RELEASES.md or its pending entries, the zero time if
+// nothing has been generated yet
+func lastGeneration(g *git.Git) (time.Time, error) {
+	releasesDir, err := getReleasesDir()
+	if err != nil {
+		return time.Time{}, err
+	}
+	hash, err := lastGenerationCommit(g, releasesDir)
+	if err != nil {
+		logging.Debug("failed to find the last generation: %v", err)
+		return time.Time{}, nil
+	}
+
+	return g.CommitTime(hash)
+}
+
+// lastGenerationCommit returns the commit that last changed RELEASES.md or, when release_after_publish defers its
+// entries, the entries pending publishing
+func lastGenerationCommit(g *git.Git, releasesDir string) (string, error) {
+	hash, err := g.LastCommitChanging(path.Join(releasesDir, "RELEASES.md"))
+	pendingHash, pendingErr := g.LastCommitChanging(path.Join(releasesDir, releases.PendingReleasesFile))
+	if pendingErr != nil {
+		return hash, err
+	}
+	if err != nil {
+		return pendingHash, nil
+	}
+
+	committed, err := g.CommitTime(hash)
+	if err != nil {
+		return "", err
+	}
+	pendingCommitted, err := g.CommitTime(pendingHash)
+	if err != nil {
+		return "", err
+	}
+	if pendingCommitted.After(committed) {
+		return pendingHash, nil
+	}
+
+	return hash, nil
+}
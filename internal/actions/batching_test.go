@@ -0,0 +1,50 @@
+package actions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextGeneration(t *testing.T) {
+	last := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		last     time.Time
+		interval time.Duration
+		now      time.Time
+		want     time.Time
+	}{
+		{
+			name:     "no interval",
+			last:     last,
+			interval: 0,
+			now:      last.Add(time.Hour),
+		},
+		{
+			name:     "nothing generated yet",
+			interval: 24 * time.Hour,
+			now:      last,
+		},
+		{
+			name:     "within the interval",
+			last:     last,
+			interval: 24 * time.Hour,
+			now:      last.Add(time.Hour),
+			want:     last.Add(24 * time.Hour),
+		},
+		{
+			name:     "after the interval",
+			last:     last,
+			interval: 24 * time.Hour,
+			now:      last.Add(25 * time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nextGeneration(tt.last, tt.interval, tt.now))
+		})
+	}
+}
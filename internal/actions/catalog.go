@@ -0,0 +1,50 @@
+package actions
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/catalog"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+	"github.com/speakeasy-api/sdk-generation-action/pkg/releases"
+)
+
+// updateCatalogInfo sets the generated SDK versions, spec version and generation time annotations of the catalog_info
+// file, so Backstage shows them once the generated changes are committed
+func updateCatalogInfo(releaseInfo releases.ReleasesInfo) error {
+	path := environment.GetCatalogInfoPath()
+	if path == "" {
+		return nil
+	}
+
+	annotations := catalogAnnotations(releaseInfo)
+
+	changed, err := catalog.Update(filepath.Join(environment.GetRepoDir(), path), annotations)
+	if err != nil {
+		return fmt.Errorf("failed to update catalog info: %w", err)
+	}
+	if changed {
+		logging.Info("Updated the Speakeasy annotations of %s", path)
+	}
+
+	return nil
+}
+
+func catalogAnnotations(releaseInfo releases.ReleasesInfo) map[string]string {
+	annotations := map[string]string{
+		catalog.AnnotationPrefix + "last-generated": environment.GetInvokeTime().Format(time.RFC3339),
+	}
+	if releaseInfo.DocVersion != "" {
+		annotations[catalog.AnnotationPrefix+"spec-version"] = releaseInfo.DocVersion
+	}
+	if releaseInfo.GenerationVersion != "" {
+		annotations[catalog.AnnotationPrefix+"generation-version"] = releaseInfo.GenerationVersion
+	}
+	for lang, info := range releaseInfo.LanguagesGenerated {
+		annotations[catalog.AnnotationPrefix+lang+"-sdk-version"] = info.Version
+	}
+
+	return annotations
+}
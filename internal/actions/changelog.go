@@ -0,0 +1,75 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/cli"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+	"github.com/speakeasy-api/sdk-generation-action/pkg/releases"
+)
+
+func Changelog() error {
+	fromRelease := environment.GetFromRelease()
+	if fromRelease == "" {
+		return errors.New("from_release is required for the changelog action")
+	}
+
+	g, err := initAction()
+	if err != nil {
+		return err
+	}
+
+	if _, err = cli.Download("latest", g); err != nil {
+		return err
+	}
+
+	fromGHRelease, _, err := g.GetReleaseByTag(context.Background(), fromRelease)
+	if err != nil {
+		return fmt.Errorf("failed to get release %s: %w", fromRelease, err)
+	}
+
+	ghReleases, err := g.GetReleasesBetween(fromRelease, environment.GetToRelease())
+	if err != nil {
+		return err
+	}
+
+	fromInfo, err := releases.ParseReleases(fromGHRelease.GetBody())
+	if err != nil {
+		return fmt.Errorf("failed to parse release %s: %w", fromRelease, err)
+	}
+
+	toGHRelease := ghReleases[len(ghReleases)-1]
+	toInfo, err := releases.ParseReleases(toGHRelease.GetBody())
+	if err != nil {
+		return fmt.Errorf("failed to parse release %s: %w", toGHRelease.GetTagName(), err)
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Changes from %s to %s\n", fromRelease, toGHRelease.GetTagName()))
+	sb.WriteString(fmt.Sprintf("\n## OpenAPI Doc\n\n%s -> %s\n", fromInfo.DocVersion, toInfo.DocVersion))
+
+	if fromInfo.GenerationVersion != toInfo.GenerationVersion {
+		generatorChangelog, err := cli.GetGenerationChangelog(toInfo.GenerationVersion, fromInfo.GenerationVersion)
+		if err != nil {
+			// The generator changelog is supplementary, still output the release notes we have
+			logging.Info("failed to get generator changelog: %s", err.Error())
+		} else if strings.TrimSpace(generatorChangelog) != "" {
+			sb.WriteString(fmt.Sprintf("\n## Generator Changes (%s -> %s)\n\n%s\n", fromInfo.GenerationVersion, toInfo.GenerationVersion, strings.TrimSpace(generatorChangelog)))
+		}
+	}
+
+	sb.WriteString("\n## Releases\n")
+	for _, r := range ghReleases {
+		body := strings.TrimSpace(strings.TrimPrefix(r.GetBody(), "# Generated by Speakeasy CLI"))
+		sb.WriteString(fmt.Sprintf("\n### %s\n\n%s\n", r.GetName(), body))
+	}
+
+	return setOutputs(map[string]string{
+		"changelog": sb.String(),
+	})
+}
@@ -0,0 +1,64 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// checkpointFile records the outputs of the run that generated the committed SDKs, relative to the working directory
+const checkpointFile = ".speakeasy/action-checkpoint.json"
+
+type checkpoint struct {
+	RunID   string            `json:"run_id"`
+	Outputs map[string]string `json:"outputs"`
+}
+
+// writeCheckpoint stores the run's outputs alongside the generated changes so a re-run of the same workflow run can
+// resume publishing without regenerating and bumping versions again
+func writeCheckpoint(outputs map[string]string) error {
+	data, err := json.MarshalIndent(checkpoint{
+		RunID:   environment.GetRunID(),
+		Outputs: outputs,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	checkpointPath := filepath.Join(environment.GetRepoDir(), environment.GetWorkingDirectory(), checkpointFile)
+	if err := os.WriteFile(checkpointPath, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// readCheckpoint returns the outputs of a previous attempt of this workflow run if its generated changes have already
+// been committed to the branch at ref, the branch the workflow runs on in direct mode or the PR's branch in PR mode
+func readCheckpoint(getFileContents func(filePath, ref string) ([]byte, error), ref string) (map[string]string, error) {
+	attempt, err := strconv.Atoi(os.Getenv("GITHUB_RUN_ATTEMPT"))
+	if err != nil || attempt <= 1 {
+		return nil, nil
+	}
+
+	data, err := getFileContents(path.Join(environment.GetWorkingDirectory(), checkpointFile), ref)
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+
+	if cp.RunID != environment.GetRunID() {
+		return nil, nil
+	}
+
+	return cp.Outputs, nil
+}
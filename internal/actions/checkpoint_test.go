@@ -0,0 +1,57 @@
+package actions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpoint(t *testing.T) {
+	repoDir := t.TempDir()
+	t.Setenv("INPUT_REPO_DIR", repoDir)
+	t.Setenv("INPUT_WORKING_DIRECTORY", "sdks")
+	t.Setenv("GITHUB_RUN_ID", "1234")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "sdks", ".speakeasy"), 0o755))
+
+	outputs := map[string]string{"python_regenerated": "true", "publish_python": "true"}
+	require.NoError(t, writeCheckpoint(outputs))
+
+	// The checkpoint is read from the branch it was committed to, e.g. the PR's branch in PR mode
+	committed := map[string]string{}
+	getFileContents := func(filePath, ref string) ([]byte, error) {
+		assert.Equal(t, "sdks/.speakeasy/action-checkpoint.json", filePath)
+		if ref != "speakeasy-sdk-regen-1700000000" {
+			return nil, nil
+		}
+		return []byte(committed[filePath]), nil
+	}
+	data, err := os.ReadFile(filepath.Join(repoDir, "sdks", checkpointFile))
+	require.NoError(t, err)
+	committed["sdks/.speakeasy/action-checkpoint.json"] = string(data)
+
+	tests := []struct {
+		name    string
+		attempt string
+		runID   string
+		ref     string
+		want    map[string]string
+	}{
+		{name: "first attempt", attempt: "1", runID: "1234", ref: "speakeasy-sdk-regen-1700000000"},
+		{name: "re-run of the same run", attempt: "2", runID: "1234", ref: "speakeasy-sdk-regen-1700000000", want: outputs},
+		{name: "re-run of another run", attempt: "2", runID: "5678", ref: "speakeasy-sdk-regen-1700000000"},
+		{name: "not committed to the branch", attempt: "2", runID: "1234", ref: "refs/heads/main"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GITHUB_RUN_ATTEMPT", tt.attempt)
+			t.Setenv("GITHUB_RUN_ID", tt.runID)
+
+			got, err := readCheckpoint(getFileContents, tt.ref)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package actions
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/git"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+	"github.com/speakeasy-api/sdk-generation-action/internal/run"
+)
+
+type checkRun struct {
+	g  *git.Git
+	id int64
+}
+
+// startCheckRun creates the dedicated Speakeasy check run if enabled. Failing to create the check run never fails the action.
+func startCheckRun(g *git.Git) *checkRun {
+	if !environment.CreateCheckRun() || environment.IsTestMode() {
+		return nil
+	}
+
+	id, err := g.CreateCheckRun()
+	if err != nil {
+		logging.Info("failed to create check run: %s", err.Error())
+		return nil
+	}
+
+	return &checkRun{g: g, id: id}
+}
+
+func (c *checkRun) complete(runErr error, outputs map[string]string, annotations []git.CheckRunAnnotation) {
+	if c == nil {
+		return
+	}
+
+	conclusion := "success"
+	title := "SDK generation succeeded"
+	if runErr != nil {
+		conclusion = "failure"
+		title = "SDK generation failed"
+	}
+
+	regenerated := []string{}
+	for k, v := range outputs {
+		if lang, ok := strings.CutSuffix(k, "_regenerated"); ok && v == "true" {
+			regenerated = append(regenerated, lang)
+		}
+	}
+	sort.Strings(regenerated)
+
+	summary := "No SDKs were regenerated."
+	if len(regenerated) > 0 {
+		lines := []string{"| Language | Version | Directory |", "| --- | --- | --- |"}
+		for _, lang := range regenerated {
+			lines = append(lines, fmt.Sprintf("| %s | %s | %s |", lang, outputs[lang+"_version"], outputs[lang+"_directory"]))
+		}
+		summary = strings.Join(lines, "\n")
+	}
+
+	text := ""
+	if runErr != nil {
+		text = fmt.Sprintf("```\n%s\n```", runErr.Error())
+	}
+
+	if err := c.g.CompleteCheckRun(c.id, conclusion, title, summary, text, annotations); err != nil {
+		logging.Info("failed to complete check run: %s", err.Error())
+	}
+}
+
+var validationIssueLevels = map[string]string{
+	"error": "failure",
+	"warn":  "warning",
+	"hint":  "notice",
+}
+
+// checkRunAnnotations annotates the workflow file with the targets that failed to generate, and the OpenAPI doc with
+// the issues found validating it and the generator's warnings about it. They fall back to the workflow file when the
+// doc isn't a single file in the repo.
+func checkRunAnnotations(runRes *run.RunResult, wf *workflow.Workflow) []git.CheckRunAnnotation {
+	if runRes == nil {
+		return nil
+	}
+
+	workflowFile := path.Join(filepath.ToSlash(environment.GetWorkingDirectory()), ".speakeasy", "workflow.yaml")
+	docFile := sourceDocumentFile(wf)
+	if docFile == "" {
+		docFile = workflowFile
+	}
+
+	annotations := []git.CheckRunAnnotation{}
+
+	failedTargets := make([]string, 0, len(runRes.FailedTargets))
+	for targetID := range runRes.FailedTargets {
+		failedTargets = append(failedTargets, targetID)
+	}
+	sort.Strings(failedTargets)
+	for _, targetID := range failedTargets {
+		annotations = append(annotations, git.CheckRunAnnotation{
+			Path:    workflowFile,
+			Level:   "failure",
+			Title:   targetID + " failed to generate",
+			Message: runRes.FailedTargets[targetID].Error(),
+		})
+	}
+
+	for _, issue := range runRes.ValidationIssues {
+		annotations = append(annotations, git.CheckRunAnnotation{
+			Path:    docFile,
+			Line:    issue.Line,
+			Level:   validationIssueLevels[issue.Severity],
+			Title:   "validation " + issue.Severity,
+			Message: issue.Message,
+		})
+	}
+
+	for _, warning := range runRes.GenerationWarnings {
+		annotations = append(annotations, git.CheckRunAnnotation{
+			Path:    docFile,
+			Level:   "warning",
+			Title:   generationWarningTitles[warning.Kind] + " by the generator",
+			Message: warning.Message,
+		})
+	}
+
+	return annotations
+}
+
+// sourceDocumentFile returns the path from the repo root of the OpenAPI doc the workflow generates from when it has a
+// single source with a single input in the repo, empty otherwise
+func sourceDocumentFile(wf *workflow.Workflow) string {
+	if wf == nil || len(wf.Sources) != 1 {
+		return ""
+	}
+
+	for _, source := range wf.Sources {
+		if len(source.Inputs) != 1 {
+			return ""
+		}
+		location := source.Inputs[0].Location.Reference()
+		if strings.HasPrefix(location, "$") || strings.Contains(location, "://") || filepath.IsAbs(location) {
+			return ""
+		}
+		return path.Join(filepath.ToSlash(environment.GetWorkingDirectory()), filepath.ToSlash(location))
+	}
+
+	return ""
+}
@@ -0,0 +1,51 @@
+package actions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/speakeasy-api/sdk-generation-action/internal/cli"
+	"github.com/speakeasy-api/sdk-generation-action/internal/git"
+	"github.com/speakeasy-api/sdk-generation-action/internal/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckRunAnnotations(t *testing.T) {
+	t.Setenv("INPUT_WORKING_DIRECTORY", "sdks")
+
+	runRes := &run.RunResult{
+		FailedTargets: map[string]error{
+			"typescript": errors.New("error running workflow: exit status 1"),
+			"python":     errors.New("error running workflow: exit status 2"),
+		},
+		ValidationIssues: []cli.ValidationIssue{
+			{Severity: "error", Line: 40, Message: "missing-response - operation getPets has no responses"},
+			{Severity: "hint", Message: "consider adding examples"},
+		},
+		GenerationWarnings: []cli.GenerationWarning{
+			{Kind: cli.GenerationWarningSkipped, Message: "Skipping operation getPets: no responses defined"},
+		},
+	}
+
+	localDoc := &workflow.Workflow{Sources: map[string]workflow.Source{
+		"api": {Inputs: []workflow.Document{{Location: "openapi/api.yaml"}}},
+	}}
+	assert.Equal(t, []git.CheckRunAnnotation{
+		{Path: "sdks/.speakeasy/workflow.yaml", Level: "failure", Title: "python failed to generate", Message: "error running workflow: exit status 2"},
+		{Path: "sdks/.speakeasy/workflow.yaml", Level: "failure", Title: "typescript failed to generate", Message: "error running workflow: exit status 1"},
+		{Path: "sdks/openapi/api.yaml", Line: 40, Level: "failure", Title: "validation error", Message: "missing-response - operation getPets has no responses"},
+		{Path: "sdks/openapi/api.yaml", Level: "notice", Title: "validation hint", Message: "consider adding examples"},
+		{Path: "sdks/openapi/api.yaml", Level: "warning", Title: "Skipped by the generator", Message: "Skipping operation getPets: no responses defined"},
+	}, checkRunAnnotations(runRes, localDoc))
+
+	// Issues in a doc outside the repo are attached to the workflow file
+	remoteDoc := &workflow.Workflow{Sources: map[string]workflow.Source{
+		"api": {Inputs: []workflow.Document{{Location: "https://example.com/openapi.yaml"}}},
+	}}
+	for _, annotation := range checkRunAnnotations(runRes, remoteDoc) {
+		assert.Equal(t, "sdks/.speakeasy/workflow.yaml", annotation.Path)
+	}
+
+	assert.Nil(t, checkRunAnnotations(nil, localDoc))
+}
@@ -0,0 +1,59 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+	"github.com/speakeasy-api/sdk-generation-action/pkg/releases"
+)
+
+// publishCocoaPods pushes the podspec of a tagged Swift SDK release to the CocoaPods trunk, recording the pod's URL in
+// the release and outputs
+func publishCocoaPods(g sdkReleaser, info releases.LanguageReleaseInfo, outputs map[string]string) error {
+	token := environment.GetCocoaPodsTrunkToken()
+	if token == "" {
+		return nil
+	}
+
+	if outputs["publish_swift"] == "false" {
+		logging.Info("Swift release v%s has already been published, skipping pushing to CocoaPods", info.Version)
+		return nil
+	}
+
+	podspecs, err := filepath.Glob(filepath.Join(environment.GetRepoDir(), info.Path, "*.podspec"))
+	if err != nil {
+		return fmt.Errorf("failed to find podspec: %w", err)
+	}
+	if len(podspecs) == 0 {
+		logging.Info("No podspec found in %s, skipping pushing to CocoaPods", info.Path)
+		return nil
+	}
+
+	podspec := podspecs[0]
+	podName := strings.TrimSuffix(filepath.Base(podspec), ".podspec")
+
+	logging.Info("Pushing %s to CocoaPods", filepath.Base(podspec))
+
+	cmd := exec.Command("pod", "trunk", "push", podspec, "--allow-warnings")
+	cmd.Dir = filepath.Dir(podspec)
+	cmd.Env = append(os.Environ(), "COCOAPODS_TRUNK_TOKEN="+token)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to push %s to CocoaPods: %w", podName, err)
+	}
+
+	podURL := fmt.Sprintf("https://cocoapods.org/pods/%s", podName)
+	outputs["cocoapods_url"] = podURL
+
+	if err := g.AppendToReleaseBody(info.Version, info.Path, fmt.Sprintf("- [CocoaPods v%s] %s - %s", info.Version, podURL, info.Path)); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package actions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/git"
+	"github.com/speakeasy-api/sdk-generation-action/internal/run"
+	"github.com/speakeasy-api/sdk-generation-action/pkg/releases"
+)
+
+// appendToDigestIssue summarizes the changes a dry run would have generated on this week's digest issue
+func appendToDigestIssue(g *git.Git, runRes *run.RunResult, generated map[string]releases.GenerationInfo) (string, error) {
+	title := digestIssueTitle(environment.GetInvokeTime())
+
+	return g.AppendToDigestIssue(title, digestComment(runRes, generated))
+}
+
+func digestIssueTitle(t time.Time) string {
+	// Weeks start on Monday
+	weekStart := t.AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+
+	return fmt.Sprintf("Speakeasy SDK changes for the week of %s", weekStart.Format("2006-01-02"))
+}
+
+func digestComment(runRes *run.RunResult, generated map[string]releases.GenerationInfo) string {
+	langs := make([]string, 0, len(generated))
+	for lang := range generated {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	lines := []string{
+		fmt.Sprintf("### %s", environment.GetInvokeTime().Format("2006-01-02 15:04:05")),
+		"",
+		"| Language | Version | Directory |",
+		"| --- | --- | --- |",
+	}
+	for _, lang := range langs {
+		lines = append(lines, fmt.Sprintf("| %s | %s | %s |", lang, generated[lang].Version, generated[lang].Path))
+	}
+
+	if runRes.OpenAPIChangeSummary != "" {
+		lines = append(lines, "", "<details>", "<summary>OpenAPI changes</summary>", "", runRes.OpenAPIChangeSummary, "", "</details>")
+	}
+
+	links := []string{}
+	if runRes.ChangesReportURL != "" {
+		links = append(links, fmt.Sprintf("[OpenAPI changes report](%s)", runRes.ChangesReportURL))
+	}
+	if runRes.LintingReportURL != "" {
+		links = append(links, fmt.Sprintf("[Linting report](%s)", runRes.LintingReportURL))
+	}
+	links = append(links, fmt.Sprintf("[Workflow run](%s)", workflowRunURL()))
+	lines = append(lines, "", strings.Join(links, " | "))
+
+	return strings.Join(lines, "\n")
+}
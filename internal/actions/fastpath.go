@@ -0,0 +1,146 @@
+package actions
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/speakeasy-api/sdk-generation-action/internal/cli"
+	"github.com/speakeasy-api/sdk-generation-action/internal/configuration"
+	"github.com/speakeasy-api/sdk-generation-action/internal/document"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/git"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+	"gopkg.in/yaml.v3"
+)
+
+type noChangesResult struct {
+	// NoChanges is true when neither the remote specs, the workflow nor the CLI version have changed since the last generation
+	NoChanges bool
+	// SpecsModified is false when every remote spec responded as not modified
+	SpecsModified bool
+	Validators    document.RemoteSpecValidators
+}
+
+// checkNoChanges determines without cloning the repo whether a run would find nothing to generate, comparing the
+// lockfile of the last generation against the current workflow, the remote specs and the CLI version that would be used
+func checkNoChanges(g *git.Git) (*noChangesResult, error) {
+	speakeasyDir := path.Join(environment.GetWorkingDirectory(), ".speakeasy")
+
+	lockData, err := g.GetFileContents(path.Join(speakeasyDir, "workflow.lock"))
+	if err != nil {
+		return nil, err
+	}
+	workflowData, err := g.GetFileContents(path.Join(speakeasyDir, "workflow.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	if lockData == nil || workflowData == nil {
+		return &noChangesResult{SpecsModified: true}, nil
+	}
+
+	var lockFile workflow.LockFile
+	if err := yaml.Unmarshal(lockData, &lockFile); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow.lock: %w", err)
+	}
+	var wf workflow.Workflow
+	if err := yaml.Unmarshal(workflowData, &wf); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow.yaml: %w", err)
+	}
+
+	validatorsData, err := g.GetFileContents(path.Join(environment.GetWorkingDirectory(), document.RemoteSpecValidatorsFile))
+	if err != nil {
+		return nil, err
+	}
+	stored, err := document.ParseRemoteSpecValidators(validatorsData)
+	if err != nil {
+		return nil, err
+	}
+
+	specsModified, validators, err := document.CheckRemoteSpecsModified(&wf, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &noChangesResult{SpecsModified: specsModified, Validators: validators}
+	if specsModified {
+		return result, nil
+	}
+
+	if !reflect.DeepEqual(wf, lockFile.Workflow) {
+		logging.Info("The workflow has changed since the last generation")
+		return result, nil
+	}
+
+	configChanged, err := generationConfigChanged(g, &wf)
+	if err != nil {
+		return nil, err
+	}
+	if configChanged {
+		return result, nil
+	}
+
+	// The CLI version the run would use, pinned or latest, is looked up in its GitHub releases rather than downloaded
+	_, cliVersion, err := g.GetDownloadLink(cli.GetVersion(environment.GetPinnedSpeakeasyVersion()))
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimPrefix(cliVersion, "v") != strings.TrimPrefix(lockFile.SpeakeasyVersion, "v") {
+		logging.Info("The Speakeasy CLI version has changed since the last generation (%s -> %s)", lockFile.SpeakeasyVersion, cliVersion)
+		return result, nil
+	}
+
+	result.NoChanges = true
+
+	return result, nil
+}
+
+// generationConfigChanged compares the checksum of each target's generation config against the checksum recorded in
+// the management section of its gen.lock when it was last generated
+func generationConfigChanged(g *git.Git, wf *workflow.Workflow) (bool, error) {
+	for targetID, target := range wf.Targets {
+		lockFile, err := getTargetLockFile(g, targetID, target)
+		if err != nil || lockFile == nil {
+			return true, err
+		}
+
+		checksum, err := configuration.GenerationConfigChecksum(g.GetFileContents, wf, targetID)
+		if err != nil {
+			return false, err
+		}
+
+		if checksum != lockFile.Management.AdditionalProperties[configuration.GenerationConfigChecksumKey] {
+			logging.Info("The generation config of target %s has changed since it was last generated", targetID)
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// getTargetLockFile returns the target's gen.lock from the remote branch, or nil if it hasn't been generated before
+func getTargetLockFile(g *git.Git, targetID string, target workflow.Target) (*config.LockFile, error) {
+	dir := "."
+	if target.Output != nil {
+		dir = *target.Output
+	}
+
+	data, err := g.GetFileContents(path.Join(environment.GetWorkingDirectory(), dir, ".speakeasy", "gen.lock"))
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		logging.Info("Target %s has not been generated before", targetID)
+		return nil, nil
+	}
+
+	var lockFile config.LockFile
+	if err := yaml.Unmarshal(data, &lockFile); err != nil {
+		return nil, fmt.Errorf("failed to parse gen.lock of target %s: %w", targetID, err)
+	}
+
+	return &lockFile, nil
+}
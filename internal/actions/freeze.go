@@ -0,0 +1,92 @@
+package actions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/freeze"
+	"github.com/speakeasy-api/sdk-generation-action/internal/git"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+const freezeIssueTitle = "SDK changes queued by the release freeze"
+
+// releaseFreeze returns whether releases are frozen at the time the run was invoked and, if they are, when the freeze
+// ends. configured is false when release_freeze isn't set.
+func releaseFreeze() (configured, frozen bool, until time.Time, err error) {
+	windows, err := environment.GetReleaseFreezeWindows()
+	if err != nil || len(windows) == 0 {
+		return false, false, time.Time{}, err
+	}
+
+	frozen, until = freeze.Frozen(windows, environment.GetInvokeTime())
+
+	return true, frozen, until, nil
+}
+
+// queueFrozenChanges comments the changes held back by the release freeze on the freeze issue, returning its URL. The
+// issue is only supported on GitHub, elsewhere the changes are only queued through the release_frozen output.
+func queueFrozenChanges(g *git.Git, until time.Time, lines []string) (string, error) {
+	if !ci.IsGitHub() {
+		return "", nil
+	}
+
+	body := append([]string{fmt.Sprintf("### %s", environment.GetInvokeTime().Format("2006-01-02 15:04:05")), ""}, lines...)
+	body = append(body, "", fmt.Sprintf("Held until the freeze ends at %s. [Workflow run](%s)", until.Format(time.RFC1123), workflowRunURL()))
+
+	return g.AppendToFreezeIssue(freezeIssueTitle, strings.Join(body, "\n"))
+}
+
+// frozenOutputs are the outputs of runs that held back their changes or releases during a release freeze
+func frozenOutputs(g *git.Git, until time.Time, lines []string) (map[string]string, error) {
+	logging.Info("Releases are frozen until %s, queuing the changes", until.Format(time.RFC1123))
+
+	outputs := map[string]string{
+		"release_frozen":      "true",
+		"release_freeze_ends": until.Format(time.RFC3339),
+	}
+
+	url, err := queueFrozenChanges(g, until, lines)
+	if err != nil {
+		return nil, err
+	}
+	if url != "" {
+		outputs["release_freeze_issue_url"] = url
+	}
+
+	return outputs, nil
+}
+
+// closeFreezeIssue closes the release freeze issue once its queued changes are generated after the freeze
+func closeFreezeIssue(g *git.Git) {
+	if !ci.IsGitHub() {
+		return
+	}
+
+	issue, err := g.FindFreezeIssue(freezeIssueTitle)
+	if err != nil {
+		logging.Info("Failed to find release freeze issue: %v", err)
+		return
+	}
+	if issue == nil {
+		return
+	}
+
+	if err := g.CloseIssue(issue.GetNumber(), fmt.Sprintf("The release freeze has ended, the queued changes are generated in [this workflow run](%s).", workflowRunURL())); err != nil {
+		logging.Info("Failed to close release freeze issue: %v", err)
+	}
+}
+
+// anyRegenerated returns whether the outputs of the generation report any regenerated target
+func anyRegenerated(outputs map[string]string) bool {
+	for key, value := range outputs {
+		if strings.HasSuffix(key, "_regenerated") && value == "true" {
+			return true
+		}
+	}
+
+	return false
+}
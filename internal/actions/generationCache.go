@@ -0,0 +1,63 @@
+package actions
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+const generationCacheKeyPrefix = "speakeasy-generation-"
+
+// addGenerationCacheOutputs sets the cache directory and a key derived from its contents, so a subsequent
+// actions/cache/save step only saves a new cache entry when the CLI's cache has changed
+func addGenerationCacheOutputs(outputs map[string]string) error {
+	dir := environment.GetGenerationCacheDir()
+	if dir == "" {
+		return nil
+	}
+
+	key, err := generationCacheKey(dir)
+	if err != nil {
+		return err
+	}
+
+	outputs["generation_cache_dir"] = environment.GetGenerationCacheDirInput()
+	outputs["generation_cache_key"] = key
+
+	return nil
+}
+
+func generationCacheKey(dir string) (string, error) {
+	hash := sha256.New()
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(hash, "%s:%d\n", relPath, info.Size())
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to compute generation cache key: %w", err)
+	}
+
+	return generationCacheKeyPrefix + hex.EncodeToString(hash.Sum(nil))[:16], nil
+}
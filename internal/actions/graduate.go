@@ -0,0 +1,26 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// Graduate regenerates the SDKs at their stable version then commits, tags and releases them in one run, by running the
+// workflow directly on the main branch with the version set to graduate_version or the SDKs' graduated version
+func Graduate() error {
+	overrides := map[string]string{
+		"INPUT_MODE":         string(environment.ModeDirect),
+		"INPUT_FORCE":        "true",
+		"INPUT_VERSION_BUMP": "",
+		"INPUT_SET_VERSION":  environment.GetGraduateVersion(),
+	}
+	for name, value := range overrides {
+		if err := os.Setenv(name, value); err != nil {
+			return fmt.Errorf("failed to set %s for graduation: %w", name, err)
+		}
+	}
+
+	return RunWorkflow()
+}
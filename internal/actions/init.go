@@ -3,20 +3,61 @@ package actions
 import (
 	"errors"
 
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
 	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
 	"github.com/speakeasy-api/sdk-generation-action/internal/git"
 )
 
 func initAction() (*git.Git, error) {
+	g, err := initGit()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.CloneRepo(); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// initGit creates the GitHub client without cloning the repo
+func initGit() (*git.Git, error) {
 	accessToken := environment.GetAccessToken()
 	if accessToken == "" {
 		return nil, errors.New("github access token is required")
 	}
 
 	g := git.New(accessToken)
-	if err := g.CloneRepo(); err != nil {
-		return nil, err
+
+	// Token permissions are checked through the GitHub API
+	if required := requiredPermissions(); len(required) > 0 && !environment.IsTestMode() && ci.IsGitHub() {
+		if err := g.CheckTokenPermissions(required); err != nil {
+			return nil, err
+		}
 	}
 
 	return g, nil
 }
+
+// requiredPermissions returns the token permissions needed by the current action
+func requiredPermissions() []git.Permission {
+	switch environment.GetAction() {
+	case environment.ActionRunWorkflow:
+		if environment.PushCodeSamplesOnly() {
+			return nil
+		}
+		if environment.GetMode() == environment.ModePR {
+			return []git.Permission{git.PermissionContentsWrite, git.PermissionPullRequestsWrite}
+		}
+		return []git.Permission{git.PermissionContentsWrite}
+	case environment.ActionSuggest, environment.ActionFinalizeSuggestion:
+		return []git.Permission{git.PermissionContentsWrite, git.PermissionPullRequestsWrite}
+	case environment.ActionGraduate:
+		return []git.Permission{git.PermissionContentsWrite}
+	case environment.ActionRelease, environment.ActionPublishEvent:
+		return []git.Permission{git.PermissionContentsWrite}
+	}
+
+	return nil
+}
@@ -35,6 +35,11 @@ func LogActionResult() error {
 		serverURL = s
 	}
 
+	if environment.TelemetryDisabled() {
+		fmt.Println("telemetry disabled, not logging action result.")
+		return nil
+	}
+
 	key := os.Getenv("SPEAKEASY_API_KEY")
 	if key == "" {
 		fmt.Print("no SPEAKEASY_API_KEY provided.")
@@ -0,0 +1,48 @@
+package actions
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+//go:embed matchers/speakeasy.json
+var problemMatchers []byte
+
+// registerProblemMatchers writes the Speakeasy problem matchers to the workspace and registers them with the runner so
+// validation errors and warnings from the CLI output are surfaced as annotations. The returned func unregisters them.
+func registerProblemMatchers() func() {
+	// Problem matchers are specific to GitHub Actions
+	if !ci.IsGitHub() {
+		return func() {}
+	}
+
+	matcherPath := filepath.Join(environment.GetWorkspace(), ".speakeasy-matchers.json")
+	if err := os.WriteFile(matcherPath, problemMatchers, 0o644); err != nil {
+		logging.Debug("failed to write problem matchers: %v", err)
+		return func() {}
+	}
+
+	fmt.Printf("::add-matcher::%s\n", matcherPath)
+
+	return func() {
+		var matchers struct {
+			ProblemMatcher []struct {
+				Owner string `json:"owner"`
+			} `json:"problemMatcher"`
+		}
+		if err := json.Unmarshal(problemMatchers, &matchers); err != nil {
+			return
+		}
+
+		for _, matcher := range matchers.ProblemMatcher {
+			fmt.Printf("::remove-matcher owner=%s::\n", matcher.Owner)
+		}
+	}
+}
@@ -1,12 +1,23 @@
 package actions
 
 import (
-	"encoding/base64"
+	"encoding/json"
 	"fmt"
-	"os"
+	"math"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
+	"github.com/speakeasy-api/sdk-generation-action/internal/cli"
+	"github.com/speakeasy-api/sdk-generation-action/internal/coverage"
+	"github.com/speakeasy-api/sdk-generation-action/internal/document"
 	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
-	"golang.org/x/exp/rand"
+	"github.com/speakeasy-api/sdk-generation-action/internal/metrics"
+	"github.com/speakeasy-api/sdk-generation-action/internal/tracing"
+	"github.com/speakeasy-api/sdk-generation-action/pkg/releases"
 
 	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
 )
@@ -14,52 +25,216 @@ import (
 func setOutputs(outputs map[string]string) error {
 	logging.Info("Setting outputs:")
 
-	outputFile := os.Getenv("GITHUB_OUTPUT")
+	if len(metrics.Durations()) > 0 {
+		durations, err := metrics.DurationsJSON()
+		if err != nil {
+			return err
+		}
+		outputs["durations"] = durations
+	}
 
-	f, err := os.OpenFile(outputFile, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0o600)
-	if err != nil {
-		return fmt.Errorf("error opening output file: %w", err)
+	if err := addGenerationCacheOutputs(outputs); err != nil {
+		logging.Info("failed to set generation cache outputs: %v", err)
 	}
-	defer f.Close()
 
-	for k, v := range outputs {
-		if k == "cli_output" {
-			delimiter, err := randomDelimiter()
-			if err != nil {
-				return err
-			}
+	outputs["telemetry_enabled"] = strconv.FormatBool(!environment.TelemetryDisabled())
+
+	if traceID := tracing.TraceID(); traceID != "" {
+		outputs["trace_id"] = traceID
+	}
+
+	return ci.Current().SetOutputs(outputs)
+}
 
-			err = printAndWriteString(f, fmt.Sprintf("%s<<%s\n%s\n%s\n", k, delimiter, v, delimiter))
-			if err != nil {
-				return err
+// addDefaultLanguageOutputs ensures every per-language output is present so downstream publishing jobs
+// can rely on a stable set of outputs regardless of which languages were generated.
+func addDefaultLanguageOutputs(outputs map[string]string) {
+	for _, lang := range cli.GetSupportedLanguages() {
+		defaults := map[string]string{
+			fmt.Sprintf("publish_%s", lang):     "false",
+			fmt.Sprintf("%s_regenerated", lang): "false",
+			fmt.Sprintf("%s_directory", lang):   "",
+			fmt.Sprintf("%s_version", lang):     "",
+		}
+
+		for k, v := range defaults {
+			if _, ok := outputs[k]; !ok {
+				outputs[k] = v
 			}
+		}
+	}
+}
+
+// testPyPIRepositoryURL is the upload URL of TestPyPI, the index canary releases of Python SDKs are published to
+const testPyPIRepositoryURL = "https://test.pypi.org/legacy/"
+
+// addReleaseChannelOutputs sets the outputs directing the publishing jobs to the registries of the release channel
+func addReleaseChannelOutputs(outputs map[string]string) {
+	channel := environment.GetReleaseChannel()
+
+	outputs["release_channel"] = string(channel)
+	outputs["npm_dist_tag"] = channel.NPMDistTag()
+	outputs["pypi_repository_url"] = ""
+	if channel == environment.ReleaseChannelCanary {
+		outputs["pypi_repository_url"] = testPyPIRepositoryURL
+	}
+}
+
+type targetOutput struct {
+	Lang      string `json:"lang"`
+	Dir       string `json:"dir"`
+	Version   string `json:"version"`
+	Published bool   `json:"published"`
+}
+
+// addTargetsOutput sets the `targets` output to a JSON array of the generated languages that can be used
+// directly as a job matrix via fromJSON.
+func addTargetsOutput(outputs map[string]string, generated map[string]releases.GenerationInfo) error {
+	targets := []targetOutput{}
+	for lang, info := range generated {
+		targets = append(targets, targetOutput{
+			Lang:      lang,
+			Dir:       info.Path,
+			Version:   info.Version,
+			Published: outputs[fmt.Sprintf("publish_%s", lang)] == "true",
+		})
+	}
+
+	slices.SortFunc(targets, func(a, b targetOutput) int {
+		return strings.Compare(a.Lang, b.Lang)
+	})
+
+	data, err := json.Marshal(targets)
+	if err != nil {
+		return fmt.Errorf("error marshalling targets output: %w", err)
+	}
+
+	outputs["targets"] = string(data)
+
+	return nil
+}
+
+// addResolvedDocumentOutputs sets the path and checksum of the final documents generated from, so later steps can use
+// exactly the same documents. openapi_doc and openapi_doc_checksum are set when there's a single source.
+func addResolvedDocumentOutputs(outputs map[string]string, wf *workflow.Workflow) error {
+	sourceIDs := map[string]bool{}
+	for targetID, target := range wf.Targets {
+		if specified := environment.SpecifiedTarget(); specified != "" && specified != "all" && specified != targetID {
+			continue
+		}
+		sourceIDs[target.Source] = true
+	}
+	if len(wf.Targets) == 0 {
+		for sourceID := range wf.Sources {
+			sourceIDs[sourceID] = true
+		}
+	}
+
+	resolved := map[string]document.ResolvedDocument{}
+	for sourceID := range sourceIDs {
+		source, ok := wf.Sources[sourceID]
+		if !ok {
 			continue
 		}
-		err = printAndWriteString(f, fmt.Sprintf("%s=%s\n", k, v))
+
+		doc, err := document.GetResolvedDocument(source)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to resolve document of source %s: %w", sourceID, err)
+		}
+		if doc != nil {
+			resolved[sourceID] = *doc
 		}
 	}
 
-	return nil
-}
+	if len(resolved) == 0 {
+		return nil
+	}
 
-func printAndWriteString(f *os.File, out string) error {
-	fmt.Print(out)
-	// Don't persist outputs to GH actions if we are in test mode
-	if !environment.IsTestMode() {
-		if _, err := f.WriteString(out); err != nil {
-			return fmt.Errorf("error writing output: %w", err)
+	resolvedJSON, err := json.Marshal(resolved)
+	if err != nil {
+		return fmt.Errorf("failed to marshal resolved documents: %w", err)
+	}
+	outputs["openapi_docs"] = string(resolvedJSON)
+
+	if len(resolved) == 1 {
+		for _, doc := range resolved {
+			outputs["openapi_doc"] = doc.Path
+			outputs["openapi_doc_checksum"] = doc.Checksum
 		}
 	}
+
 	return nil
 }
 
-func randomDelimiter() (string, error) {
-	b := make([]byte, 15)
-	_, err := rand.Read(b)
+// addCoverageOutputs measures how many of the operations in the documents the targets were generated from have a
+// method in the SDKs, setting the coverage across every target and a report for each and adding them to the job summary
+func addCoverageOutputs(outputs map[string]string, wf *workflow.Workflow, failedTargets map[string]error) error {
+	reports := map[string]targetCoverage{}
+	operations, covered := 0, 0
+
+	for targetID, target := range wf.Targets {
+		if specified := environment.SpecifiedTarget(); specified != "" && specified != "all" && specified != targetID {
+			continue
+		}
+		if _, failed := failedTargets[targetID]; failed || !coverage.Supported(target.Target) || slices.Contains(environment.GetServerStubTargets(), targetID) {
+			continue
+		}
+		source, ok := wf.Sources[target.Source]
+		if !ok {
+			continue
+		}
+
+		doc, err := document.GetResolvedDocument(source)
+		if err != nil {
+			return fmt.Errorf("failed to resolve document of source %s: %w", target.Source, err)
+		}
+		if doc == nil {
+			continue
+		}
+		docPath := doc.Path
+		if !filepath.IsAbs(docPath) {
+			docPath = filepath.Join(environment.GetWorkspace(), docPath)
+		}
+
+		dir := "."
+		if target.Output != nil {
+			dir = *target.Output
+		}
+		outputDir := filepath.Join(environment.GetRepoDir(), environment.GetWorkingDirectory(), dir)
+
+		report, err := coverage.Measure(docPath, target.Target, outputDir)
+		if err != nil {
+			return fmt.Errorf("failed to measure coverage of target %s: %w", targetID, err)
+		}
+		if report == nil {
+			continue
+		}
+		reports[targetID] = targetCoverage{Lang: target.Target, Report: report}
+		operations += report.Operations
+		covered += report.Covered
+	}
+
+	if len(reports) == 0 {
+		return nil
+	}
+
+	reportJSON, err := json.Marshal(reports)
 	if err != nil {
-		return "", fmt.Errorf("error generating random delimiter: %w", err)
+		return fmt.Errorf("failed to marshal coverage report: %w", err)
 	}
-	return base64.StdEncoding.EncodeToString(b), nil
+	outputs["coverage_report"] = string(reportJSON)
+
+	percentage := 100.0
+	if operations > 0 {
+		percentage = math.Round(float64(covered)/float64(operations)*1000) / 10
+	}
+	outputs["coverage"] = strconv.FormatFloat(percentage, 'f', 1, 64)
+
+	targetIDs := make([]string, 0, len(reports))
+	for targetID := range reports {
+		targetIDs = append(targetIDs, targetID)
+	}
+	slices.Sort(targetIDs)
+
+	return appendJobSummary(formatCoverageSummary(targetIDs, reports))
 }
@@ -0,0 +1,48 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/speakeasy-api/sdk-generation-action/pkg/releases"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddTargetsOutput(t *testing.T) {
+	outputs := map[string]string{
+		"publish_typescript": "true",
+		"publish_go":         "false",
+	}
+
+	err := addTargetsOutput(outputs, map[string]releases.GenerationInfo{
+		"typescript": {Version: "1.2.3", Path: "typescript"},
+		"go":         {Version: "0.4.0", Path: "."},
+	})
+	require.NoError(t, err)
+	require.Equal(t, `[{"lang":"go","dir":".","version":"0.4.0","published":false},{"lang":"typescript","dir":"typescript","version":"1.2.3","published":true}]`, outputs["targets"])
+
+	err = addTargetsOutput(outputs, nil)
+	require.NoError(t, err)
+	require.Equal(t, `[]`, outputs["targets"])
+}
+
+func TestAddReleaseChannelOutputs(t *testing.T) {
+	t.Setenv("INPUT_PROMOTE", "")
+	t.Setenv("INPUT_RELEASE_CHANNEL", "canary")
+
+	outputs := map[string]string{}
+	addReleaseChannelOutputs(outputs)
+	require.Equal(t, map[string]string{
+		"release_channel":     "canary",
+		"npm_dist_tag":        "next",
+		"pypi_repository_url": "https://test.pypi.org/legacy/",
+	}, outputs)
+
+	t.Setenv("INPUT_PROMOTE", "true")
+
+	addReleaseChannelOutputs(outputs)
+	require.Equal(t, map[string]string{
+		"release_channel":     "stable",
+		"npm_dist_tag":        "latest",
+		"pypi_repository_url": "",
+	}, outputs)
+}
@@ -2,10 +2,17 @@ package actions
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"path"
+	"slices"
 	"strings"
 
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/git"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
 	"github.com/speakeasy-api/sdk-generation-action/internal/telemetry"
+	"github.com/speakeasy-api/sdk-generation-action/pkg/releases"
 )
 
 func PublishEventAction() error {
@@ -16,12 +23,71 @@ func PublishEventAction() error {
 
 	version, err := telemetry.TriggerPublishingEvent(os.Getenv("INPUT_TARGET_DIRECTORY"), os.Getenv("GH_ACTION_RESULT"), os.Getenv("INPUT_REGISTRY_NAME"))
 	if version != "" {
-		if strings.Contains(os.Getenv("GH_ACTION_RESULT"), "success") {
+		published := strings.Contains(os.Getenv("GH_ACTION_RESULT"), "success")
+		if published {
 			if err = g.SetReleaseToPublished(version, os.Getenv("INPUT_TARGET_DIRECTORY")); err != nil {
 				fmt.Println("Failed to set release to published %w", err)
 			}
+			if err := recordPublishedRelease(g, version, os.Getenv("INPUT_TARGET_DIRECTORY")); err != nil {
+				fmt.Printf("Failed to record release in RELEASES.md: %v\n", err)
+			}
+		}
+
+		if environment.TrackDeployments() && os.Getenv("INPUT_REGISTRY_NAME") != "" {
+			state := git.DeploymentStateFailure
+			if published {
+				state = git.DeploymentStateSuccess
+			}
+
+			if err := g.SetDeploymentState(os.Getenv("INPUT_REGISTRY_NAME"), git.ReleaseTag(version, os.Getenv("INPUT_TARGET_DIRECTORY")), state, ""); err != nil {
+				fmt.Printf("Failed to update deployment: %v\n", err)
+			}
 		}
 	}
 
 	return err
 }
+
+// maxRecordAttempts limits the attempts to push the RELEASES.md entry of a published release, which fail when the
+// publishing jobs of the release's other SDKs push at the same time
+const maxRecordAttempts = 3
+
+// deferredReleases returns the languages of the release deferred by release_after_publish until they're published
+func deferredReleases(releaseInfo releases.ReleasesInfo, outputs map[string]string) []string {
+	deferred := []string{}
+	for _, lang := range slices.Sorted(maps.Keys(releaseInfo.Languages)) {
+		if git.ReleasedAfterPublish(lang, outputs) {
+			deferred = append(deferred, lang)
+		}
+	}
+
+	return deferred
+}
+
+// recordPublishedRelease marks the SDK released at the version from the directory as published when its release was
+// deferred by release_after_publish, committing the release's RELEASES.md entry once all of its SDKs are published.
+// The commit skips CI so it doesn't trigger the publishing workflow again.
+func recordPublishedRelease(g *git.Git, version, directory string) error {
+	releasesDir, err := getReleasesDir()
+	if err != nil {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		updated, err := releases.PublishPendingRelease(version, directory, releasesDir)
+		if err != nil || !updated {
+			return err
+		}
+
+		message := fmt.Sprintf("ci: record the publishing of %s [skip ci]", git.ReleaseTag(version, directory))
+		_, err = g.CommitFilesAndPush(message, path.Join(releasesDir, "RELEASES.md"), path.Join(releasesDir, releases.PendingReleasesFile))
+		if err == nil || attempt == maxRecordAttempts {
+			return err
+		}
+
+		logging.Info("Failed to push the publishing of %s, retrying on top of the latest changes: %v", git.ReleaseTag(version, directory), err)
+		if err := g.CloneRepo(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package actions
+
+import (
+	"maps"
+	"sort"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/git"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+	"github.com/speakeasy-api/sdk-generation-action/pkg/releases"
+)
+
+// recoverReleases creates the releases of the last generation that are missing, e.g. when a previous run pushed its
+// changes but failed to create the releases. Later runs would otherwise never retry them as there's nothing to
+// regenerate. The releases are created at the commit that last changed RELEASES.md or its pending entries, the
+// generation commit.
+func recoverReleases(g *git.Git) error {
+	releaseInfo, err := getReleasesInfo()
+	if err != nil {
+		// Sources only workflows and repos that were never generated have no releases
+		logging.Debug("failed to read the releases of the last generation: %v", err)
+		return nil
+	}
+
+	tags, err := g.RemoteTags()
+	if err != nil {
+		return err
+	}
+
+	missing := missingReleases(*releaseInfo, tags)
+	if len(missing.Languages) == 0 {
+		return nil
+	}
+
+	releasesDir, err := getReleasesDir()
+	if err != nil {
+		return err
+	}
+	commitHash, err := lastGenerationCommit(g, releasesDir)
+	if err != nil {
+		return err
+	}
+	headHash, err := g.HeadCommit()
+	if err != nil {
+		return err
+	}
+
+	// Terraform releases are built from the checked out code
+	if _, ok := missing.Languages["terraform"]; ok && commitHash != headHash {
+		logging.Info("Not recreating the terraform release of the last generation as commit %s has been followed by others, release it with the release action", commitHash)
+		delete(missing.Languages, "terraform")
+		if len(missing.Languages) == 0 {
+			return nil
+		}
+	}
+
+	missingTags := make([]string, 0, len(missing.Languages))
+	for _, info := range missing.Languages {
+		missingTags = append(missingTags, git.ReleaseTag(info.Version, info.Path))
+	}
+	sort.Strings(missingTags)
+	logging.Info("The releases %s of the last generation are missing, creating them at commit %s", strings.Join(missingTags, ", "), commitHash)
+
+	return g.CreateReleaseAt(missing, map[string]string{}, commitHash)
+}
+
+// missingReleases returns the release info with only the languages whose release tags don't exist
+func missingReleases(releaseInfo releases.ReleasesInfo, tags map[string]string) releases.ReleasesInfo {
+	releaseInfo.Languages = maps.Clone(releaseInfo.Languages)
+	for lang, info := range releaseInfo.Languages {
+		if tags[git.ReleaseTag(info.Version, info.Path)] != "" {
+			delete(releaseInfo.Languages, lang)
+		}
+	}
+
+	return releaseInfo
+}
@@ -0,0 +1,23 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/speakeasy-api/sdk-generation-action/pkg/releases"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingReleases(t *testing.T) {
+	releaseInfo := releases.ReleasesInfo{
+		ReleaseTitle: "2024-01-01 00:00:00",
+		Languages: map[string]releases.LanguageReleaseInfo{
+			"go":         {Version: "1.2.0", Path: "go"},
+			"typescript": {Version: "0.4.1", Path: "typescript"},
+		},
+	}
+
+	missing := missingReleases(releaseInfo, map[string]string{"go/v1.2.0": "abc"})
+	assert.Equal(t, "2024-01-01 00:00:00", missing.ReleaseTitle)
+	assert.Equal(t, map[string]releases.LanguageReleaseInfo{"typescript": {Version: "0.4.1", Path: "typescript"}}, missing.Languages)
+	assert.Len(t, releaseInfo.Languages, 2)
+}
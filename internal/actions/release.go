@@ -2,9 +2,12 @@ package actions
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/speakeasy-api/sdk-generation-action/internal/cli"
@@ -12,6 +15,7 @@ import (
 	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
 	"github.com/speakeasy-api/sdk-generation-action/internal/git"
 	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+	"github.com/speakeasy-api/sdk-generation-action/internal/metrics"
 	"github.com/speakeasy-api/sdk-generation-action/internal/run"
 	"github.com/speakeasy-api/sdk-generation-action/pkg/releases"
 )
@@ -64,7 +68,7 @@ func Release() error {
 
 	var latestRelease *releases.ReleasesInfo
 	if usingReleasesMd {
-		latestRelease, err = releases.GetLastReleaseInfo(dir)
+		latestRelease, err = releases.GetLatestReleaseInfo(dir)
 		if err != nil {
 			return err
 		}
@@ -79,15 +83,51 @@ func Release() error {
 	for lang, info := range latestRelease.Languages {
 		outputs[fmt.Sprintf("%s_regenerated", lang)] = "true"
 		outputs[fmt.Sprintf("%s_directory", lang)] = info.Path
+		outputs[fmt.Sprintf("%s_version", lang)] = info.Version
 	}
 
 	if err = addPublishOutputs(dir, outputs); err != nil {
 		return err
 	}
 
-	if err := g.CreateRelease(*latestRelease, outputs); err != nil {
+	addDefaultLanguageOutputs(outputs)
+	addReleaseChannelOutputs(outputs)
+
+	if err = addTargetsOutput(outputs, latestRelease.LanguagesGenerated); err != nil {
+		return err
+	}
+
+	// Release notes templates are passed the generator changelog, which is read using the CLI
+	if environment.GetReleaseNotesTemplate() != "" {
+		if _, err := cli.Download("latest", g); err != nil {
+			return err
+		}
+	}
+
+	_, frozen, until, err := releaseFreeze()
+	if err != nil {
+		return err
+	}
+	if frozen {
+		lines := []string{"The release of the following SDKs was held back, re-run the workflow run after the freeze to release them.", "", "| Language | Version | Directory |", "| --- | --- | --- |"}
+		for _, lang := range slices.Sorted(maps.Keys(latestRelease.Languages)) {
+			info := latestRelease.Languages[lang]
+			lines = append(lines, fmt.Sprintf("| %s | %s | %s |", lang, info.Version, info.Path))
+		}
+
+		// The publish outputs aren't set as nothing is released
+		heldOutputs, err := frozenOutputs(g, until, lines)
+		if err != nil {
+			return err
+		}
+		return setOutputs(heldOutputs)
+	}
+
+	stopTimer := metrics.Time("publish")
+	if err := releaseSDKs(g, latestRelease, outputs); err != nil {
 		return err
 	}
+	stopTimer()
 
 	if err = setOutputs(outputs); err != nil {
 		return err
@@ -102,6 +142,43 @@ func Release() error {
 	return nil
 }
 
+// sdkReleaser creates the releases of the SDKs and publishes them where the host's releases don't, implemented by
+// *git.Git
+type sdkReleaser interface {
+	CreateRelease(releaseInfo releases.ReleasesInfo, outputs map[string]string) error
+	PublishUPMRelease(version, directory string) (string, error)
+	AppendToReleaseBody(version, directory, line string) error
+	GetUpstreamSpecContributors(specLocations []string, until time.Time) ([]string, error)
+	HeadCommit() (string, error)
+	CommitTime(hash string) (time.Time, error)
+}
+
+// releaseSDKs creates the releases of the SDKs followed by the steps that publish them from the release, for both the
+// release action and direct mode
+func releaseSDKs(g sdkReleaser, releaseInfo *releases.ReleasesInfo, outputs map[string]string) error {
+	addSpecContributors(g, releaseInfo)
+
+	if err := g.CreateRelease(*releaseInfo, outputs); err != nil {
+		return err
+	}
+
+	if info, ok := releaseInfo.Languages["csharp"]; ok && environment.GetUnityPackageName() != "" {
+		tag, err := g.PublishUPMRelease(info.Version, info.Path)
+		if err != nil {
+			return err
+		}
+		outputs["upm_tag"] = tag
+	}
+
+	if info, ok := releaseInfo.Languages["swift"]; ok {
+		if err := publishCocoaPods(g, info, outputs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func GetDirAndShouldUseReleasesMD(files []string, dir string, usingReleasesMd bool) (string, bool) {
 	for _, file := range files {
 		// Maintain Support for RELEASES.MD for backward compatibility with existing publishing actions
@@ -114,6 +191,14 @@ func GetDirAndShouldUseReleasesMD(files []string, dir string, usingReleasesMd bo
 			break
 		}
 
+		// The entry of releases deferred by release_after_publish is pending until they're published
+		if strings.HasSuffix(file, releases.PendingReleasesFile) {
+			dir = filepath.Dir(filepath.Dir(file))
+			logging.Info("Found pending releases in %s\n", dir)
+			usingReleasesMd = true
+			break
+		}
+
 		if strings.Contains(file, "gen.lock") {
 			// file = .speakeasy/gen.lock
 			dir = filepath.Dir(file)
@@ -156,6 +241,40 @@ func addPublishOutputs(dir string, outputs map[string]string) error {
 	return nil
 }
 
+// addSpecContributors credits the authors of changes to OpenAPI docs hosted in other repos in the release notes, up to
+// the generation commit at HEAD
+func addSpecContributors(g sdkReleaser, releaseInfo *releases.ReleasesInfo) {
+	wf, err := configuration.GetWorkflowAndValidateLanguages(false)
+	if err != nil {
+		logging.Debug("failed to load workflow for crediting spec contributors: %v", err)
+		return
+	}
+
+	locations := []string{}
+	for _, source := range wf.Sources {
+		for _, input := range source.Inputs {
+			locations = append(locations, input.Location.Resolve())
+		}
+	}
+
+	until := environment.GetInvokeTime()
+	if head, err := g.HeadCommit(); err != nil {
+		logging.Debug("failed to get the generation commit for crediting spec contributors: %v", err)
+	} else if committed, err := g.CommitTime(head); err != nil {
+		logging.Debug("failed to get the generation commit for crediting spec contributors: %v", err)
+	} else {
+		until = committed
+	}
+
+	contributors, err := g.GetUpstreamSpecContributors(locations, until)
+	if err != nil {
+		logging.Info("Failed to get spec contributors: %s", err.Error())
+		return
+	}
+
+	releaseInfo.Contributors = contributors
+}
+
 func addCurrentBranchTagging(g *git.Git, latestRelease map[string]releases.LanguageReleaseInfo) error {
 	_, err := cli.Download("latest", g)
 	if err != nil {
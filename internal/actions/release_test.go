@@ -1,6 +1,13 @@
 package actions
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/speakeasy-api/sdk-generation-action/pkg/releases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
 
 func TestGetDirAndShouldUseReleasesMD(t *testing.T) {
 	type args struct {
@@ -74,6 +81,16 @@ func TestGetDirAndShouldUseReleasesMD(t *testing.T) {
 			want:  ".",
 			want1: true,
 		},
+		{
+			name: "pending releases found in subdirectory",
+			args: args{
+				files:           []string{"subdir/.speakeasy/gen.lock", "subdir/.speakeasy/pending-releases.json"},
+				dir:             ".",
+				usingReleasesMd: false,
+			},
+			want:  "subdir",
+			want1: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -87,3 +104,89 @@ func TestGetDirAndShouldUseReleasesMD(t *testing.T) {
 		})
 	}
 }
+
+type fakeReleaser struct {
+	released     []string
+	upmReleases  []string
+	releaseNotes []string
+}
+
+func (f *fakeReleaser) CreateRelease(releaseInfo releases.ReleasesInfo, outputs map[string]string) error {
+	for lang := range releaseInfo.Languages {
+		f.released = append(f.released, lang)
+	}
+	return nil
+}
+
+func (f *fakeReleaser) PublishUPMRelease(version, directory string) (string, error) {
+	f.upmReleases = append(f.upmReleases, directory)
+	return "upm/v" + version, nil
+}
+
+func (f *fakeReleaser) AppendToReleaseBody(version, directory, line string) error {
+	f.releaseNotes = append(f.releaseNotes, line)
+	return nil
+}
+
+func (f *fakeReleaser) GetUpstreamSpecContributors(specLocations []string, until time.Time) ([]string, error) {
+	return nil, nil
+}
+
+func (f *fakeReleaser) HeadCommit() (string, error) {
+	return "abc123", nil
+}
+
+func (f *fakeReleaser) CommitTime(hash string) (time.Time, error) {
+	return time.Unix(0, 0), nil
+}
+
+func TestReleaseSDKs(t *testing.T) {
+	releaseInfo := &releases.ReleasesInfo{Languages: map[string]releases.LanguageReleaseInfo{
+		"csharp": {Version: "1.2.0", Path: "csharp"},
+	}}
+
+	g := &fakeReleaser{}
+	outputs := map[string]string{}
+	require.NoError(t, releaseSDKs(g, releaseInfo, outputs))
+	assert.Equal(t, []string{"csharp"}, g.released)
+	assert.Empty(t, g.upmReleases)
+	assert.NotContains(t, outputs, "upm_tag")
+
+	t.Setenv("INPUT_UNITY_PACKAGE_NAME", "com.acme.petstore")
+
+	g = &fakeReleaser{}
+	outputs = map[string]string{}
+	require.NoError(t, releaseSDKs(g, releaseInfo, outputs))
+	assert.Equal(t, []string{"csharp"}, g.upmReleases)
+	assert.Equal(t, "upm/v1.2.0", outputs["upm_tag"])
+}
+
+func TestReleaseSDKs_CocoaPods(t *testing.T) {
+	releaseInfo := &releases.ReleasesInfo{Languages: map[string]releases.LanguageReleaseInfo{
+		"swift": {Version: "1.2.0", Path: "swift"},
+	}}
+	t.Setenv("INPUT_REPO_DIR", t.TempDir())
+	t.Setenv("INPUT_COCOAPODS_TRUNK_TOKEN", "token")
+
+	// The swift directory has no podspec to push
+	g := &fakeReleaser{}
+	outputs := map[string]string{}
+	require.NoError(t, releaseSDKs(g, releaseInfo, outputs))
+	assert.Equal(t, []string{"swift"}, g.released)
+	assert.NotContains(t, outputs, "cocoapods_url")
+	assert.Empty(t, g.releaseNotes)
+}
+
+func TestDeferredReleases(t *testing.T) {
+	releaseInfo := releases.ReleasesInfo{Languages: map[string]releases.LanguageReleaseInfo{
+		"go":         {Version: "1.2.0", Path: "go"},
+		"python":     {Version: "1.2.0", Path: "python"},
+		"typescript": {Version: "1.2.0", Path: "typescript"},
+	}}
+	outputs := map[string]string{"publish_go": "true", "publish_python": "false", "publish_typescript": "true"}
+
+	assert.Empty(t, deferredReleases(releaseInfo, outputs))
+
+	t.Setenv("INPUT_RELEASE_AFTER_PUBLISH", "true")
+	assert.Equal(t, []string{"typescript"}, deferredReleases(releaseInfo, outputs))
+}
@@ -0,0 +1,125 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/git"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// checksPollInterval is how often the checks of the generation commit are polled while waiting for them
+const checksPollInterval = 15 * time.Second
+
+// waitForRequiredChecks waits for the required checks of the commit pushed in direct mode to complete, up to
+// wait_for_checks_timeout, setting their outcome as outputs. Failing checks don't fail the run, the commit isn't
+// released and later jobs gate on the outputs instead.
+func waitForRequiredChecks(g *git.Git, sha string, outputs map[string]string) error {
+	if !ci.IsGitHub() {
+		logging.Info("Waiting for checks is only supported on GitHub, skipping")
+		return nil
+	}
+
+	required := environment.GetRequiredChecks()
+	if len(required) == 0 {
+		var err error
+		required, err = g.RequiredChecks()
+		if err != nil {
+			return err
+		}
+	}
+	if len(required) == 0 {
+		logging.Info("No checks are required on the branch, skipping waiting for checks")
+		outputs["checks_conclusion"] = "none"
+		return nil
+	}
+
+	timeout, err := environment.GetWaitForChecksTimeout()
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(timeout)
+
+	logging.Info("Waiting up to %s for the required checks of commit %s: %s", timeout, sha, strings.Join(required, ", "))
+
+	for {
+		conclusions, err := g.CheckConclusions(sha)
+		if err != nil {
+			return err
+		}
+
+		outcome, results, done := checksOutcome(required, conclusions)
+		if !done && !time.Now().Before(deadline) {
+			outcome, done = "timed_out", true
+		}
+		if done {
+			data, err := json.Marshal(results)
+			if err != nil {
+				return fmt.Errorf("failed to marshal check conclusions: %w", err)
+			}
+			outputs["checks_conclusion"] = outcome
+			outputs["checks"] = string(data)
+
+			logging.Info("The required checks of commit %s concluded with %s", sha, outcome)
+			return nil
+		}
+
+		time.Sleep(checksPollInterval)
+	}
+}
+
+// checksPassed returns whether the required checks waited for by waitForRequiredChecks allow releasing, i.e. they
+// succeeded, none are required or they weren't waited for
+func checksPassed(outputs map[string]string) bool {
+	switch outputs["checks_conclusion"] {
+	case "", "success", "none":
+		return true
+	default:
+		return false
+	}
+}
+
+// withholdPublishing stops the publishing jobs publishing the SDKs of a commit that isn't released
+func withholdPublishing(outputs map[string]string) {
+	for key := range outputs {
+		if strings.HasPrefix(key, "publish_") {
+			outputs[key] = "false"
+		}
+	}
+}
+
+// checksOutcome returns the outcome of the required checks given the conclusions of the commit's checks by name, the
+// conclusion of each required check and whether the outcome is final. Any failed check fails the outcome without
+// waiting for the others, checks that haven't been reported yet are pending.
+func checksOutcome(required []string, conclusions map[string]string) (string, map[string]string, bool) {
+	results := map[string]string{}
+	failed, pending := false, false
+	for _, name := range required {
+		conclusion, ok := conclusions[name]
+		if !ok {
+			conclusion = "pending"
+		}
+		results[name] = conclusion
+
+		switch conclusion {
+		case "success", "neutral", "skipped":
+		case "pending":
+			pending = true
+		default:
+			failed = true
+		}
+	}
+
+	if failed {
+		return "failure", results, true
+	}
+	if pending {
+		return "", results, false
+	}
+
+	return "success", results, true
+}
@@ -0,0 +1,39 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksOutcome(t *testing.T) {
+	required := []string{"build", "test"}
+
+	outcome, results, done := checksOutcome(required, map[string]string{"build": "success", "lint": "failure"})
+	assert.False(t, done)
+	assert.Equal(t, map[string]string{"build": "success", "test": "pending"}, results)
+
+	outcome, _, done = checksOutcome(required, map[string]string{"build": "success", "test": "skipped"})
+	assert.True(t, done)
+	assert.Equal(t, "success", outcome)
+
+	outcome, results, done = checksOutcome(required, map[string]string{"build": "pending", "test": "error"})
+	assert.True(t, done)
+	assert.Equal(t, "failure", outcome)
+	assert.Equal(t, map[string]string{"build": "pending", "test": "error"}, results)
+}
+
+func TestChecksPassed(t *testing.T) {
+	assert.True(t, checksPassed(map[string]string{}))
+	assert.True(t, checksPassed(map[string]string{"checks_conclusion": "success"}))
+	assert.True(t, checksPassed(map[string]string{"checks_conclusion": "none"}))
+	assert.False(t, checksPassed(map[string]string{"checks_conclusion": "failure"}))
+	assert.False(t, checksPassed(map[string]string{"checks_conclusion": "timed_out"}))
+}
+
+func TestWithholdPublishing(t *testing.T) {
+	outputs := map[string]string{"publish_python": "true", "publish_go": "false", "python_regenerated": "true"}
+
+	withholdPublishing(outputs)
+	assert.Equal(t, map[string]string{"publish_python": "false", "publish_go": "false", "python_regenerated": "true"}, outputs)
+}
@@ -0,0 +1,65 @@
+package actions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/git"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// alreadyGenerated returns whether the changes generated for the commit the run was triggered for are already at the
+// head of the branch they're committed to, e.g. when a successful run is re-run, so regenerating would bump the
+// versions again. That's the existing PR's branch in PR mode and the cloned branch otherwise. In direct mode the
+// releases of the generated versions must exist too, as re-running would fail to create their tags.
+func alreadyGenerated(g *git.Git, prBranch string) (bool, error) {
+	sha := environment.GetSHA()
+	if sha == "" || environment.ForceGeneration() {
+		return false, nil
+	}
+
+	var sourceCommit string
+	var err error
+	if prBranch != "" {
+		sourceCommit, err = g.BranchTrailer(prBranch, "Source-Commit")
+	} else {
+		sourceCommit, err = g.HeadTrailer("Source-Commit")
+	}
+	if err != nil {
+		return false, err
+	}
+	if sourceCommit != sha {
+		return false, nil
+	}
+
+	if environment.GetMode() != environment.ModeDirect {
+		return true, nil
+	}
+
+	releaseInfo, err := getReleasesInfo()
+	if err != nil {
+		// Sources only workflows have no releases
+		logging.Debug("failed to read the releases of the previous generation: %v", err)
+		return true, nil
+	}
+
+	tags, err := g.RemoteTags()
+	if err != nil {
+		return false, err
+	}
+
+	missing := []string{}
+	for _, info := range releaseInfo.Languages {
+		if tag := git.ReleaseTag(info.Version, info.Path); tags[tag] == "" {
+			missing = append(missing, tag)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return false, fmt.Errorf("the changes generated for commit %s were already committed by a previous run but not released, missing tags %s: enable resumable to retry publishing or set force to regenerate", sha, strings.Join(missing, ", "))
+	}
+
+	return true, nil
+}
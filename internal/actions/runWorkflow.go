@@ -1,40 +1,280 @@
 package actions
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
-	"github.com/google/go-github/v63/github"
 	"github.com/pkg/errors"
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
 	"github.com/speakeasy-api/sdk-generation-action/internal/versionbumps"
 	"github.com/speakeasy-api/versioning-reports/versioning"
 
+	"github.com/speakeasy-api/sdk-generation-action/internal/apisurface"
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
 	"github.com/speakeasy-api/sdk-generation-action/internal/configuration"
+	"github.com/speakeasy-api/sdk-generation-action/internal/document"
 	"github.com/speakeasy-api/sdk-generation-action/internal/git"
+	"github.com/speakeasy-api/sdk-generation-action/internal/pipeline"
 	"github.com/speakeasy-api/sdk-generation-action/internal/run"
 
 	"github.com/speakeasy-api/sdk-generation-action/internal/cli"
 	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
 	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+	"github.com/speakeasy-api/sdk-generation-action/internal/metrics"
 	"github.com/speakeasy-api/sdk-generation-action/pkg/releases"
 )
 
-func RunWorkflow() error {
-	g, err := initAction()
+// workflowRun is the state of a run of the workflow shared between the steps of its pipeline
+type workflowRun struct {
+	g           *git.Git
+	remoteSpecs document.RemoteSpecValidators
+	// postmanConversions are the checksums of the OpenAPI documents the workflow's Postman collections were converted to
+	postmanConversions document.PostmanConversions
+
+	unregisterProblemMatchers func()
+	checkRun                  *checkRun
+	checkRunOutputs           map[string]string
+
+	// unchanged is set when nothing has changed since the last generation but the run continues to recover its releases
+	unchanged bool
+
+	resolvedVersion string
+	wf              *workflow.Workflow
+	sourcesOnly     bool
+
+	// branchPrepared is set once the branch the changes are committed to is found or created, and deleted if the run
+	// doesn't succeed
+	branchPrepared bool
+	branchName     string
+	pr             *ci.PullRequest
+	// generatorUpgrade is set when the changes, caused only by a new Speakeasy CLI version, are committed to the
+	// generator upgrade PR
+	generatorUpgrade bool
+
+	runRes  *run.RunResult
+	outputs map[string]string
+	// failedTargets fail the run once the successfully generated targets are committed
+	failedTargets []string
+
+	anythingRegenerated bool
+	releaseInfo         releases.ReleasesInfo
+
+	// held is set when the run holds back its changes, e.g. until they're approved, so its status describes the SDKs
+	// as committed before generating
+	held          bool
+	committedSDKs sdkStatus
+
+	success bool
+}
+
+func RunWorkflow() (err error) {
+	r := &workflowRun{checkRunOutputs: map[string]string{}}
+	defer r.cleanup(&err)
+
+	return pipeline.Run([]pipeline.Step{
+		{Name: "resume", Run: r.resume},
+		{Name: "check_changes", Run: r.checkChanges},
+		{Name: "clone", Run: r.clone},
+		{Name: "recover_releases", Run: r.recoverReleases},
+		{Name: "check_rerun", Run: r.checkRerun},
+		{Name: "setup", Run: r.setup},
+		{Name: "load_workflow", Run: r.loadWorkflow},
+		{Name: "check_interval", Run: r.checkInterval},
+		{Name: "prepare_branch", Run: r.prepareBranch},
+		{Name: "generate", Run: r.generate},
+		{Name: "check_approval", Run: r.checkApproval},
+		{Name: "check_freeze", Run: r.checkFreeze},
+		{Name: "check_generator_upgrade", Run: r.checkGeneratorUpgrade},
+		{Name: "commit", Run: r.commit},
+		{Name: "finalize", Run: r.finalize},
+		{Name: "housekeeping", Run: r.housekeeping},
+	}, environment.GetStepHooks())
+}
+
+// cleanup fails the run for the targets that failed to generate, deletes the branch of unsuccessful runs, completes
+// the check run with the run's error and reports the status of the SDKs
+func (r *workflowRun) cleanup(err *error) {
+	if len(r.failedTargets) > 0 && *err == nil {
+		*err = fmt.Errorf("targets failed to generate: %s", strings.Join(r.failedTargets, ", "))
+	}
+
+	if r.branchPrepared && shouldDeleteBranch(r.success) {
+		if err := r.g.DeleteBranch(r.branchName); err != nil {
+			logging.Debug("failed to delete branch %s: %v", r.branchName, err)
+		}
+	}
+
+	if r.checkRun != nil {
+		r.checkRun.complete(*err, r.checkRunOutputs, checkRunAnnotations(r.runRes, r.wf))
+	}
+
+	if r.unregisterProblemMatchers != nil {
+		r.unregisterProblemMatchers()
+	}
+
+	var committed *sdkStatus
+	if r.held {
+		committed = &r.committedSDKs
+	}
+	reportStatus(r.g, *err, committed)
+}
+
+// resume resumes re-runs of a workflow run whose generated changes were already committed with the original outputs so
+// publishing can be retried without regenerating. In PR mode the changes are committed to the PR's branch, so re-runs
+// are resumed once it's found while preparing the branch.
+func (r *workflowRun) resume() error {
+	g, err := initGit()
+	if err != nil {
+		return err
+	}
+	r.g = g
+
+	if environment.GetMode() == environment.ModePR {
+		return nil
+	}
+
+	return r.resumeFrom(environment.GetRef())
+}
+
+// resumeFrom stops the pipeline with the outputs of the checkpoint committed to the branch at ref by a previous attempt
+// of this run
+func (r *workflowRun) resumeFrom(ref string) error {
+	if !environment.IsResumable() || environment.IsTestMode() {
+		return nil
+	}
+
+	outputs, err := readCheckpoint(r.g.GetFileContentsAt, ref)
+	if err != nil {
+		logging.Info("Failed to read checkpoint: %v", err)
+		return nil
+	}
+	if outputs == nil {
+		return nil
+	}
+
+	logging.Info("Resuming from the changes committed by a previous attempt of this run, skipping generation")
+	outputs["resumed"] = "true"
+	addDefaultLanguageOutputs(outputs)
+	if err := setOutputs(outputs); err != nil {
+		return err
+	}
+
+	return pipeline.ErrStop
+}
+
+// checkChanges ends scheduled runs if nothing has changed since the last generation, before cloning the repo outside of
+// direct mode
+func (r *workflowRun) checkChanges() error {
+	if !environment.IsScheduledRun() || environment.ForceGeneration() || environment.IsTestMode() {
+		return nil
+	}
+
+	res, err := checkNoChanges(r.g)
+	if err != nil {
+		logging.Info("Failed to check for changes since the last generation: %v", err)
+		return nil
+	}
+	if !res.NoChanges {
+		r.remoteSpecs = res.Validators
+		return nil
+	}
+
+	// Direct mode runs still clone the repo to recover the releases of the last generation
+	if environment.GetMode() == environment.ModeDirect {
+		r.unchanged = true
+		return nil
+	}
+
+	return skipUnchanged()
+}
+
+// skipUnchanged ends the run as nothing has changed since the last generation
+func skipUnchanged() error {
+	logging.Info("Nothing has changed since the last generation, skipping generation")
+	outputs := map[string]string{"no_changes": "true", "not_modified": "true"}
+	addDefaultLanguageOutputs(outputs)
+	if err := setOutputs(outputs); err != nil {
+		return err
+	}
+
+	return pipeline.ErrStop
+}
+
+func (r *workflowRun) clone() error {
+	if err := r.g.CloneRepo(); err != nil {
+		return err
+	}
+
+	r.unregisterProblemMatchers = registerProblemMatchers()
+	r.checkRun = startCheckRun(r.g)
+
+	return nil
+}
+
+// recoverReleases creates the missing releases of the last generation in direct mode, then ends the run if nothing has
+// changed since
+func (r *workflowRun) recoverReleases() error {
+	if environment.GetMode() == environment.ModeDirect && !environment.IsTestMode() && !environment.IsDryRun() {
+		if err := recoverReleases(r.g); err != nil {
+			return fmt.Errorf("failed to recover the releases of the last generation: %w", err)
+		}
+	}
+
+	if r.unchanged {
+		return skipUnchanged()
+	}
+
+	return nil
+}
+
+// checkRerun ends runs for a commit whose generated changes were already committed, e.g. re-runs of a successful run,
+// instead of bumping the versions again. In PR mode the changes are committed to the existing PR's branch, which
+// prepareBranch checks once it's found.
+func (r *workflowRun) checkRerun() error {
+	if environment.GetMode() == environment.ModePR {
+		return nil
+	}
+
+	return r.stopIfGenerated("")
+}
+
+func (r *workflowRun) stopIfGenerated(prBranch string) error {
+	generated, err := alreadyGenerated(r.g, prBranch)
 	if err != nil {
 		return err
 	}
+	if !generated {
+		return nil
+	}
 
+	logging.Info("The changes generated for commit %s were already committed, skipping generation", environment.GetSHA())
+	outputs := map[string]string{"already_generated": "true"}
+	addDefaultLanguageOutputs(outputs)
+	r.checkRunOutputs = outputs
+	if err := setOutputs(outputs); err != nil {
+		return err
+	}
+
+	return pipeline.ErrStop
+}
+
+// setup sets up the environment and downloads the CLI
+func (r *workflowRun) setup() error {
 	if err := SetupEnvironment(); err != nil {
 		return fmt.Errorf("failed to setup environment: %w", err)
 	}
 
 	// The top-level CLI can always use latest. The CLI itself manages pinned versions.
-	resolvedVersion, err := cli.Download("latest", g)
+	stopTimer := metrics.Time("download")
+	resolvedVersion, err := cli.Download("latest", r.g)
 	if err != nil {
 		return err
 	}
+	stopTimer()
 
 	// This flag is generally deprecated, it will not be provided on new action instances
 	pinnedVersion := cli.GetVersion(environment.GetPinnedSpeakeasyVersion())
@@ -45,74 +285,309 @@ func RunWorkflow() error {
 			return fmt.Errorf("failed to set pinned speakeasy version: %w", err)
 		}
 	}
+	r.resolvedVersion = resolvedVersion
 
-	mode := environment.GetMode()
+	return nil
+}
 
+// loadWorkflow loads the workflow and stages and checks its source documents
+func (r *workflowRun) loadWorkflow() error {
 	wf, err := configuration.GetWorkflowAndValidateLanguages(true)
 	if err != nil {
 		return err
 	}
 
-	sourcesOnly := wf.Targets == nil || len(wf.Targets) == 0
+	stopTimer := metrics.Time("validation")
+	staged, err := document.StageDocuments(wf, r.g.DownloadArtifactFile)
+	if err != nil {
+		return err
+	}
+
+	if err := document.CheckSourceDocuments(wf, staged); err != nil {
+		return err
+	}
+	stopTimer()
+	r.postmanConversions = staged.PostmanConversions
+
+	r.wf = wf
+	r.sourcesOnly = wf.Targets == nil || len(wf.Targets) == 0
 
-	branchName := ""
-	var pr *github.PullRequest
-	if mode == environment.ModePR {
-		var err error
-		branchName, pr, err = g.FindExistingPR("", environment.ActionRunWorkflow, sourcesOnly)
+	return nil
+}
+
+// prepareBranch finds the existing PR and checks out the branch the changes are committed to
+func (r *workflowRun) prepareBranch() error {
+	if environment.GetMode() == environment.ModePR {
+		branchName, pr, err := r.g.FindExistingPR("", environment.ActionRunWorkflow, r.sourcesOnly)
 		if err != nil {
 			return err
 		}
+		r.branchName = branchName
+		r.pr = pr
 
 		if pr != nil {
-			os.Setenv("GH_PULL_REQUEST", *pr.URL)
+			os.Setenv("GH_PULL_REQUEST", pr.URL)
+		}
+
+		if branchName != "" {
+			if err := r.resumeFrom(branchName); err != nil {
+				return err
+			}
+			if err := r.stopIfGenerated(branchName); err != nil {
+				return err
+			}
 		}
 	}
 
 	// We want to stay on main if we're pushing code samples because we want to tag the code samples with `main`
-	if !environment.PushCodeSamplesOnly() && !environment.IsTestMode() {
-		branchName, err = g.FindOrCreateBranch(branchName, environment.ActionRunWorkflow)
+	if !environment.PushCodeSamplesOnly() && !environment.IsTestMode() && !environment.IsDryRun() {
+		branchName, err := r.g.FindOrCreateBranch(r.branchName, environment.ActionRunWorkflow)
 		if err != nil {
 			return err
 		}
+		r.branchName = branchName
 	}
+	r.branchPrepared = true
 
-	success := false
-	defer func() {
-		if shouldDeleteBranch(success) {
-			if err := g.DeleteBranch(branchName); err != nil {
-				logging.Debug("failed to delete branch %s: %v", branchName, err)
-			}
-		}
-	}()
-
-	if branchName != "" {
-		os.Setenv("SPEAKEASY_ACTIVE_BRANCH", branchName)
+	if r.branchName != "" {
+		os.Setenv("SPEAKEASY_ACTIVE_BRANCH", r.branchName)
 	}
 
-	runRes, outputs, err := run.Run(g, pr, wf)
+	return nil
+}
+
+func (r *workflowRun) generate() error {
+	r.committedSDKs = readSDKStatus()
+
+	runRes, outputs, err := run.Run(r.g, r.pr, r.wf)
+	r.checkRunOutputs = outputs
 	if err != nil {
 		if err := setOutputs(outputs); err != nil {
 			logging.Debug("failed to set outputs: %v", err)
 		}
 		return err
 	}
+	r.runRes = runRes
+	r.outputs = outputs
+
+	if err := reportGenerationWarnings(runRes.GenerationWarnings); err != nil {
+		logging.Info("failed to report generation warnings: %v", err)
+	}
+
+	if len(runRes.FailedTargets) > 0 {
+		failedTargets := make([]string, 0, len(runRes.FailedTargets))
+		for targetID := range runRes.FailedTargets {
+			failedTargets = append(failedTargets, targetID)
+		}
+		sort.Strings(failedTargets)
+
+		failedTargetsJSON, err := json.Marshal(failedTargets)
+		if err != nil {
+			return fmt.Errorf("failed to marshal failed targets: %w", err)
+		}
+		outputs["failed_targets"] = string(failedTargetsJSON)
+
+		// The successfully generated targets are still committed before the run is failed
+		if environment.GetLanguageFailurePolicy() == environment.LanguageFailureContinue {
+			r.failedTargets = failedTargets
+		}
+	}
+
+	if err := addResolvedDocumentOutputs(outputs, r.wf); err != nil {
+		logging.Info("Failed to set resolved document outputs: %v", err)
+	}
+	if err := addCoverageOutputs(outputs, r.wf, runRes.FailedTargets); err != nil {
+		logging.Info("Failed to set coverage outputs: %v", err)
+	}
+
+	return nil
+}
+
+// checkApproval ends runs whose changes require a major version bump that isn't approved yet when major_bump_approval is
+// set, before the changes are committed
+func (r *workflowRun) checkApproval() error {
+	if environment.IsDryRun() || environment.IsTestMode() || !requiresMajorBumpApproval(r.runRes.VersioningInfo) {
+		return nil
+	}
+
+	// The outputs of the generation aren't set as the changes aren't committed
+	outputs := map[string]string{}
+	approved, err := checkMajorBumpApproval(r.g, r.runRes, outputs)
+	if err != nil {
+		return err
+	}
+	if approved {
+		return nil
+	}
+
+	logging.Info("The changes require a major version bump that hasn't been approved, skipping committing them")
+	outputs["approval_required"] = "true"
+	addDefaultLanguageOutputs(outputs)
+	r.checkRunOutputs = outputs
+	if err := setOutputs(outputs); err != nil {
+		return err
+	}
+	// The run held back the changes as configured, so the branch of an existing PR is kept
+	r.success = true
+	r.held = true
+
+	return pipeline.ErrStop
+}
+
+// checkFreeze ends direct mode runs during a release freeze before their changes are committed and released, queuing
+// them on the release freeze issue. The first run after the freeze generates them again and closes the issue.
+func (r *workflowRun) checkFreeze() error {
+	if environment.GetMode() != environment.ModeDirect || environment.IsDryRun() || environment.IsTestMode() || r.sourcesOnly || !anyRegenerated(r.outputs) {
+		return nil
+	}
+
+	configured, frozen, until, err := releaseFreeze()
+	if err != nil || !configured {
+		return err
+	}
+	if !frozen {
+		closeFreezeIssue(r.g)
+		return nil
+	}
+
+	lines := []string{"Changes were detected but not generated."}
+	if r.runRes.VersioningInfo.VersionReport != nil {
+		lines = append(lines, "")
+		lines = append(lines, versionBumpTable(r.runRes.VersioningInfo.VersionReport)...)
+	}
+
+	// The outputs of the generation aren't set as the changes aren't committed
+	outputs, err := frozenOutputs(r.g, until, lines)
+	if err != nil {
+		return err
+	}
+	addDefaultLanguageOutputs(outputs)
+	r.checkRunOutputs = outputs
+	if err := setOutputs(outputs); err != nil {
+		return err
+	}
+	r.success = true
+	r.held = true
+
+	return pipeline.ErrStop
+}
+
+// checkInterval ends runs within min_release_interval of the last generation before generating, so frequent changes
+// are batched into a single generation
+func (r *workflowRun) checkInterval() error {
+	if environment.ForceGeneration() || environment.IsDryRun() || environment.IsTestMode() || r.sourcesOnly {
+		return nil
+	}
+
+	interval, err := environment.GetMinReleaseInterval()
+	if err != nil || interval == 0 {
+		return err
+	}
+	last, err := lastGeneration(r.g)
+	if err != nil {
+		return err
+	}
+	next := nextGeneration(last, interval, environment.GetInvokeTime())
+	if next.IsZero() {
+		return nil
+	}
+
+	// Nothing is generated, so the status describes the SDKs as committed
+	r.committedSDKs = readSDKStatus()
+	logging.Info("The last generation was less than %s ago, batching the changes until %s", interval, next.Format(time.RFC1123))
+	// The outputs of the generation aren't set as nothing is generated
+	outputs := map[string]string{
+		"release_batched":    "true",
+		"next_release_after": next.Format(time.RFC3339),
+	}
+	addDefaultLanguageOutputs(outputs)
+	r.checkRunOutputs = outputs
+	if err := setOutputs(outputs); err != nil {
+		return err
+	}
+	r.success = true
+	r.held = true
+
+	return pipeline.ErrStop
+}
+
+// checkGeneratorUpgrade moves changes caused only by a new Speakeasy CLI version to the generator upgrade PR when
+// generator_upgrade_interval is set, so they're reviewed separately from changes to the OpenAPI document. Without an open
+// upgrade PR the changes are held back until the interval since the last one was opened has passed. Changes are left
+// on the branch of an open SDK update PR, which they're included in.
+func (r *workflowRun) checkGeneratorUpgrade() error {
+	if environment.GetMode() != environment.ModePR || environment.IsDryRun() || environment.IsTestMode() || environment.PushCodeSamplesOnly() || r.sourcesOnly || r.pr != nil || !r.runRes.GeneratorOnly {
+		return nil
+	}
+
+	interval, err := environment.GetGeneratorUpgradeInterval()
+	if err != nil || interval == 0 {
+		return err
+	}
+	if !ci.IsGitHub() {
+		logging.Info("Generator upgrade PRs are only supported on GitHub, committing the generator-only changes to the SDK update PR")
+		return nil
+	}
+
+	pr, last, err := r.g.FindGeneratorUpgradePR()
+	if err != nil {
+		return err
+	}
+
+	if pr == nil {
+		if next := nextGeneratorUpgrade(last, interval, environment.GetInvokeTime()); !next.IsZero() {
+			logging.Info("The last generator upgrade PR was opened less than %s ago, holding back the generator-only changes until %s", interval, next.Format(time.RFC1123))
+			// The outputs of the generation aren't set as the changes aren't committed
+			outputs := map[string]string{
+				"generator_upgrade_held":       "true",
+				"next_generator_upgrade_after": next.Format(time.RFC3339),
+			}
+			addDefaultLanguageOutputs(outputs)
+			r.checkRunOutputs = outputs
+			if err := setOutputs(outputs); err != nil {
+				return err
+			}
+			r.success = true
+			r.held = true
+
+			return pipeline.ErrStop
+		}
+	}
+
+	branchName, err := r.g.CheckoutGeneratorUpgradeBranch(pr)
+	if err != nil {
+		return err
+	}
+	r.branchName = branchName
+	r.pr = pr
+	r.generatorUpgrade = true
+	r.outputs["generator_upgrade"] = "true"
 
-	anythingRegenerated := false
+	os.Setenv("SPEAKEASY_ACTIVE_BRANCH", r.branchName)
+	if pr != nil {
+		os.Setenv("GH_PULL_REQUEST", pr.URL)
+	}
+
+	return nil
+}
+
+// commit records the release and commits and pushes the generated changes
+func (r *workflowRun) commit() error {
+	runRes, outputs := r.runRes, r.outputs
 
-	var releaseInfo releases.ReleasesInfo
 	if runRes.GenInfo != nil {
 		docVersion := runRes.GenInfo.OpenAPIDocVersion
-		resolvedVersion = runRes.GenInfo.SpeakeasyVersion
+		r.resolvedVersion = runRes.GenInfo.SpeakeasyVersion
 
-		releaseInfo = releases.ReleasesInfo{
+		r.releaseInfo = releases.ReleasesInfo{
 			ReleaseTitle:       environment.GetInvokeTime().Format("2006-01-02 15:04:05"),
 			DocVersion:         docVersion,
-			SpeakeasyVersion:   resolvedVersion,
+			SpeakeasyVersion:   r.resolvedVersion,
 			GenerationVersion:  runRes.GenInfo.GenerationVersion,
 			DocLocation:        environment.GetOpenAPIDocLocation(),
 			Languages:          map[string]releases.LanguageReleaseInfo{},
 			LanguagesGenerated: map[string]releases.GenerationInfo{},
+			APIChanges:         releaseAPIChanges(runRes.APISurfaceDiffs),
 		}
 
 		supportedLanguages := cli.GetSupportedLanguages()
@@ -120,18 +595,18 @@ func RunWorkflow() error {
 			langGenInfo, ok := runRes.GenInfo.Languages[lang]
 
 			if ok && outputs[fmt.Sprintf("%s_regenerated", lang)] == "true" {
-				anythingRegenerated = true
+				r.anythingRegenerated = true
 
 				path := outputs[fmt.Sprintf("%s_directory", lang)]
 				path = strings.TrimPrefix(path, "./")
 
-				releaseInfo.LanguagesGenerated[lang] = releases.GenerationInfo{
+				r.releaseInfo.LanguagesGenerated[lang] = releases.GenerationInfo{
 					Version: langGenInfo.Version,
 					Path:    path,
 				}
 
 				if published, ok := outputs[fmt.Sprintf("publish_%s", lang)]; ok && published == "true" {
-					releaseInfo.Languages[lang] = releases.LanguageReleaseInfo{
+					r.releaseInfo.Languages[lang] = releases.LanguageReleaseInfo{
 						PackageName: langGenInfo.PackageName,
 						Version:     langGenInfo.Version,
 						Path:        path,
@@ -140,9 +615,58 @@ func RunWorkflow() error {
 			}
 		}
 
+		// Server stubs aren't released but their changes are committed with the SDKs'
+		if len(runRes.GenInfo.ServerStubs) > 0 {
+			r.anythingRegenerated = true
+		}
+
+		if err := addTargetsOutput(outputs, r.releaseInfo.LanguagesGenerated); err != nil {
+			return err
+		}
+
 		if environment.PushCodeSamplesOnly() {
 			// If we're just pushing code samples we don't want to raise a PR
-			return nil
+			return pipeline.ErrStop
+		}
+
+		if environment.IsDryRun() {
+			outputs["resolved_speakeasy_version"] = r.resolvedVersion
+			if environment.UpdateDigestIssue() && r.anythingRegenerated {
+				issueURL, err := appendToDigestIssue(r.g, runRes, r.releaseInfo.LanguagesGenerated)
+				if err != nil {
+					return err
+				}
+				outputs["digest_issue_url"] = issueURL
+			}
+			return stopAfter(finishDryRun(outputs))
+		}
+
+		if r.anythingRegenerated && r.remoteSpecs != nil {
+			if err := document.SaveRemoteSpecValidators(r.remoteSpecs); err != nil {
+				return err
+			}
+		}
+
+		if r.anythingRegenerated && len(r.postmanConversions) > 0 {
+			if err := document.SavePostmanConversions(r.postmanConversions); err != nil {
+				return err
+			}
+		}
+
+		// The spec changes are only rendered by release notes templates
+		if r.anythingRegenerated && environment.GetReleaseNotesTemplate() != "" {
+			if err := releases.SaveSpecChanges(releases.SpecChanges{
+				Summary:   runRes.OpenAPIChangeSummary,
+				ReportURL: runRes.ChangesReportURL,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if environment.IsResumable() {
+			if err := writeCheckpoint(outputs); err != nil {
+				return err
+			}
 		}
 
 		releasesDir, err := getReleasesDir()
@@ -150,66 +674,196 @@ func RunWorkflow() error {
 			return err
 		}
 
-		if err := releases.UpdateReleasesFile(releaseInfo, releasesDir); err != nil {
+		// The entry of releases deferred by release_after_publish is added to RELEASES.md once they're published
+		if deferred := deferredReleases(r.releaseInfo, outputs); len(deferred) > 0 {
+			if err := releases.AddPendingRelease(r.releaseInfo, deferred, releasesDir); err != nil {
+				return err
+			}
+		} else if err := releases.UpdateReleasesFile(r.releaseInfo, releasesDir); err != nil {
 			return err
 		}
 
-		if _, err := g.CommitAndPush(docVersion, resolvedVersion, "", environment.ActionRunWorkflow, false); err != nil {
+		if r.anythingRegenerated {
+			if err := updateCatalogInfo(r.releaseInfo); err != nil {
+				return err
+			}
+		}
+
+		generatedLanguages := make([]string, 0, len(r.releaseInfo.LanguagesGenerated))
+		for lang := range r.releaseInfo.LanguagesGenerated {
+			generatedLanguages = append(generatedLanguages, lang)
+		}
+		sort.Strings(generatedLanguages)
+
+		trailers := &git.CommitTrailers{
+			SpeakeasyVersion:   r.resolvedVersion,
+			OpenAPIDocVersion:  docVersion,
+			OpenAPIDocChecksum: runRes.GenInfo.OpenAPIDocChecksum,
+			GeneratedLanguages: generatedLanguages,
+			SourceCommit:       environment.GetSHA(),
+		}
+
+		if _, err := r.g.CommitAndPush(docVersion, r.resolvedVersion, "", environment.ActionRunWorkflow, false, trailers); err != nil {
 			return err
 		}
 	}
 
-	outputs["resolved_speakeasy_version"] = resolvedVersion
+	outputs["resolved_speakeasy_version"] = r.resolvedVersion
+
+	if environment.IsDryRun() {
+		return stopAfter(finishDryRun(outputs))
+	}
 
-	if sourcesOnly {
-		if _, err := g.CommitAndPush("", resolvedVersion, "", environment.ActionRunWorkflow, sourcesOnly); err != nil {
+	if r.sourcesOnly {
+		if _, err := r.g.CommitAndPush("", r.resolvedVersion, "", environment.ActionRunWorkflow, r.sourcesOnly, &git.CommitTrailers{SpeakeasyVersion: r.resolvedVersion, SourceCommit: environment.GetSHA()}); err != nil {
 			return err
 		}
 	}
 
 	// If test mode is successful to this point, exit here
 	if environment.IsTestMode() {
-		success = true
-		return nil
+		r.success = true
+		return pipeline.ErrStop
 	}
 
+	return nil
+}
+
+// finalize creates or updates the PR, or merges and releases the changes in direct mode
+func (r *workflowRun) finalize() error {
 	if err := finalize(finalizeInputs{
-		Outputs:              outputs,
-		BranchName:           branchName,
-		AnythingRegenerated:  anythingRegenerated,
-		SourcesOnly:          sourcesOnly,
-		Git:                  g,
-		VersioningInfo:       runRes.VersioningInfo,
-		LintingReportURL:     runRes.LintingReportURL,
-		ChangesReportURL:     runRes.ChangesReportURL,
-		OpenAPIChangeSummary: runRes.OpenAPIChangeSummary,
-		currentRelease:       &releaseInfo,
+		Outputs:                   r.outputs,
+		BranchName:                r.branchName,
+		AnythingRegenerated:       r.anythingRegenerated,
+		SourcesOnly:               r.sourcesOnly,
+		Git:                       r.g,
+		VersioningInfo:            r.runRes.VersioningInfo,
+		LintingReportURL:          r.runRes.LintingReportURL,
+		ChangesReportURL:          r.runRes.ChangesReportURL,
+		OpenAPIChangeSummary:      r.runRes.OpenAPIChangeSummary,
+		MergeConflicts:            r.runRes.MergeConflicts,
+		ModifiedGeneratedFiles:    r.runRes.ModifiedGeneratedFiles,
+		OrphanedFiles:             r.runRes.OrphanedFiles,
+		APISurfaceDiffs:           r.runRes.APISurfaceDiffs,
+		GeneratorUpgrade:          r.generatorUpgrade,
+		PR:                        r.pr,
+		PreviousGenerationVersion: r.runRes.PreviousGenerationVersion,
+		currentRelease:            &r.releaseInfo,
 	}); err != nil {
 		return err
 	}
 
-	success = true
+	r.success = true
+
+	return nil
+}
+
+// housekeeping closes the generation PRs superseded by the run's PR and deletes stale generation branches in PR mode.
+// Housekeeping doesn't fail the run, which has already updated its PR.
+func (r *workflowRun) housekeeping() error {
+	if !environment.IsHousekeepingEnabled() || environment.GetMode() != environment.ModePR {
+		return nil
+	}
+	if !ci.IsGitHub() {
+		logging.Info("Housekeeping is only supported on GitHub, skipping")
+		return nil
+	}
+
+	closed, err := r.g.CloseSupersededPRs(r.branchName, r.sourcesOnly)
+	if len(closed) > 0 {
+		logging.Info("Closed %d superseded PRs", len(closed))
+	}
+	if err != nil {
+		ci.Warning("Failed to close superseded PRs", err.Error())
+	}
+
+	deleted, err := r.g.DeleteStaleBranches(r.branchName, environment.GetStaleBranchAge())
+	if len(deleted) > 0 {
+		logging.Info("Deleted stale branches %s", strings.Join(deleted, ", "))
+	}
+	if err != nil {
+		ci.Warning("Failed to delete stale branches", err.Error())
+	}
 
 	return nil
 }
 
+// stopAfter stops the pipeline unless err is set
+func stopAfter(err error) error {
+	if err != nil {
+		return err
+	}
+
+	return pipeline.ErrStop
+}
+
+// maxReleaseAPIChanges limits the API changes recorded for each language in RELEASES.md, which grows with every release
+const maxReleaseAPIChanges = 100
+
+// releaseAPIChanges converts the changes to the public API of the regenerated SDKs to those recorded in RELEASES.md,
+// removals first as they're the breaking ones
+func releaseAPIChanges(diffs map[string]apisurface.Diff) []releases.APIChange {
+	langs := make([]string, 0, len(diffs))
+	for lang := range diffs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	var changes []releases.APIChange
+	for _, lang := range langs {
+		diff := diffs[lang]
+
+		langChanges := make([]releases.APIChange, 0, len(diff.Removed)+len(diff.Added))
+		for _, symbol := range diff.Removed {
+			langChanges = append(langChanges, releases.APIChange{Lang: lang, Change: "removed", Symbol: symbol})
+		}
+		for _, symbol := range diff.Added {
+			langChanges = append(langChanges, releases.APIChange{Lang: lang, Change: "added", Symbol: symbol})
+		}
+
+		if len(langChanges) > maxReleaseAPIChanges {
+			langChanges = langChanges[:maxReleaseAPIChanges]
+		}
+		changes = append(changes, langChanges...)
+	}
+
+	return changes
+}
+
 func shouldDeleteBranch(isSuccess bool) bool {
 	isDirectMode := environment.GetMode() == environment.ModeDirect
-	return !environment.IsDebugMode() && !environment.IsTestMode() && (isDirectMode || !isSuccess)
+	return !environment.IsDebugMode() && !environment.IsTestMode() && !environment.IsDryRun() && (isDirectMode || !isSuccess)
+}
+
+// finishDryRun sets the outputs of a dry run, where the generated changes are left uncommitted
+func finishDryRun(outputs map[string]string) error {
+	logging.Info("Dry run enabled, skipping committing, pushing and releasing the generated changes")
+
+	addDefaultLanguageOutputs(outputs)
+
+	return setOutputs(outputs)
 }
 
 type finalizeInputs struct {
-	Outputs              map[string]string
-	BranchName           string
-	AnythingRegenerated  bool
-	SourcesOnly          bool
-	Git                  *git.Git
-	LintingReportURL     string
-	ChangesReportURL     string
-	OpenAPIChangeSummary string
-	VersioningReport     *versioning.MergedVersionReport
-	VersioningInfo       versionbumps.VersioningInfo
-	currentRelease       *releases.ReleasesInfo
+	Outputs                map[string]string
+	BranchName             string
+	AnythingRegenerated    bool
+	SourcesOnly            bool
+	Git                    *git.Git
+	LintingReportURL       string
+	ChangesReportURL       string
+	OpenAPIChangeSummary   string
+	VersioningReport       *versioning.MergedVersionReport
+	VersioningInfo         versionbumps.VersioningInfo
+	MergeConflicts         []string
+	ModifiedGeneratedFiles []string
+	OrphanedFiles          []string
+	APISurfaceDiffs        map[string]apisurface.Diff
+	// GeneratorUpgrade is set when the changes are committed to the generator upgrade PR, PR if it's already open
+	GeneratorUpgrade          bool
+	PR                        *ci.PullRequest
+	PreviousGenerationVersion string
+	currentRelease            *releases.ReleasesInfo
 }
 
 // Sets outputs and creates or adds releases info
@@ -226,6 +880,7 @@ func finalize(inputs finalizeInputs) error {
 
 	defer func() {
 		inputs.Outputs["branch_name"] = branchName
+		addDefaultLanguageOutputs(inputs.Outputs)
 
 		if err := setOutputs(inputs.Outputs); err != nil {
 			logging.Debug("failed to set outputs: %v", err)
@@ -234,21 +889,31 @@ func finalize(inputs finalizeInputs) error {
 
 	switch environment.GetMode() {
 	case environment.ModePR:
-		branchName, pr, err := inputs.Git.FindExistingPR(branchName, environment.ActionFinalize, inputs.SourcesOnly)
-		if err != nil {
-			return err
+		pr := inputs.PR
+		// The generator upgrade PR was already found when the changes were moved to its branch
+		if !inputs.GeneratorUpgrade {
+			branchName, pr, err = inputs.Git.FindExistingPR(branchName, environment.ActionFinalize, inputs.SourcesOnly)
+			if err != nil {
+				return err
+			}
 		}
 
 		pr, err = inputs.Git.CreateOrUpdatePR(git.PRInfo{
-			BranchName:           branchName,
-			ReleaseInfo:          inputs.currentRelease,
-			PreviousGenVersion:   inputs.Outputs["previous_gen_version"],
-			PR:                   pr,
-			SourceGeneration:     inputs.SourcesOnly,
-			LintingReportURL:     inputs.LintingReportURL,
-			ChangesReportURL:     inputs.ChangesReportURL,
-			VersioningInfo:       inputs.VersioningInfo,
-			OpenAPIChangeSummary: inputs.OpenAPIChangeSummary,
+			BranchName:                branchName,
+			ReleaseInfo:               inputs.currentRelease,
+			PreviousGenVersion:        inputs.Outputs["previous_gen_version"],
+			PR:                        pr,
+			SourceGeneration:          inputs.SourcesOnly,
+			LintingReportURL:          inputs.LintingReportURL,
+			ChangesReportURL:          inputs.ChangesReportURL,
+			VersioningInfo:            inputs.VersioningInfo,
+			OpenAPIChangeSummary:      inputs.OpenAPIChangeSummary,
+			MergeConflicts:            inputs.MergeConflicts,
+			ModifiedGeneratedFiles:    inputs.ModifiedGeneratedFiles,
+			OrphanedFiles:             inputs.OrphanedFiles,
+			APISurfaceDiffs:           inputs.APISurfaceDiffs,
+			GeneratorUpgrade:          inputs.GeneratorUpgrade,
+			PreviousGenerationVersion: inputs.PreviousGenerationVersion,
 		})
 
 		if err != nil {
@@ -256,7 +921,7 @@ func finalize(inputs finalizeInputs) error {
 		}
 
 		if pr != nil {
-			os.Setenv("GH_PULL_REQUEST", *pr.URL)
+			os.Setenv("GH_PULL_REQUEST", pr.URL)
 		}
 
 	case environment.ModeDirect:
@@ -277,13 +942,27 @@ func finalize(inputs finalizeInputs) error {
 			return err
 		}
 
-		if !inputs.SourcesOnly {
-			if err := inputs.Git.CreateRelease(*releaseInfo, inputs.Outputs); err != nil {
+		releasable := true
+		if environment.WaitForChecks() {
+			if err := waitForRequiredChecks(inputs.Git, commitHash, inputs.Outputs); err != nil {
+				return err
+			}
+			releasable = checksPassed(inputs.Outputs)
+		}
+
+		if !releasable {
+			logging.Info("The required checks of commit %s concluded with %s, leaving the release to a later job", commitHash, inputs.Outputs["checks_conclusion"])
+			withholdPublishing(inputs.Outputs)
+		} else if !inputs.SourcesOnly {
+			stopTimer := metrics.Time("publish")
+			if err := releaseSDKs(inputs.Git, releaseInfo, inputs.Outputs); err != nil {
 				return err
 			}
+			stopTimer()
 		}
 
 		inputs.Outputs["commit_hash"] = commitHash
+		addReleaseChannelOutputs(inputs.Outputs)
 
 		// add merging branch registry tag
 		if err = addDirectModeBranchTagging(); err != nil {
@@ -347,7 +1026,7 @@ func getReleasesInfo() (*releases.ReleasesInfo, error) {
 		return nil, err
 	}
 
-	releasesInfo, err := releases.GetLastReleaseInfo(releasesDir)
+	releasesInfo, err := releases.GetLatestReleaseInfo(releasesDir)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,182 @@
+package actions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
+	"github.com/speakeasy-api/sdk-generation-action/internal/configuration"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/git"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+	"github.com/speakeasy-api/sdk-generation-action/internal/utils"
+)
+
+// statusSchemaVersion is the version of the status document's schema, bumped on changes consumers must handle
+const statusSchemaVersion = 1
+
+// statusDocument describes the state of the repo's SDKs at the end of a run in a standard form, for building dashboards
+// across the repos using the action
+type statusDocument struct {
+	SchemaVersion int    `json:"schema_version"`
+	Repo          string `json:"repo"`
+	Workflow      string `json:"workflow"`
+	RunURL        string `json:"run_url"`
+	// Result is success or failure
+	Result string    `json:"result"`
+	Time   time.Time `json:"time"`
+	// LastSuccess is when the SDKs were last generated or found up to date by a successful run, unset if they never were
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	sdkStatus
+}
+
+// sdkStatus describes the repo's SDKs from the gen.lock files of the workflow's targets
+type sdkStatus struct {
+	SpecVersion       string           `json:"spec_version,omitempty"`
+	SpeakeasyVersion  string           `json:"speakeasy_version,omitempty"`
+	GenerationVersion string           `json:"generation_version,omitempty"`
+	Languages         []languageStatus `json:"languages"`
+}
+
+type languageStatus struct {
+	Target      string `json:"target"`
+	Lang        string `json:"lang"`
+	Dir         string `json:"dir"`
+	PackageName string `json:"package_name,omitempty"`
+	Version     string `json:"version,omitempty"`
+}
+
+// newStatusDocument describes the repo's SDKs as generated by the run. Runs that held their changes back pass the SDKs
+// as committed instead, which like failed runs were last successfully generated by the last generation.
+func newStatusDocument(g *git.Git, runErr error, committed *sdkStatus) statusDocument {
+	now := environment.GetInvokeTime()
+	doc := statusDocument{
+		SchemaVersion: statusSchemaVersion,
+		Repo:          environment.GetRepo(),
+		Workflow:      environment.GetWorkflowName(),
+		RunURL:        workflowRunURL(),
+		Result:        "success",
+		Time:          now,
+		LastSuccess:   &now,
+		sdkStatus:     sdkStatus{Languages: []languageStatus{}},
+	}
+
+	if runErr != nil {
+		doc.Result = "failure"
+	}
+	if runErr != nil || committed != nil {
+		doc.LastSuccess = nil
+		if g != nil {
+			if last, err := lastGeneration(g); err != nil {
+				logging.Debug("failed to find the last generation for the status document: %v", err)
+			} else if !last.IsZero() {
+				doc.LastSuccess = &last
+			}
+		}
+	}
+
+	if committed != nil {
+		doc.sdkStatus = *committed
+	} else {
+		doc.sdkStatus = readSDKStatus()
+	}
+
+	return doc
+}
+
+// readSDKStatus describes the SDKs in the repo's working tree
+func readSDKStatus() sdkStatus {
+	status := sdkStatus{Languages: []languageStatus{}}
+
+	wf, err := configuration.GetWorkflowAndValidateLanguages(false)
+	if err != nil {
+		logging.Debug("failed to load workflow for the status document: %v", err)
+		return status
+	}
+
+	for _, targetID := range slices.Sorted(maps.Keys(wf.Targets)) {
+		target := wf.Targets[targetID]
+
+		dir := "."
+		if target.Output != nil {
+			dir = *target.Output
+		}
+		dir = filepath.Join(environment.GetWorkingDirectory(), dir)
+
+		langStatus := languageStatus{Target: targetID, Lang: target.Target, Dir: dir}
+
+		cfg, err := config.Load(filepath.Join(environment.GetRepoDir(), dir))
+		if err != nil {
+			logging.Debug("failed to load the config of target %s for the status document: %v", targetID, err)
+			status.Languages = append(status.Languages, langStatus)
+			continue
+		}
+		if cfg.Config != nil {
+			if langCfg, ok := cfg.Config.Languages[target.Target]; ok {
+				langStatus.PackageName = utils.GetPackageName(target.Target, &langCfg)
+			}
+		}
+		if cfg.LockFile != nil {
+			langStatus.Version = cfg.LockFile.Management.ReleaseVersion
+			status.SpecVersion = cfg.LockFile.Management.DocVersion
+			status.SpeakeasyVersion = cfg.LockFile.Management.SpeakeasyVersion
+			status.GenerationVersion = cfg.LockFile.Management.GenerationVersion
+		}
+
+		status.Languages = append(status.Languages, langStatus)
+	}
+
+	return status
+}
+
+// reportStatus sets the status output to the run's status document and posts it to status_endpoint when set. Failing to
+// report the status doesn't fail the run.
+func reportStatus(g *git.Git, runErr error, committed *sdkStatus) {
+	data, err := json.Marshal(newStatusDocument(g, runErr, committed))
+	if err != nil {
+		logging.Info("Failed to marshal the status document: %v", err)
+		return
+	}
+
+	if err := ci.Current().SetOutputs(map[string]string{"status": string(data)}); err != nil {
+		logging.Info("Failed to set the status output: %v", err)
+	}
+
+	if endpoint := environment.GetStatusEndpoint(); endpoint != "" {
+		if err := postStatus(endpoint, data); err != nil {
+			ci.Warning("Failed to report status", err.Error())
+		}
+	}
+}
+
+func postStatus(endpoint string, data []byte) error {
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create status request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token := environment.GetStatusEndpointToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post status to %s: %w", endpoint, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("failed to post status to %s: %s", endpoint, strings.TrimSpace(res.Status))
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package actions
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportStatus(t *testing.T) {
+	repoDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, ".speakeasy"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, ".speakeasy", "workflow.yaml"), []byte(`workflowVersion: 1.0.0
+sources:
+  api:
+    inputs:
+      - location: openapi.yaml
+targets:
+  typescript:
+    target: typescript
+    source: api
+    output: typescript
+`), 0o644))
+
+	var body []byte
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	t.Setenv("INPUT_REPO_DIR", repoDir)
+	t.Setenv("INPUT_WORKING_DIRECTORY", "")
+	t.Setenv("GITHUB_REPOSITORY", "acme/sdks")
+	t.Setenv("GITHUB_OUTPUT", filepath.Join(t.TempDir(), "output"))
+	t.Setenv("INPUT_STATUS_ENDPOINT", server.URL)
+	t.Setenv("INPUT_STATUS_ENDPOINT_TOKEN", "secret")
+
+	reportStatus(nil, nil, nil)
+
+	assert.Equal(t, "Bearer secret", auth)
+	var doc statusDocument
+	require.NoError(t, json.Unmarshal(body, &doc))
+	assert.Equal(t, statusSchemaVersion, doc.SchemaVersion)
+	assert.Equal(t, "acme/sdks", doc.Repo)
+	assert.Equal(t, "success", doc.Result)
+	require.NotNil(t, doc.LastSuccess)
+	assert.True(t, doc.Time.Equal(*doc.LastSuccess))
+	require.Len(t, doc.Languages, 1)
+	assert.Equal(t, languageStatus{Target: "typescript", Lang: "typescript", Dir: "typescript"}, doc.Languages[0])
+
+	output, err := os.ReadFile(os.Getenv("GITHUB_OUTPUT"))
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "status=")
+
+	failed := newStatusDocument(nil, errors.New("generation failed"), nil)
+	assert.Equal(t, "failure", failed.Result)
+	assert.Nil(t, failed.LastSuccess)
+
+	committed := sdkStatus{
+		SpecVersion: "1.0.0",
+		Languages:   []languageStatus{{Target: "typescript", Lang: "typescript", Dir: "typescript", Version: "0.1.0"}},
+	}
+	held := newStatusDocument(nil, nil, &committed)
+	assert.Equal(t, "success", held.Result)
+	assert.Nil(t, held.LastSuccess)
+	assert.Equal(t, committed, held.sdkStatus)
+}
+
+func TestPostStatus_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	t.Setenv("INPUT_STATUS_ENDPOINT_TOKEN", "")
+
+	err := postStatus(server.URL, []byte(`{}`))
+	assert.ErrorContains(t, err, "401 Unauthorized")
+}
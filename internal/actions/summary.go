@@ -0,0 +1,98 @@
+package actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
+	"github.com/speakeasy-api/sdk-generation-action/internal/cli"
+	"github.com/speakeasy-api/sdk-generation-action/internal/coverage"
+)
+
+// maxSummaryItems limits the warnings or operations listed in each section of the job summary, which is size limited
+const maxSummaryItems = 100
+
+var generationWarningTitles = map[cli.GenerationWarningKind]string{
+	cli.GenerationWarningSkipped:     "Skipped",
+	cli.GenerationWarningUnsupported: "Unsupported",
+}
+
+// reportGenerationWarnings annotates the run with the parts of the OpenAPI doc the generator skipped or doesn't
+// support and counts them in the job summary, so gaps in the SDKs' coverage of the API aren't missed
+func reportGenerationWarnings(warnings []cli.GenerationWarning) error {
+	if len(warnings) == 0 {
+		return nil
+	}
+
+	counts := map[cli.GenerationWarningKind]int{}
+	for _, warning := range warnings {
+		counts[warning.Kind]++
+		ci.Warning(generationWarningTitles[warning.Kind]+" by the generator", warning.Message)
+	}
+
+	return appendJobSummary(formatGenerationWarningsSummary(warnings, counts))
+}
+
+// appendJobSummary adds the markdown to the summary shown on the job's page
+func appendJobSummary(markdown string) error {
+	return ci.Current().AppendSummary(markdown)
+}
+
+func formatGenerationWarningsSummary(warnings []cli.GenerationWarning, counts map[cli.GenerationWarningKind]int) string {
+	var sb strings.Builder
+
+	sb.WriteString("## SDK coverage\n\n")
+	sb.WriteString("The generator skipped parts of the OpenAPI doc or found constructs it doesn't support, which the SDKs don't cover.\n\n")
+	sb.WriteString("| Warning | Count |\n| --- | --- |\n")
+	for _, kind := range []cli.GenerationWarningKind{cli.GenerationWarningSkipped, cli.GenerationWarningUnsupported} {
+		fmt.Fprintf(&sb, "| %s | %d |\n", generationWarningTitles[kind], counts[kind])
+	}
+
+	sb.WriteString("\n<details>\n<summary>Warnings</summary>\n\n")
+	for i, warning := range warnings {
+		if i == maxSummaryItems {
+			fmt.Fprintf(&sb, "- …and %d more\n", len(warnings)-maxSummaryItems)
+			break
+		}
+		fmt.Fprintf(&sb, "- **%s**: %s\n", generationWarningTitles[warning.Kind], warning.Message)
+	}
+	sb.WriteString("\n</details>\n\n")
+
+	return sb.String()
+}
+
+type targetCoverage struct {
+	Lang string `json:"lang"`
+	*coverage.Report
+}
+
+func formatCoverageSummary(targetIDs []string, reports map[string]targetCoverage) string {
+	var sb strings.Builder
+
+	sb.WriteString("## Operation coverage\n\n")
+	sb.WriteString("| Target | Language | Operations | Covered | Coverage |\n| --- | --- | --- | --- | --- |\n")
+	for _, targetID := range targetIDs {
+		report := reports[targetID]
+		fmt.Fprintf(&sb, "| %s | %s | %d | %d | %.1f%% |\n", targetID, report.Lang, report.Operations, report.Covered, report.Percentage)
+	}
+
+	for _, targetID := range targetIDs {
+		missing := reports[targetID].Missing
+		if len(missing) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&sb, "\n<details>\n<summary>Operations missing from %s</summary>\n\n", targetID)
+		for i, operation := range missing {
+			if i == maxSummaryItems {
+				fmt.Fprintf(&sb, "- …and %d more\n", len(missing)-maxSummaryItems)
+				break
+			}
+			fmt.Fprintf(&sb, "- `%s`\n", operation)
+		}
+		sb.WriteString("\n</details>\n")
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
@@ -1,6 +1,7 @@
 package actions
 
 import (
+	"fmt"
 	"path/filepath"
 
 	"github.com/speakeasy-api/sdk-generation-action/internal/configuration"
@@ -44,3 +45,8 @@ func getReleasesDir() (string, error) {
 
 	return releasesDir, nil
 }
+
+// workflowRunURL returns the URL of the page of the workflow run
+func workflowRunURL() string {
+	return fmt.Sprintf("%s/%s/actions/runs/%s", environment.GetGithubServerURL(), environment.GetRepo(), environment.GetRunID())
+}
@@ -0,0 +1,148 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+
+	"github.com/google/go-github/v63/github"
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
+	"github.com/speakeasy-api/sdk-generation-action/internal/configuration"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+	"github.com/speakeasy-api/sdk-generation-action/internal/utils"
+)
+
+type sdkYanker interface {
+	FindRelease(version, directory string) (*github.RepositoryRelease, error)
+	SetReleaseToDraft(version, directory string) error
+}
+
+// Yank withdraws a bad release of the workflow's targets that released the version, or just the specified target: the
+// version is deprecated on npm or yanked from RubyGems when credentials are provided, and its GitHub release is
+// returned to a draft. Releases already returned to a draft are left as is, so a failed yank can be re-run.
+func Yank() error {
+	version := environment.GetYankVersion()
+	if version == "" {
+		return errors.New("yank_version is required for the yank action")
+	}
+
+	g, err := initAction()
+	if err != nil {
+		return err
+	}
+
+	wf, err := configuration.GetWorkflowAndValidateLanguages(false)
+	if err != nil {
+		return err
+	}
+
+	return yankTargets(g, wf, version)
+}
+
+func yankTargets(g sdkYanker, wf *workflow.Workflow, version string) error {
+	yanked := 0
+	for _, targetID := range slices.Sorted(maps.Keys(wf.Targets)) {
+		if specifiedTarget := environment.SpecifiedTarget(); specifiedTarget != "" && specifiedTarget != "all" && specifiedTarget != targetID {
+			continue
+		}
+		target := wf.Targets[targetID]
+
+		dir := "."
+		if target.Output != nil {
+			dir = *target.Output
+		}
+		dir = filepath.Join(environment.GetWorkingDirectory(), dir)
+
+		// Targets released independently of each other needn't have released the version
+		release, err := g.FindRelease(version, dir)
+		if err != nil {
+			return err
+		}
+		if release == nil {
+			logging.Info("%s has no release of v%s, skipping", targetID, version)
+			continue
+		}
+		// The release is returned to a draft last, so a draft release was already yanked
+		if release.GetDraft() {
+			logging.Info("%s v%s was already yanked", targetID, version)
+			yanked++
+			continue
+		}
+
+		if err := yankTarget(g, target.Target, dir, version); err != nil {
+			return fmt.Errorf("failed to yank %s v%s: %w", targetID, version, err)
+		}
+		yanked++
+	}
+
+	if yanked == 0 {
+		return fmt.Errorf("no target has a release of v%s", version)
+	}
+
+	return nil
+}
+
+func yankTarget(g sdkYanker, lang, dir, version string) error {
+	cfg, err := config.Load(filepath.Join(environment.GetRepoDir(), dir))
+	if err != nil {
+		return err
+	}
+	langCfg, ok := cfg.Config.Languages[lang]
+	if !ok {
+		return fmt.Errorf("%s config not found in %s", lang, dir)
+	}
+	packageName := utils.GetPackageName(lang, &langCfg)
+
+	switch lang {
+	case "typescript":
+		if token := environment.GetNPMToken(); token != "" {
+			logging.Info("Deprecating %s@%s on npm", packageName, version)
+
+			npmrc := filepath.Join(os.TempDir(), ".npmrc-yank")
+			if err := os.WriteFile(npmrc, []byte("//registry.npmjs.org/:_authToken="+token+"\n"), 0o600); err != nil {
+				return fmt.Errorf("failed to write npmrc: %w", err)
+			}
+			defer os.Remove(npmrc)
+
+			if err := runYankCommand([]string{"NPM_CONFIG_USERCONFIG=" + npmrc}, "npm", "deprecate", fmt.Sprintf("%s@%s", packageName, version), environment.GetYankReason()); err != nil {
+				return err
+			}
+		}
+	case "ruby":
+		if apiKey := environment.GetRubyGemsAPIKey(); apiKey != "" {
+			logging.Info("Yanking %s %s from RubyGems", packageName, version)
+
+			if err := runYankCommand([]string{"GEM_HOST_API_KEY=" + apiKey}, "gem", "yank", packageName, "-v", version); err != nil {
+				return err
+			}
+		}
+	case "python":
+		// PyPI only supports yanking releases through its website
+		ci.Warning(fmt.Sprintf("yank %s from PyPI", packageName), fmt.Sprintf("PyPI releases can't be yanked through its API, yank it at https://pypi.org/manage/project/%s/release/%s/", utils.NormalizePackageName(lang, packageName), version))
+	}
+
+	if err := g.SetReleaseToDraft(version, dir); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func runYankCommand(env []string, args ...string) error {
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("error running `%s %s`: %w", args[0], args[1], err)
+	}
+
+	return nil
+}
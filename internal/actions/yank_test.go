@@ -0,0 +1,58 @@
+package actions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeYanker struct {
+	releases map[string]*github.RepositoryRelease
+	found    []string
+	drafted  []string
+}
+
+func (f *fakeYanker) FindRelease(version, directory string) (*github.RepositoryRelease, error) {
+	f.found = append(f.found, directory)
+	return f.releases[directory], nil
+}
+
+func (f *fakeYanker) SetReleaseToDraft(version, directory string) error {
+	f.drafted = append(f.drafted, directory)
+	return nil
+}
+
+func TestYankTargets(t *testing.T) {
+	repoDir := t.TempDir()
+	t.Setenv("INPUT_REPO_DIR", repoDir)
+	t.Setenv("INPUT_WORKING_DIRECTORY", "")
+	t.Setenv("INPUT_TARGET", "")
+
+	wf := &workflow.Workflow{Targets: map[string]workflow.Target{}}
+	for _, lang := range []string{"go", "java", "csharp"} {
+		dir := filepath.Join("sdks", lang)
+		require.NoError(t, os.MkdirAll(filepath.Join(repoDir, dir, ".speakeasy"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, dir, ".speakeasy", "gen.yaml"), []byte("configVersion: 2.0.0\n"+lang+":\n  version: 1.2.3\n  packageName: acme\n"), 0o644))
+		wf.Targets[lang] = workflow.Target{Target: lang, Source: "api", Output: &dir}
+	}
+
+	g := &fakeYanker{releases: map[string]*github.RepositoryRelease{
+		"sdks/go": {TagName: github.String("sdks/go/v1.2.3")},
+		// Yanked by a previous run
+		"sdks/csharp": {TagName: github.String("sdks/csharp/v1.2.3"), Draft: github.Bool(true)},
+	}}
+	require.NoError(t, yankTargets(g, wf, "1.2.3"))
+
+	assert.Equal(t, []string{"sdks/csharp", "sdks/go", "sdks/java"}, g.found)
+	assert.Equal(t, []string{"sdks/go"}, g.drafted)
+
+	// A version no target released is an error
+	g = &fakeYanker{}
+	assert.ErrorContains(t, yankTargets(g, wf, "9.9.9"), "no target has a release of v9.9.9")
+	assert.Empty(t, g.drafted)
+}
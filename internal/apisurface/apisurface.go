@@ -0,0 +1,373 @@
+// Package apisurface extracts the public API of generated SDKs, so changes to it can be reported independently of the
+// changes to the OpenAPI doc
+package apisurface
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Surface is the set of exported symbols of an SDK, qualified by the package or module they are exported from
+type Surface map[string]bool
+
+// Diff is the symbols added to and removed from a Surface. A symbol whose signature changed is both removed and added.
+type Diff struct {
+	Added   []string
+	Removed []string
+}
+
+func (d Diff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// Supported returns whether the public API of SDKs in the language can be extracted
+func Supported(lang string) bool {
+	return lang == "go" || lang == "typescript"
+}
+
+// Extract returns the public API of the SDK generated in dir, or nil if the language isn't supported
+func Extract(lang, dir string) (Surface, error) {
+	switch lang {
+	case "go":
+		return extractGo(dir)
+	case "typescript":
+		return extractTypescript(dir)
+	}
+
+	return nil, nil
+}
+
+// Compare returns the symbols added and removed between the previous and current surfaces
+func Compare(previous, current Surface) Diff {
+	diff := Diff{}
+	for symbol := range current {
+		if !previous[symbol] {
+			diff.Added = append(diff.Added, symbol)
+		}
+	}
+	for symbol := range previous {
+		if !current[symbol] {
+			diff.Removed = append(diff.Removed, symbol)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+
+	return diff
+}
+
+// extractGo collects the exported functions (with their signatures), methods, types (with the types of the exported
+// fields of structs), constants and variables of each package in the module
+func extractGo(dir string) (Surface, error) {
+	surface := Surface{}
+	fset := token.NewFileSet()
+
+	err := walkSources(dir, ".go", func(path, rel string) error {
+		if strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", rel, err)
+		}
+		if file.Name.Name == "main" {
+			return nil
+		}
+
+		pkg := filepath.ToSlash(filepath.Dir(rel))
+		if pkg == "." {
+			pkg = file.Name.Name
+		}
+
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if !decl.Name.IsExported() {
+					continue
+				}
+				name := decl.Name.Name
+				if decl.Recv != nil && len(decl.Recv.List) > 0 {
+					recv := receiverName(decl.Recv.List[0].Type)
+					if !ast.IsExported(recv) {
+						continue
+					}
+					name = recv + "." + name
+				}
+				surface[fmt.Sprintf("%s.%s %s", pkg, name, types.ExprString(decl.Type))] = true
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						if !spec.Name.IsExported() {
+							continue
+						}
+						surface[pkg+"."+spec.Name.Name] = true
+						if structType, ok := spec.Type.(*ast.StructType); ok {
+							for _, field := range exportedFields(structType) {
+								surface[fmt.Sprintf("%s.%s.%s", pkg, spec.Name.Name, field)] = true
+							}
+						}
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							if name.IsExported() {
+								surface[pkg+"."+name.Name] = true
+							}
+						}
+					}
+				}
+			}
+		}
+
+		return nil
+	})
+
+	return surface, err
+}
+
+// exportedFields returns the exported fields of the struct with their types, embedded fields being named after their
+// type
+func exportedFields(structType *ast.StructType) []string {
+	fields := []string{}
+	for _, field := range structType.Fields.List {
+		fieldType := types.ExprString(field.Type)
+		if len(field.Names) == 0 {
+			if name := receiverName(field.Type); ast.IsExported(name) {
+				fields = append(fields, name+" "+fieldType)
+			}
+			continue
+		}
+		for _, name := range field.Names {
+			if name.IsExported() {
+				fields = append(fields, name.Name+" "+fieldType)
+			}
+		}
+	}
+
+	return fields
+}
+
+func receiverName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(expr.X)
+	case *ast.IndexExpr:
+		return receiverName(expr.X)
+	case *ast.IndexListExpr:
+		return receiverName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	}
+
+	return ""
+}
+
+var (
+	typescriptExportRegex      = regexp.MustCompile(`(?m)^export\s+(?:declare\s+)?(?:default\s+)?(?:async\s+)?(?:abstract\s+)?(function\*?|class|const|let|var|type|interface|enum|namespace)\s+([A-Za-z_$][\w$]*)`)
+	typescriptExportClassRegex = regexp.MustCompile(`(?m)^export\s+(?:declare\s+)?(?:default\s+)?(?:abstract\s+)?class\s+([A-Za-z_$][\w$]*)`)
+	// Class members are indented once, private ones are declared with the private modifier or a # prefix
+	typescriptClassMemberRegex = regexp.MustCompile(`(?m)^  (?:public\s+)?(?:static\s+)?(?:async\s+)?(?:get\s+|set\s+)?([A-Za-z_$][\w$]*)\s*[(<]`)
+	typescriptBlockEndRegex    = regexp.MustCompile(`(?m)^}`)
+)
+
+// extractTypescript collects the top level declarations exported by each module in src, along with the public methods
+// of its exported classes
+func extractTypescript(dir string) (Surface, error) {
+	surface := Surface{}
+
+	err := walkSources(filepath.Join(dir, "src"), ".ts", func(path, rel string) error {
+		if strings.HasSuffix(path, ".test.ts") || strings.HasSuffix(path, ".d.ts") {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		module := strings.TrimSuffix(filepath.ToSlash(rel), ".ts")
+		for _, match := range typescriptExportRegex.FindAllStringSubmatch(string(data), -1) {
+			surface[fmt.Sprintf("%s.%s", module, match[2])] = true
+		}
+		for class, methods := range typescriptClassMethods(data) {
+			for _, method := range methods {
+				surface[fmt.Sprintf("%s.%s.%s", module, class, method)] = true
+			}
+		}
+
+		return nil
+	})
+
+	return surface, err
+}
+
+// typescriptClassMethods returns the public methods, including accessors, of each exported class in the module
+func typescriptClassMethods(data []byte) map[string][]string {
+	methods := map[string][]string{}
+
+	for _, class := range typescriptExportClassRegex.FindAllSubmatchIndex(data, -1) {
+		name := string(data[class[2]:class[3]])
+		body := data[class[1]:]
+		if end := typescriptBlockEndRegex.FindIndex(body); end != nil {
+			body = body[:end[0]]
+		}
+
+		for _, member := range typescriptClassMemberRegex.FindAllSubmatch(body, -1) {
+			if method := string(member[1]); method != "constructor" {
+				methods[name] = append(methods[name], method)
+			}
+		}
+	}
+
+	return methods
+}
+
+// walkSources calls fn with the path, and path relative to dir, of each file with the extension, skipping
+// dependencies, build output and hidden directories
+func walkSources(dir, ext string, fn func(path, rel string) error) error {
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != dir && (strings.HasPrefix(name, ".") || name == "node_modules" || name == "dist" || name == "vendor" || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ext {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		return fn(path, rel)
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
+
+// Method is a method of an SDK's operations, Group is the type or class it belongs to, if any
+type Method struct {
+	Group string
+	Name  string
+}
+
+// Methods returns the methods of the SDK generated in dir that call the API, or nil if the language isn't supported
+func Methods(lang, dir string) ([]Method, error) {
+	switch lang {
+	case "go":
+		return goMethods(dir)
+	case "typescript":
+		return typescriptMethods(dir)
+	}
+
+	return nil, nil
+}
+
+// goMethods collects the exported methods of exported types that take a context, which only the methods calling the
+// API do
+func goMethods(dir string) ([]Method, error) {
+	methods := []Method{}
+	fset := token.NewFileSet()
+
+	err := walkSources(dir, ".go", func(path, rel string) error {
+		if strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", rel, err)
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 || !fn.Name.IsExported() {
+				continue
+			}
+			recv := receiverName(fn.Recv.List[0].Type)
+			if !ast.IsExported(recv) {
+				continue
+			}
+
+			params := fn.Type.Params.List
+			if len(params) == 0 || types.ExprString(params[0].Type) != "context.Context" {
+				continue
+			}
+
+			methods = append(methods, Method{Group: recv, Name: fn.Name.Name})
+		}
+
+		return nil
+	})
+
+	return methods, err
+}
+
+var (
+	typescriptClassRegex    = regexp.MustCompile(`(?m)^export\s+class\s+([A-Za-z_$][\w$]*)`)
+	typescriptMethodRegex   = regexp.MustCompile(`(?m)^  async\s+([a-z_$][\w$]*)\s*\(`)
+	typescriptFunctionRegex = regexp.MustCompile(`(?m)^export\s+(?:async\s+)?function\s+([a-z_$][\w$]*)`)
+)
+
+// typescriptMethods collects the async methods of the classes in src/sdk and the standalone functions in src/funcs
+func typescriptMethods(dir string) ([]Method, error) {
+	methods := []Method{}
+
+	err := walkSources(filepath.Join(dir, "src", "sdk"), ".ts", func(path, rel string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		classes := typescriptClassRegex.FindAllSubmatchIndex(data, -1)
+		for _, match := range typescriptMethodRegex.FindAllSubmatchIndex(data, -1) {
+			// Methods belong to the last class declared before them
+			group := ""
+			for _, class := range classes {
+				if class[0] < match[0] {
+					group = string(data[class[2]:class[3]])
+				}
+			}
+
+			methods = append(methods, Method{Group: group, Name: string(data[match[2]:match[3]])})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = walkSources(filepath.Join(dir, "src", "funcs"), ".ts", func(path, rel string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		for _, match := range typescriptFunctionRegex.FindAllSubmatch(data, -1) {
+			methods = append(methods, Method{Name: string(match[1])})
+		}
+
+		return nil
+	})
+
+	return methods, err
+}
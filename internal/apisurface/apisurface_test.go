@@ -0,0 +1,129 @@
+package apisurface
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractGo(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "models", "components"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sdk.go"), []byte(`package sdk
+
+import "context"
+
+type Pets struct{}
+
+func (p *Pets) List(ctx context.Context, limit *int64) ([]string, error) { return nil, nil }
+
+func (p *Pets) unexported() {}
+
+func New(opts ...string) *Pets { return &Pets{} }
+
+const ServerProd = "prod"
+`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "models", "components", "pet.go"), []byte("package components\n\ntype Pet struct {\n\tBase\n\tName, Tag *string `json:\"name\"`\n\tid string\n}\n\ntype Base struct{}\n\nvar internal = 1\n"), 0o644))
+
+	surface, err := Extract("go", dir)
+	require.NoError(t, err)
+	assert.Equal(t, Surface{
+		"sdk.Pets": true,
+		"sdk.Pets.List func(ctx context.Context, limit *int64) ([]string, error)": true,
+		"sdk.New func(opts ...string) *Pets":                                      true,
+		"sdk.ServerProd":                                                          true,
+		"models/components.Pet":                                                   true,
+		"models/components.Pet.Base Base":                                         true,
+		"models/components.Pet.Name *string":                                      true,
+		"models/components.Pet.Tag *string":                                       true,
+		"models/components.Base":                                                  true,
+	}, surface)
+}
+
+func TestExtractTypescript(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "src", "sdk"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src", "sdk", "pets.ts"), []byte(`export class Pets extends ClientSDK {
+  private _owners?: Owners;
+  get owners(): Owners {
+    return (this._owners ??= new Owners(this._options));
+  }
+
+  constructor(options: SDKOptions) {}
+
+  async list(
+    request?: ListPetsRequest,
+  ): Promise<ListPetsResponse> {
+    if (request) {
+      return unwrapAsync(petsList(this, request));
+    }
+  }
+
+  private format(pet: Pet) {}
+}
+export type Pet = { name: string };
+export async function listPets() {}
+const internal = {
+  helper() {},
+};
+`), 0o644))
+
+	surface, err := Extract("typescript", dir)
+	require.NoError(t, err)
+	assert.Equal(t, Surface{
+		"sdk/pets.Pets":        true,
+		"sdk/pets.Pets.owners": true,
+		"sdk/pets.Pets.list":   true,
+		"sdk/pets.Pet":         true,
+		"sdk/pets.listPets":    true,
+	}, surface)
+}
+
+func TestCompare(t *testing.T) {
+	diff := Compare(Surface{"a": true, "b": true}, Surface{"b": true, "c": true})
+	assert.Equal(t, Diff{Added: []string{"c"}, Removed: []string{"a"}}, diff)
+	assert.True(t, Compare(Surface{"a": true}, Surface{"a": true}).IsEmpty())
+}
+
+func TestMethods(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pets.go"), []byte(`package sdk
+
+import "context"
+
+type Pets struct{}
+
+func (p *Pets) List(ctx context.Context) error { return nil }
+
+func (p *Pets) GetName() string { return "" }
+
+type pagination struct{}
+
+func (p *pagination) Next(ctx context.Context) error { return nil }
+`), 0o644))
+
+	methods, err := Methods("go", dir)
+	require.NoError(t, err)
+	assert.Equal(t, []Method{{Group: "Pets", Name: "List"}}, methods)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "src", "sdk"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "src", "funcs"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src", "sdk", "pets.ts"), []byte(`export class Pets extends ClientSDK {
+  async list(
+    request?: operations.ListPetsRequest,
+  ): Promise<operations.ListPetsResponse> {
+    if (request) {
+      return unwrapAsync(petsList(this, request));
+    }
+  }
+}
+`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src", "funcs", "petsList.ts"), []byte("export async function petsList(client: SDKCore) {}\n"), 0o644))
+
+	methods, err = Methods("typescript", dir)
+	require.NoError(t, err)
+	assert.Equal(t, []Method{{Group: "Pets", Name: "list"}, {Name: "petsList"}}, methods)
+}
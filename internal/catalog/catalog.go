@@ -0,0 +1,150 @@
+// Package catalog updates the annotations of Backstage catalog-info.yaml files with the SDKs' metadata
+package catalog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AnnotationPrefix prefixes the annotations set by the action
+const AnnotationPrefix = "speakeasy.com/"
+
+// Update sets the annotations on the first Component entity of the catalog-info file at path, or its first entity if
+// none are Components, leaving the rest of the file as is. It returns whether the file changed.
+func Update(path string, annotations map[string]string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	updated, changed, err := update(data, annotations)
+	if err != nil || !changed {
+		return false, err
+	}
+
+	if err := os.WriteFile(path, updated, 0o644); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return true, nil
+}
+
+func update(data []byte, annotations map[string]string) ([]byte, bool, error) {
+	docs := []*yaml.Node{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, false, fmt.Errorf("failed to parse catalog-info: %w", err)
+		}
+		docs = append(docs, &doc)
+	}
+
+	entity := findEntity(docs)
+	if entity == nil {
+		return nil, false, fmt.Errorf("no entity found in catalog-info")
+	}
+
+	metadata := mappingValue(entity, "metadata")
+	existing := mappingValue(metadata, "annotations")
+
+	changed := false
+	for _, key := range slices.Sorted(maps.Keys(annotations)) {
+		if setMappingValue(existing, key, annotations[key]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return data, false, nil
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	for _, doc := range docs {
+		if err := enc.Encode(doc); err != nil {
+			return nil, false, fmt.Errorf("failed to marshal catalog-info: %w", err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return nil, false, fmt.Errorf("failed to marshal catalog-info: %w", err)
+	}
+
+	return buf.Bytes(), true, nil
+}
+
+// findEntity returns the mapping of the first Component entity, or of the first entity if none are Components
+func findEntity(docs []*yaml.Node) *yaml.Node {
+	var first *yaml.Node
+	for _, doc := range docs {
+		if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+			continue
+		}
+
+		entity := doc.Content[0]
+		if first == nil {
+			first = entity
+		}
+		if kind := lookup(entity, "kind"); kind != nil && kind.Value == "Component" {
+			return entity
+		}
+	}
+
+	return first
+}
+
+func lookup(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// mappingValue returns the mapping under the key, adding it if it's missing
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if value := lookup(mapping, key); value != nil && value.Kind == yaml.MappingNode {
+		return value
+	}
+
+	value := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return value
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+
+	return value
+}
+
+// setMappingValue sets the key to the string value, returning whether it changed
+func setMappingValue(mapping *yaml.Node, key, value string) bool {
+	if existing := lookup(mapping, key); existing != nil {
+		if existing.Kind == yaml.ScalarNode && existing.Value == value {
+			return false
+		}
+		*existing = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+		return true
+	}
+
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value},
+	)
+
+	return true
+}
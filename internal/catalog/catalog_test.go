@@ -0,0 +1,67 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdate(t *testing.T) {
+	data := []byte(`apiVersion: backstage.io/v1alpha1
+kind: System
+metadata:
+  name: payments
+---
+# The SDKs of the payments API
+apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: payments-sdks
+  annotations:
+    github.com/project-slug: acme/payments-sdks
+    speakeasy.com/typescript-sdk-version: 1.1.0
+spec:
+  type: library
+  owner: payments
+`)
+
+	updated, changed, err := update(data, map[string]string{
+		"speakeasy.com/typescript-sdk-version": "1.2.0",
+		"speakeasy.com/spec-version":           "2.0",
+	})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, `apiVersion: backstage.io/v1alpha1
+kind: System
+metadata:
+  name: payments
+---
+# The SDKs of the payments API
+apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: payments-sdks
+  annotations:
+    github.com/project-slug: acme/payments-sdks
+    speakeasy.com/typescript-sdk-version: 1.2.0
+    speakeasy.com/spec-version: "2.0"
+spec:
+  type: library
+  owner: payments
+`, string(updated))
+
+	_, changed, err = update(updated, map[string]string{"speakeasy.com/spec-version": "2.0"})
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func TestUpdate_AddsAnnotations(t *testing.T) {
+	updated, changed, err := update([]byte("kind: Component\nmetadata:\n  name: sdks\n"), map[string]string{"speakeasy.com/spec-version": "1.0.0"})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "kind: Component\nmetadata:\n  name: sdks\n  annotations:\n    speakeasy.com/spec-version: 1.0.0\n", string(updated))
+
+	_, _, err = update([]byte("# empty\n"), map[string]string{"speakeasy.com/spec-version": "1.0.0"})
+	assert.ErrorContains(t, err, "no entity found")
+}
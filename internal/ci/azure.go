@@ -0,0 +1,189 @@
+package ci
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+type azureDevOps struct{}
+
+func init() {
+	register(azureDevOps{})
+}
+
+// azureDevOpsVariables sets the GitHub Actions variables the action reads from the predefined variables of the Azure
+// Pipelines build. The repository is set to "<project>/_git/<repo>" so it resolves to the repository's URL under the
+// organization's URL like it does under GitHub's.
+var azureDevOpsVariables = map[string]func() string{
+	"GITHUB_SERVER_URL": func() string { return strings.TrimSuffix(os.Getenv("SYSTEM_COLLECTIONURI"), "/") },
+	"GITHUB_REPOSITORY": func() string {
+		if os.Getenv("SYSTEM_TEAMPROJECT") == "" || os.Getenv("BUILD_REPOSITORY_NAME") == "" {
+			return ""
+		}
+		return os.Getenv("SYSTEM_TEAMPROJECT") + "/_git/" + os.Getenv("BUILD_REPOSITORY_NAME")
+	},
+	"GITHUB_REPOSITORY_OWNER": func() string { return os.Getenv("SYSTEM_TEAMPROJECT") },
+	"GITHUB_WORKSPACE":        func() string { return os.Getenv("BUILD_SOURCESDIRECTORY") },
+	"GITHUB_RUN_ID":           func() string { return os.Getenv("BUILD_BUILDID") },
+	"GITHUB_SHA":              func() string { return os.Getenv("BUILD_SOURCEVERSION") },
+	"GITHUB_WORKFLOW":         func() string { return os.Getenv("BUILD_DEFINITIONNAME") },
+	"GITHUB_BASE_REF": func() string {
+		return strings.TrimPrefix(os.Getenv("SYSTEM_PULLREQUEST_TARGETBRANCH"), "refs/heads/")
+	},
+	"GITHUB_REF": func() string {
+		// Pull request builds run on a merge ref
+		if branch := os.Getenv("SYSTEM_PULLREQUEST_SOURCEBRANCH"); branch != "" {
+			return branch
+		}
+		return os.Getenv("BUILD_SOURCEBRANCH")
+	},
+	"GITHUB_EVENT_NAME": func() string {
+		switch os.Getenv("BUILD_REASON") {
+		case "Schedule":
+			return "schedule"
+		case "IndividualCI", "BatchedCI":
+			return "push"
+		case "PullRequest":
+			return "pull_request"
+		case "Manual":
+			return "workflow_dispatch"
+		}
+		return ""
+	},
+}
+
+func (azureDevOps) Name() string {
+	return "Azure Pipelines"
+}
+
+func (azureDevOps) Detect() bool {
+	return os.Getenv("TF_BUILD") == "True"
+}
+
+// NewHost returns the repository the build runs for, authenticated with a personal access token or the build's
+// System.AccessToken
+func (azureDevOps) NewHost(accessToken string) Host {
+	return &azureDevOpsHost{Remote: NewRemote("gen", accessToken), client: NewAzureDevOpsClient(accessToken)}
+}
+
+// Init sets the GitHub Actions variables the action reads that aren't already set from their Azure Pipelines
+// counterparts
+func (azureDevOps) Init() error {
+	return setGitHubVariables(azureDevOpsVariables)
+}
+
+// SetOutputs sets the outputs as output variables of the step, referenced by later jobs as
+// dependencies.<job>.outputs['<step>.<output>']
+func (azureDevOps) SetOutputs(outputs map[string]string) error {
+	for k, v := range outputs {
+		// Don't set the variables in test mode
+		if environment.IsTestMode() {
+			fmt.Printf("%s=%s\n", k, v)
+			continue
+		}
+		fmt.Printf("##vso[task.setvariable variable=%s;isoutput=true]%s\n", k, escapeLoggingData(v))
+	}
+
+	return nil
+}
+
+// Annotate logs the annotation as an issue of the build, which only has warnings and errors
+func (azureDevOps) Annotate(annotation Annotation) {
+	if annotation.Level == LevelNotice {
+		logAnnotation(annotation)
+		return
+	}
+
+	properties := "type=" + string(annotation.Level)
+	if annotation.File != "" {
+		properties += ";sourcepath=" + escapeLoggingProperty(annotation.File)
+	}
+
+	message := annotation.Message
+	if annotation.Title != "" {
+		message = annotation.Title + ": " + message
+	}
+
+	fmt.Printf("##vso[task.logissue %s]%s\n", properties, escapeLoggingData(message))
+}
+
+// AppendSummary uploads the markdown as a section of the build's summary
+func (azureDevOps) AppendSummary(markdown string) error {
+	f, err := os.CreateTemp(os.Getenv("AGENT_TEMPDIRECTORY"), "speakeasy-summary-*.md")
+	if err != nil {
+		return fmt.Errorf("error creating job summary file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(markdown); err != nil {
+		return fmt.Errorf("error writing job summary: %w", err)
+	}
+
+	fmt.Printf("##vso[task.uploadsummary]%s\n", f.Name())
+
+	return nil
+}
+
+// escapeLoggingData escapes the characters that would end the data of a logging command
+func escapeLoggingData(value string) string {
+	return strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A").Replace(value)
+}
+
+// escapeLoggingProperty escapes the characters that would end a property of a logging command
+func escapeLoggingProperty(value string) string {
+	return strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A", ";", "%3B", "]", "%5D").Replace(value)
+}
+
+type azureDevOpsHost struct {
+	Remote
+	client *AzureDevOpsClient
+}
+
+func (h *azureDevOpsHost) FindPR(titlePrefix string) (*PullRequest, error) {
+	prs, err := h.client.ActivePullRequests()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pr := range prs {
+		if strings.HasPrefix(pr.Title, titlePrefix) {
+			return h.pullRequest(&pr), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (h *azureDevOpsHost) CreatePR(newPR NewPullRequest) (*PullRequest, error) {
+	pr, err := h.client.CreateOrUpdatePullRequest(newPR.SourceBranch, newPR.TargetBranch, newPR.Title, newPR.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.pullRequest(pr), nil
+}
+
+// CreateRelease creates an annotated tag with the release notes as its message, as Azure Repos has no releases
+func (h *azureDevOpsHost) CreateRelease(release Release) (*Release, error) {
+	if err := h.client.CreateTag(release.Tag, release.Commit, release.Notes); err != nil {
+		if errors.Is(err, ErrTagExists) {
+			return nil, ErrReleaseExists
+		}
+		return nil, err
+	}
+
+	return &release, nil
+}
+
+func (h *azureDevOpsHost) pullRequest(pr *AzureDevOpsPullRequest) *PullRequest {
+	return &PullRequest{
+		Number:       pr.ID,
+		Title:        pr.Title,
+		SourceBranch: strings.TrimPrefix(pr.SourceRefName, "refs/heads/"),
+		URL:          h.client.PullRequestURL(pr),
+	}
+}
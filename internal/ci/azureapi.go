@@ -0,0 +1,174 @@
+package ci
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// maxAzureDevOpsDescriptionLength is the longest pull request description Azure DevOps accepts
+const maxAzureDevOpsDescriptionLength = 4000
+
+// AzureDevOpsClient creates pull requests and tags through the Azure DevOps API for the repository the build runs for
+type AzureDevOpsClient struct {
+	// repoURL is the repository's URL, the API's is derived from it
+	repoURL string
+	token   string
+	client  *http.Client
+}
+
+// AzureDevOpsPullRequest is an Azure Repos pull request
+type AzureDevOpsPullRequest struct {
+	ID            int    `json:"pullRequestId"`
+	Title         string `json:"title"`
+	SourceRefName string `json:"sourceRefName"`
+	TargetRefName string `json:"targetRefName"`
+}
+
+// NewAzureDevOpsClient returns a client authenticating with the personal access token or the build's
+// System.AccessToken
+func NewAzureDevOpsClient(token string) *AzureDevOpsClient {
+	return &AzureDevOpsClient{
+		repoURL: fmt.Sprintf("%s%s/_git/%s", os.Getenv("SYSTEM_COLLECTIONURI"), url.PathEscape(os.Getenv("SYSTEM_TEAMPROJECT")), url.PathEscape(os.Getenv("BUILD_REPOSITORY_NAME"))),
+		token:   token,
+		client:  http.DefaultClient,
+	}
+}
+
+// PullRequestURL returns the URL of the pull request's page
+func (c *AzureDevOpsClient) PullRequestURL(pr *AzureDevOpsPullRequest) string {
+	return fmt.Sprintf("%s/pullrequest/%d", c.repoURL, pr.ID)
+}
+
+// ActivePullRequests returns the repository's active pull requests
+func (c *AzureDevOpsClient) ActivePullRequests() ([]AzureDevOpsPullRequest, error) {
+	page := struct {
+		Value []AzureDevOpsPullRequest `json:"value"`
+	}{}
+	if _, err := c.do(http.MethodGet, "/pullrequests?searchCriteria.status=active", nil, &page); err != nil {
+		return nil, fmt.Errorf("error getting pull requests: %w", err)
+	}
+
+	return page.Value, nil
+}
+
+// CreateOrUpdatePullRequest updates the title and description of the active pull request from sourceBranch into
+// targetBranch, creating it if there's none. Descriptions over Azure DevOps' limit are truncated.
+func (c *AzureDevOpsClient) CreateOrUpdatePullRequest(sourceBranch, targetBranch, title, description string) (*AzureDevOpsPullRequest, error) {
+	prs, err := c.ActivePullRequests()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(description) > maxAzureDevOpsDescriptionLength {
+		description = description[:maxAzureDevOpsDescriptionLength-3] + "..."
+	}
+	fields := map[string]any{"title": title, "description": description}
+
+	for _, pr := range prs {
+		if pr.SourceRefName != "refs/heads/"+sourceBranch || pr.TargetRefName != "refs/heads/"+targetBranch {
+			continue
+		}
+
+		updated := &AzureDevOpsPullRequest{}
+		if _, err := c.do(http.MethodPatch, fmt.Sprintf("/pullrequests/%d", pr.ID), fields, updated); err != nil {
+			return nil, fmt.Errorf("failed to update pull request: %w", err)
+		}
+		return updated, nil
+	}
+
+	fields["sourceRefName"] = "refs/heads/" + sourceBranch
+	fields["targetRefName"] = "refs/heads/" + targetBranch
+	fields["completionOptions"] = map[string]bool{"deleteSourceBranch": true}
+
+	created := &AzureDevOpsPullRequest{}
+	if _, err := c.do(http.MethodPost, "/pullrequests", fields, created); err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	return created, nil
+}
+
+// CreateTag creates an annotated tag at the commit, as Azure Repos has no releases, with the release notes as its
+// message. ErrTagExists is returned when the tag already exists.
+func (c *AzureDevOpsClient) CreateTag(name, hash, message string) error {
+	refs := struct {
+		Value []struct {
+			Name string `json:"name"`
+		} `json:"value"`
+	}{}
+	// The filter matches refs by prefix
+	if _, err := c.do(http.MethodGet, "/refs?filter="+url.QueryEscape("tags/"+name), nil, &refs); err != nil {
+		return fmt.Errorf("failed to get tag %s: %w", name, err)
+	}
+	for _, ref := range refs.Value {
+		if ref.Name == "refs/tags/"+name {
+			return ErrTagExists
+		}
+	}
+
+	if _, err := c.do(http.MethodPost, "/annotatedtags", map[string]any{
+		"name":         name,
+		"taggedObject": map[string]string{"objectId": hash},
+		"message":      message,
+	}, nil); err != nil {
+		return fmt.Errorf("failed to create tag %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// do sends the request to the repository's API at path, decoding the response into out if set, and returns the
+// response's status code
+func (c *AzureDevOpsClient) do(method, path string, body, out any) (int, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	// The API's path mirrors the repository's, e.g. <org>/<project>/_apis/git/repositories/<repo>
+	apiURL := strings.Replace(c.repoURL, "/_git/", "/_apis/git/repositories/", 1) + path
+	if strings.Contains(path, "?") {
+		apiURL += "&api-version=7.0"
+	} else {
+		apiURL += "?api-version=7.0"
+	}
+
+	req, err := http.NewRequest(method, apiURL, reqBody)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(":"+c.token)))
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(res.Body)
+		return res.StatusCode, fmt.Errorf("%s %s: %s - %s", method, path, res.Status, msg)
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			return res.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return res.StatusCode, nil
+}
@@ -0,0 +1,122 @@
+package ci
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+type bitbucket struct{}
+
+func init() {
+	register(bitbucket{})
+}
+
+// bitbucketVariables sets the GitHub Actions variables the action reads from the Bitbucket Pipelines variables of the
+// build. Bitbucket doesn't tell scheduled or manually triggered builds apart from pushes.
+var bitbucketVariables = map[string]func() string{
+	"GITHUB_SERVER_URL":       func() string { return "https://bitbucket.org" },
+	"GITHUB_REPOSITORY":       func() string { return os.Getenv("BITBUCKET_REPO_FULL_NAME") },
+	"GITHUB_REPOSITORY_OWNER": func() string { return os.Getenv("BITBUCKET_WORKSPACE") },
+	"GITHUB_WORKSPACE":        func() string { return os.Getenv("BITBUCKET_CLONE_DIR") },
+	"GITHUB_RUN_ID":           func() string { return os.Getenv("BITBUCKET_BUILD_NUMBER") },
+	"GITHUB_SHA":              func() string { return os.Getenv("BITBUCKET_COMMIT") },
+	"GITHUB_BASE_REF":         func() string { return os.Getenv("BITBUCKET_PR_DESTINATION_BRANCH") },
+	"GITHUB_REF": func() string {
+		if tag := os.Getenv("BITBUCKET_TAG"); tag != "" {
+			return "refs/tags/" + tag
+		}
+		if branch := os.Getenv("BITBUCKET_BRANCH"); branch != "" {
+			return "refs/heads/" + branch
+		}
+		return ""
+	},
+	"GITHUB_EVENT_NAME": func() string {
+		if os.Getenv("BITBUCKET_PR_ID") != "" {
+			return "pull_request"
+		}
+		return "push"
+	},
+}
+
+func (bitbucket) Name() string {
+	return "Bitbucket Pipelines"
+}
+
+func (bitbucket) Detect() bool {
+	return os.Getenv("BITBUCKET_BUILD_NUMBER") != ""
+}
+
+// NewHost returns the repository the build runs for, authenticated with a repository, project or workspace access
+// token
+func (bitbucket) NewHost(accessToken string) Host {
+	// Bitbucket only accepts access tokens with this username
+	return &bitbucketHost{Remote: NewRemote("x-token-auth", accessToken), client: NewBitbucketClient(accessToken)}
+}
+
+// Init sets the GitHub Actions variables the action reads that aren't already set from their Bitbucket Pipelines
+// counterparts
+func (bitbucket) Init() error {
+	return setGitHubVariables(bitbucketVariables)
+}
+
+// SetOutputs writes the outputs to a dotenv file, passed to later steps by declaring it as one of the step's artifacts
+// and sourced with `set -a; . speakeasy.env`
+func (bitbucket) SetOutputs(outputs map[string]string) error {
+	return writeDotenv(bitbucketFile("SPEAKEASY_DOTENV_FILE", "speakeasy.env"), outputs)
+}
+
+// Annotate logs the annotation as Bitbucket Pipelines has no equivalent
+func (bitbucket) Annotate(annotation Annotation) {
+	logAnnotation(annotation)
+}
+
+// AppendSummary appends to a markdown file, which can be attached to the step as an artifact
+func (bitbucket) AppendSummary(markdown string) error {
+	return appendToFile(bitbucketFile("SPEAKEASY_SUMMARY_FILE", "speakeasy-summary.md"), markdown)
+}
+
+func bitbucketFile(variable, defaultName string) string {
+	return pipelineFile(variable, os.Getenv("BITBUCKET_CLONE_DIR"), defaultName)
+}
+
+type bitbucketHost struct {
+	Remote
+	client *BitbucketClient
+}
+
+func (h *bitbucketHost) FindPR(titlePrefix string) (*PullRequest, error) {
+	prs, err := h.client.OpenPullRequests()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pr := range prs {
+		if strings.HasPrefix(pr.Title, titlePrefix) {
+			return &PullRequest{Number: pr.ID, Title: pr.Title, SourceBranch: pr.SourceBranch(), URL: pr.URL()}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (h *bitbucketHost) CreatePR(newPR NewPullRequest) (*PullRequest, error) {
+	pr, err := h.client.CreateOrUpdatePullRequest(newPR.SourceBranch, newPR.TargetBranch, newPR.Title, newPR.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PullRequest{Number: pr.ID, Title: pr.Title, SourceBranch: pr.SourceBranch(), URL: pr.URL()}, nil
+}
+
+// CreateRelease creates an annotated tag with the release notes as its message, as Bitbucket has no releases
+func (h *bitbucketHost) CreateRelease(release Release) (*Release, error) {
+	if err := h.client.CreateTag(release.Tag, release.Commit, release.Notes); err != nil {
+		if errors.Is(err, ErrTagExists) {
+			return nil, ErrReleaseExists
+		}
+		return nil, err
+	}
+
+	return &release, nil
+}
@@ -0,0 +1,173 @@
+package ci
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// ErrTagExists is returned when creating a tag that already exists
+var ErrTagExists = errors.New("tag already exists")
+
+// BitbucketClient creates pull requests and tags through the Bitbucket Cloud API for the repository the build runs for
+type BitbucketClient struct {
+	baseURL string
+	repo    string
+	token   string
+	client  *http.Client
+}
+
+type bitbucketBranch struct {
+	Branch struct {
+		Name string `json:"name"`
+	} `json:"branch"`
+}
+
+// BitbucketPullRequest is a Bitbucket pull request
+type BitbucketPullRequest struct {
+	ID          int             `json:"id"`
+	Title       string          `json:"title"`
+	Source      bitbucketBranch `json:"source"`
+	Destination bitbucketBranch `json:"destination"`
+	Links       struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+func (pr BitbucketPullRequest) SourceBranch() string {
+	return pr.Source.Branch.Name
+}
+
+func (pr BitbucketPullRequest) DestinationBranch() string {
+	return pr.Destination.Branch.Name
+}
+
+func (pr BitbucketPullRequest) URL() string {
+	return pr.Links.HTML.Href
+}
+
+// NewBitbucketClient returns a client authenticating with the repository, project or workspace access token
+func NewBitbucketClient(token string) *BitbucketClient {
+	return &BitbucketClient{
+		baseURL: "https://api.bitbucket.org/2.0",
+		repo:    os.Getenv("BITBUCKET_REPO_FULL_NAME"),
+		token:   token,
+		client:  http.DefaultClient,
+	}
+}
+
+// OpenPullRequests returns the repository's open pull requests
+func (c *BitbucketClient) OpenPullRequests() ([]BitbucketPullRequest, error) {
+	page := struct {
+		Values []BitbucketPullRequest `json:"values"`
+	}{}
+	if _, err := c.do(http.MethodGet, "/pullrequests?state=OPEN&pagelen=50", nil, &page); err != nil {
+		return nil, fmt.Errorf("error getting pull requests: %w", err)
+	}
+
+	return page.Values, nil
+}
+
+// CreateOrUpdatePullRequest updates the title and description of the open pull request from sourceBranch into
+// destinationBranch, creating it if there's none
+func (c *BitbucketClient) CreateOrUpdatePullRequest(sourceBranch, destinationBranch, title, description string) (*BitbucketPullRequest, error) {
+	prs, err := c.OpenPullRequests()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]any{"title": title, "description": description}
+
+	for _, pr := range prs {
+		if pr.SourceBranch() != sourceBranch || pr.DestinationBranch() != destinationBranch {
+			continue
+		}
+
+		updated := &BitbucketPullRequest{}
+		if _, err := c.do(http.MethodPut, fmt.Sprintf("/pullrequests/%d", pr.ID), fields, updated); err != nil {
+			return nil, fmt.Errorf("failed to update pull request: %w", err)
+		}
+		return updated, nil
+	}
+
+	fields["source"] = map[string]any{"branch": map[string]string{"name": sourceBranch}}
+	fields["destination"] = map[string]any{"branch": map[string]string{"name": destinationBranch}}
+	fields["close_source_branch"] = true
+
+	created := &BitbucketPullRequest{}
+	if _, err := c.do(http.MethodPost, "/pullrequests", fields, created); err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	return created, nil
+}
+
+// CreateTag creates an annotated tag at the commit, Bitbucket's closest equivalent to a release, with the release
+// notes as its message. ErrTagExists is returned when the tag already exists.
+func (c *BitbucketClient) CreateTag(name, hash, message string) error {
+	status, err := c.do(http.MethodGet, "/refs/tags/"+url.PathEscape(name), nil, nil)
+	if err == nil {
+		return ErrTagExists
+	}
+	if status != http.StatusNotFound {
+		return fmt.Errorf("failed to get tag %s: %w", name, err)
+	}
+
+	if _, err := c.do(http.MethodPost, "/refs/tags", map[string]any{
+		"name":    name,
+		"target":  map[string]string{"hash": hash},
+		"message": message,
+	}, nil); err != nil {
+		return fmt.Errorf("failed to create tag %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// do sends the request to the repository's API at path, decoding the response into out if set, and returns the
+// response's status code
+func (c *BitbucketClient) do(method, path string, body, out any) (int, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/repositories/%s%s", c.baseURL, c.repo, path), reqBody)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(res.Body)
+		return res.StatusCode, fmt.Errorf("%s %s: %s - %s", method, path, res.Status, msg)
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			return res.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return res.StatusCode, nil
+}
@@ -0,0 +1,46 @@
+// Package ci abstracts the CI platform the action runs in and the host of the repository, so the same binary runs in
+// GitHub Actions, GitLab CI, Bitbucket Pipelines and Azure Pipelines
+package ci
+
+type Level string
+
+const (
+	LevelNotice  Level = "notice"
+	LevelWarning Level = "warning"
+	LevelError   Level = "error"
+)
+
+// Annotation is a message attached to the run, and to a file when File is set
+type Annotation struct {
+	Level   Level
+	Title   string
+	File    string
+	Message string
+}
+
+// Platform is the CI platform the action runs in
+type Platform interface {
+	Name() string
+	// Init adapts the platform's environment to the GitHub Actions variables the action reads
+	Init() error
+	// SetOutputs exposes the outputs to later steps or jobs
+	SetOutputs(outputs map[string]string) error
+	Annotate(annotation Annotation)
+	// AppendSummary adds the markdown to the summary of the run
+	AppendSummary(markdown string) error
+}
+
+// Warning annotates the run with a warning
+func Warning(title, message string) {
+	Current().Annotate(Annotation{Level: LevelWarning, Title: title, Message: message})
+}
+
+// FileWarning annotates the file with a warning
+func FileWarning(file, title, message string) {
+	Current().Annotate(Annotation{Level: LevelWarning, Title: title, File: file, Message: message})
+}
+
+// Error annotates the run with an error
+func Error(title, message string) {
+	Current().Annotate(Annotation{Level: LevelError, Title: title, Message: message})
+}
@@ -0,0 +1,355 @@
+package ci
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitLabInit(t *testing.T) {
+	t.Setenv("GITLAB_CI", "true")
+	t.Setenv("CI_PROJECT_PATH", "acme/sdks/petstore")
+	t.Setenv("CI_COMMIT_BRANCH", "main")
+	t.Setenv("CI_PIPELINE_SOURCE", "schedule")
+	t.Setenv("GITHUB_REPOSITORY", "")
+	t.Setenv("GITHUB_REF", "")
+	t.Setenv("GITHUB_EVENT_NAME", "")
+	t.Setenv("GITHUB_RUN_ID", "42")
+
+	require.NoError(t, Current().Init())
+	assert.Equal(t, "acme/sdks/petstore", os.Getenv("GITHUB_REPOSITORY"))
+	assert.Equal(t, "refs/heads/main", os.Getenv("GITHUB_REF"))
+	assert.Equal(t, "schedule", os.Getenv("GITHUB_EVENT_NAME"))
+	// Variables that are already set are kept
+	assert.Equal(t, "42", os.Getenv("GITHUB_RUN_ID"))
+}
+
+func TestGitLabSetOutputs(t *testing.T) {
+	dotenvFile := filepath.Join(t.TempDir(), "outputs.env")
+	t.Setenv("GITLAB_CI", "true")
+	t.Setenv("SPEAKEASY_DOTENV_FILE", dotenvFile)
+
+	require.NoError(t, Current().SetOutputs(map[string]string{"go_regenerated": "true", "artifact_paths": "docs\npostman"}))
+
+	data, err := os.ReadFile(dotenvFile)
+	require.NoError(t, err)
+	assert.Equal(t, "artifact_paths=docs\\npostman\ngo_regenerated=true\n", string(data))
+}
+
+func TestGitLabClientCreateOrUpdateMergeRequest(t *testing.T) {
+	var updated map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("PRIVATE-TOKEN"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/projects/7/merge_requests":
+			_ = json.NewEncoder(w).Encode([]MergeRequest{
+				{IID: 1, SourceBranch: "feature", TargetBranch: "main"},
+				{IID: 2, SourceBranch: "speakeasy-sdk-regen", TargetBranch: "main"},
+			})
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v4/projects/7/merge_requests/2":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&updated))
+			_ = json.NewEncoder(w).Encode(MergeRequest{IID: 2, WebURL: "https://gitlab.example.com/mr/2"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("CI_API_V4_URL", server.URL+"/api/v4")
+	t.Setenv("CI_PROJECT_ID", "7")
+
+	mr, err := NewGitLabClient("token").CreateOrUpdateMergeRequest("speakeasy-sdk-regen", "main", "chore: update SDK", "body")
+	require.NoError(t, err)
+	assert.Equal(t, "https://gitlab.example.com/mr/2", mr.WebURL)
+	assert.Equal(t, map[string]string{"title": "chore: update SDK", "description": "body"}, updated)
+}
+
+func TestGitLabClientCreateRelease(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer server.Close()
+
+	t.Setenv("CI_API_V4_URL", server.URL)
+	t.Setenv("CI_PROJECT_ID", "7")
+
+	err := NewGitLabClient("token").CreateRelease("v1.0.0", "abc", "go - v1.0.0", "notes")
+	assert.ErrorIs(t, err, ErrReleaseExists)
+}
+
+func TestBitbucketInit(t *testing.T) {
+	t.Setenv("GITLAB_CI", "")
+	t.Setenv("BITBUCKET_BUILD_NUMBER", "17")
+	t.Setenv("BITBUCKET_REPO_FULL_NAME", "acme/petstore-sdks")
+	t.Setenv("BITBUCKET_TAG", "")
+	t.Setenv("BITBUCKET_BRANCH", "main")
+	t.Setenv("BITBUCKET_PR_ID", "")
+	t.Setenv("GITHUB_REPOSITORY", "")
+	t.Setenv("GITHUB_REF", "")
+	t.Setenv("GITHUB_EVENT_NAME", "")
+	t.Setenv("GITHUB_RUN_ID", "")
+
+	assert.Equal(t, "Bitbucket Pipelines", Current().Name())
+	require.NoError(t, Current().Init())
+	assert.Equal(t, "acme/petstore-sdks", os.Getenv("GITHUB_REPOSITORY"))
+	assert.Equal(t, "refs/heads/main", os.Getenv("GITHUB_REF"))
+	assert.Equal(t, "push", os.Getenv("GITHUB_EVENT_NAME"))
+	assert.Equal(t, "17", os.Getenv("GITHUB_RUN_ID"))
+}
+
+func TestBitbucketClientCreateOrUpdatePullRequest(t *testing.T) {
+	var created map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repositories/acme/petstore-sdks/pullrequests":
+			_, _ = w.Write([]byte(`{"values": [{"id": 1, "source": {"branch": {"name": "feature"}}, "destination": {"branch": {"name": "main"}}}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/repositories/acme/petstore-sdks/pullrequests":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			_, _ = w.Write([]byte(`{"id": 2, "links": {"html": {"href": "https://bitbucket.org/acme/petstore-sdks/pull-requests/2"}}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("BITBUCKET_REPO_FULL_NAME", "acme/petstore-sdks")
+	client := NewBitbucketClient("token")
+	client.baseURL = server.URL
+
+	pr, err := client.CreateOrUpdatePullRequest("speakeasy-sdk-regen", "main", "chore: update SDK", "body")
+	require.NoError(t, err)
+	assert.Equal(t, "https://bitbucket.org/acme/petstore-sdks/pull-requests/2", pr.URL())
+	assert.Equal(t, map[string]any{
+		"title":               "chore: update SDK",
+		"description":         "body",
+		"source":              map[string]any{"branch": map[string]any{"name": "speakeasy-sdk-regen"}},
+		"destination":         map[string]any{"branch": map[string]any{"name": "main"}},
+		"close_source_branch": true,
+	}, created)
+}
+
+func TestBitbucketClientCreateTag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/acme/petstore-sdks/refs/tags/v1.0.0", r.URL.Path)
+		_, _ = w.Write([]byte(`{"name": "v1.0.0"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("BITBUCKET_REPO_FULL_NAME", "acme/petstore-sdks")
+	client := NewBitbucketClient("token")
+	client.baseURL = server.URL
+
+	assert.ErrorIs(t, client.CreateTag("v1.0.0", "abc", "notes"), ErrTagExists)
+}
+
+func TestAzureDevOpsInit(t *testing.T) {
+	t.Setenv("GITLAB_CI", "")
+	t.Setenv("BITBUCKET_BUILD_NUMBER", "")
+	t.Setenv("TF_BUILD", "True")
+	t.Setenv("SYSTEM_COLLECTIONURI", "https://dev.azure.com/acme/")
+	t.Setenv("SYSTEM_TEAMPROJECT", "SDKs")
+	t.Setenv("BUILD_REPOSITORY_NAME", "petstore")
+	t.Setenv("BUILD_SOURCEBRANCH", "refs/heads/main")
+	t.Setenv("SYSTEM_PULLREQUEST_SOURCEBRANCH", "")
+	t.Setenv("BUILD_REASON", "Schedule")
+	for _, variable := range []string{"GITHUB_SERVER_URL", "GITHUB_REPOSITORY", "GITHUB_REF", "GITHUB_EVENT_NAME"} {
+		t.Setenv(variable, "")
+	}
+
+	assert.Equal(t, "Azure Pipelines", Current().Name())
+	require.NoError(t, Current().Init())
+	assert.Equal(t, "https://dev.azure.com/acme", os.Getenv("GITHUB_SERVER_URL"))
+	assert.Equal(t, "SDKs/_git/petstore", os.Getenv("GITHUB_REPOSITORY"))
+	assert.Equal(t, "refs/heads/main", os.Getenv("GITHUB_REF"))
+	assert.Equal(t, "schedule", os.Getenv("GITHUB_EVENT_NAME"))
+}
+
+func TestEscapeLoggingCommand(t *testing.T) {
+	assert.Equal(t, "docs%0Apostman 100%AZP25; done]", escapeLoggingData("docs\npostman 100%; done]"))
+	assert.Equal(t, "a%3Bb%5D", escapeLoggingProperty("a;b]"))
+}
+
+func TestAzureDevOpsClientCreateOrUpdatePullRequest(t *testing.T) {
+	var updated map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "7.0", r.URL.Query().Get("api-version"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/acme/SDKs/_apis/git/repositories/petstore/pullrequests":
+			_, _ = w.Write([]byte(`{"value": [{"pullRequestId": 3, "sourceRefName": "refs/heads/speakeasy-sdk-regen", "targetRefName": "refs/heads/main"}]}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/acme/SDKs/_apis/git/repositories/petstore/pullrequests/3":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&updated))
+			_, _ = w.Write([]byte(`{"pullRequestId": 3}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("SYSTEM_COLLECTIONURI", server.URL+"/acme/")
+	t.Setenv("SYSTEM_TEAMPROJECT", "SDKs")
+	t.Setenv("BUILD_REPOSITORY_NAME", "petstore")
+	client := NewAzureDevOpsClient("token")
+
+	pr, err := client.CreateOrUpdatePullRequest("speakeasy-sdk-regen", "main", "chore: update SDK", strings.Repeat("a", 5000))
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/acme/SDKs/_git/petstore/pullrequest/3", client.PullRequestURL(pr))
+	assert.Equal(t, "chore: update SDK", updated["title"])
+	assert.Len(t, updated["description"], maxAzureDevOpsDescriptionLength)
+}
+
+func TestAzureDevOpsClientCreateTag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "tags/v1.0.0", r.URL.Query().Get("filter"))
+		_, _ = w.Write([]byte(`{"value": [{"name": "refs/tags/v1.0.0"}, {"name": "refs/tags/v1.0.0-beta"}]}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("SYSTEM_COLLECTIONURI", server.URL+"/acme/")
+	t.Setenv("SYSTEM_TEAMPROJECT", "SDKs")
+	t.Setenv("BUILD_REPOSITORY_NAME", "petstore")
+
+	assert.ErrorIs(t, NewAzureDevOpsClient("token").CreateTag("v1.0.0", "abc", "notes"), ErrTagExists)
+}
+
+type testDriver struct {
+	gitHub
+	detected bool
+}
+
+func (d testDriver) Name() string {
+	return "Test CI"
+}
+
+func (d testDriver) Detect() bool {
+	return d.detected
+}
+
+func TestCurrent(t *testing.T) {
+	t.Setenv("GITLAB_CI", "")
+	t.Setenv("BITBUCKET_BUILD_NUMBER", "")
+	t.Setenv("TF_BUILD", "")
+
+	registered := drivers
+	t.Cleanup(func() { drivers = registered })
+
+	assert.Equal(t, "GitHub Actions", Current().Name())
+	assert.True(t, IsGitHub())
+
+	register(testDriver{detected: false})
+	assert.Equal(t, "GitHub Actions", Current().Name())
+
+	register(testDriver{detected: true})
+	assert.Equal(t, "Test CI", Current().Name())
+	assert.False(t, IsGitHub())
+}
+
+func TestGitLabHostFindPR(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]MergeRequest{
+			{IID: 1, Title: "feat: pets", SourceBranch: "feature"},
+			{IID: 2, Title: "chore: 🐝 Update SDK - Generate", SourceBranch: "speakeasy-sdk-regen", WebURL: "https://gitlab.example.com/mr/2"},
+		})
+	}))
+	defer server.Close()
+
+	t.Setenv("GITLAB_CI", "true")
+	t.Setenv("CI_API_V4_URL", server.URL)
+	t.Setenv("CI_PROJECT_ID", "7")
+	t.Setenv("GITHUB_SERVER_URL", "https://gitlab.example.com")
+	t.Setenv("GITHUB_REPOSITORY", "acme/petstore")
+
+	host := Current().NewHost("token")
+	assert.Equal(t, "https://gitlab.example.com/acme/petstore", host.(*gitLabHost).URL)
+
+	pr, err := host.FindPR("chore: 🐝 Update SDK")
+	require.NoError(t, err)
+	assert.Equal(t, &PullRequest{Number: 2, Title: "chore: 🐝 Update SDK - Generate", SourceBranch: "speakeasy-sdk-regen", URL: "https://gitlab.example.com/mr/2"}, pr)
+
+	pr, err = host.FindPR("chore: 🐝 Update Specs")
+	require.NoError(t, err)
+	assert.Nil(t, pr)
+}
+
+func TestGitHubHost(t *testing.T) {
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/acme/petstore/pulls":
+			if r.URL.Query().Get("head") != "" {
+				assert.Equal(t, "acme:speakeasy-sdk-regen", r.URL.Query().Get("head"))
+				assert.Equal(t, "main", r.URL.Query().Get("base"))
+			}
+			_ = json.NewEncoder(w).Encode([]map[string]any{
+				{"number": 1, "title": "feat: pets", "head": map[string]any{"ref": "feature"}},
+				{"number": 2, "title": "chore: 🐝 Update SDK - Generate", "head": map[string]any{"ref": "speakeasy-sdk-regen"}, "html_url": "https://github.com/acme/petstore/pull/2", "labels": []map[string]any{{"name": "patch"}, {"name": "docs"}}},
+			})
+		case "PATCH /repos/acme/petstore/pulls/1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"number": 1, "title": "chore: 🐝 Update SDK - Generate 1.1.0", "head": map[string]any{"ref": "feature"}, "labels": []map[string]any{{"name": "patch"}, {"name": "docs"}}})
+		case "GET /repos/acme/petstore/labels":
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"name": "patch", "description": "Patch version bump"}})
+		case "POST /repos/acme/petstore/labels":
+			_ = json.NewEncoder(w).Encode(map[string]any{"name": "minor"})
+		case "POST /repos/acme/petstore/issues/1/labels":
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"name": "patch"}, {"name": "docs"}, {"name": "minor"}})
+		case "DELETE /repos/acme/petstore/issues/1/labels/patch":
+			w.WriteHeader(http.StatusOK)
+		case "POST /repos/acme/petstore/releases":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message": "Validation Failed", "errors": [{"resource": "Release", "code": "already_exists", "field": "tag_name"}]}`))
+		case "GET /repos/acme/petstore/releases/tags/v1.0.0":
+			_ = json.NewEncoder(w).Encode(map[string]any{"tag_name": "v1.0.0", "body": "notes\n\nPublishing Completed"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_SERVER_URL", "https://github.com")
+	t.Setenv("GITHUB_REPOSITORY", "acme/petstore")
+	t.Setenv("GITHUB_REPOSITORY_OWNER", "acme")
+
+	host := gitHub{}.NewHost("token").(*gitHubHost)
+	assert.Equal(t, "https://github.com/acme/petstore", host.URL)
+	baseURL, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+	host.Client().BaseURL = baseURL
+
+	pr, err := host.FindPR("chore: 🐝 Update SDK")
+	require.NoError(t, err)
+	assert.Equal(t, &PullRequest{Number: 2, Title: "chore: 🐝 Update SDK - Generate", SourceBranch: "speakeasy-sdk-regen", URL: "https://github.com/acme/petstore/pull/2", Labels: []string{"patch", "docs"}}, pr)
+
+	// The managed patch label is replaced by minor, the docs label isn't managed so it's kept
+	pr, err = host.CreatePR(NewPullRequest{
+		SourceBranch:  "speakeasy-sdk-regen",
+		TargetBranch:  "main",
+		Title:         "chore: 🐝 Update SDK - Generate 1.1.0",
+		Body:          "# SDK update",
+		Labels:        []string{"minor"},
+		ManagedLabels: map[string]string{"patch": "Patch version bump", "minor": "Minor version bump"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs", "minor"}, pr.Labels)
+	assert.Contains(t, requests, "POST /repos/acme/petstore/labels")
+	assert.Contains(t, requests, "DELETE /repos/acme/petstore/issues/1/labels/patch")
+
+	existing, err := host.CreateRelease(Release{Tag: "v1.0.0", Commit: "abc", Name: "go - v1.0.0", Notes: "notes"})
+	assert.ErrorIs(t, err, ErrReleaseExists)
+	require.NotNil(t, existing)
+	assert.Contains(t, existing.Notes, PublishingCompleted)
+}
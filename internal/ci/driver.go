@@ -0,0 +1,168 @@
+package ci
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	gitHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// ErrReleaseExists is returned when creating a release for a tag that already has one
+var ErrReleaseExists = errors.New("release already exists")
+
+// ErrUnsupported is returned by hosts without the operation, e.g. creating commits through an API
+var ErrUnsupported = errors.New("not supported by the host")
+
+// PublishingCompleted marks the notes of releases whose SDKs were published to their registries
+const PublishingCompleted = "Publishing Completed"
+
+// Driver is a CI platform together with the host of the repositories its pipelines run for. GitHub Actions is the
+// default, the drivers of the other platforms the action supports register themselves in their init.
+type Driver interface {
+	Platform
+	// Detect returns whether the action runs on the driver's platform
+	Detect() bool
+	// NewHost returns the host of the repository the pipeline runs for, authenticated with the access token
+	NewHost(accessToken string) Host
+}
+
+// Host clones, pushes to and creates the pull requests and releases of the repository the pipeline runs for
+type Host interface {
+	// Auth returns the credentials for fetching from the repository
+	Auth() transport.AuthMethod
+	// Clone clones the repository into dir, setting the URL and credentials of opts
+	Clone(dir string, opts *git.CloneOptions) (*git.Repository, error)
+	// Push pushes to the repository's origin, setting the credentials of opts
+	Push(repo *git.Repository, opts *git.PushOptions) error
+	// CreateCommit recreates the local commit on the branch through the host's API, which signs it, and returns the
+	// hash of the commit created. The commit's parent must already be on the host. ErrUnsupported is returned when the
+	// host can't.
+	CreateCommit(repo *git.Repository, branch string, hash plumbing.Hash) (string, error)
+	// FindPR returns the open pull request whose title starts with titlePrefix, or nil if there's none
+	FindPR(titlePrefix string) (*PullRequest, error)
+	// CreatePR updates the open pull request from the source into the target branch, creating it if there's none
+	CreatePR(pr NewPullRequest) (*PullRequest, error)
+	// CreateRelease creates a release, or the host's closest equivalent, for the tag at the commit. ErrReleaseExists is
+	// returned along with the existing release, when the host can look it up, when the tag already has one.
+	CreateRelease(release Release) (*Release, error)
+	// PromoteRelease promotes the pre-release of the tag to a release, clearing its completed publishing so it's
+	// published again. It returns false when the tag has no pre-release.
+	PromoteRelease(tag string) (bool, error)
+	// DeleteRelease deletes the release of the tag, if it has one, leaving the tag itself to be deleted with git
+	DeleteRelease(tag string) error
+}
+
+// PullRequest is a pull request, or merge request, of the host
+type PullRequest struct {
+	Number       int
+	Title        string
+	Body         string
+	SourceBranch string
+	URL          string
+	// Labels are the names of the pull request's labels, on hosts with labels
+	Labels []string
+}
+
+// NewPullRequest is the pull request created or updated by CreatePR
+type NewPullRequest struct {
+	SourceBranch string
+	TargetBranch string
+	Title        string
+	Body         string
+	// Labels replace the pull request's labels among ManagedLabels, hosts without labels ignore them
+	Labels []string
+	// ManagedLabels are the descriptions of the labels the action manages by name, which are created in the repository
+	ManagedLabels map[string]string
+}
+
+// Release is a release of the host. Pre-releases, drafts and discussions are only supported by GitHub, the other hosts
+// ignore them.
+type Release struct {
+	Tag                string
+	Commit             string
+	Name               string
+	Notes              string
+	URL                string
+	Prerelease         bool
+	Draft              bool
+	DiscussionCategory string
+}
+
+var drivers []Driver
+
+// register adds the driver, detected after the drivers registered before it
+func register(driver Driver) {
+	drivers = append(drivers, driver)
+}
+
+// Current returns the driver of the platform the action runs in, defaulting to GitHub Actions
+func Current() Driver {
+	for _, driver := range drivers {
+		if driver.Detect() {
+			return driver
+		}
+	}
+
+	return gitHub{}
+}
+
+// IsGitHub returns whether the action runs in GitHub Actions
+func IsGitHub() bool {
+	_, ok := Current().(gitHub)
+	return ok
+}
+
+// Remote clones from and pushes to the repository at URL over HTTPS with basic auth. Hosts embed it to implement
+// Auth, Clone and Push, and the operations of the features they don't have, e.g. pre-releases.
+type Remote struct {
+	URL      string
+	Username string
+	Password string
+}
+
+// NewRemote returns the remote of the repository the pipeline runs for, which Init resolves like GitHub's
+func NewRemote(username, password string) Remote {
+	repoURL, err := url.JoinPath(os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"))
+	if err != nil {
+		repoURL = fmt.Sprintf("%s/%s", os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"))
+	}
+
+	return Remote{URL: repoURL, Username: username, Password: password}
+}
+
+func (r Remote) Auth() transport.AuthMethod {
+	return &gitHttp.BasicAuth{Username: r.Username, Password: r.Password}
+}
+
+func (r Remote) Clone(dir string, opts *git.CloneOptions) (*git.Repository, error) {
+	opts.URL = r.URL
+	opts.Auth = r.Auth()
+
+	return git.PlainClone(dir, false, opts)
+}
+
+func (r Remote) Push(repo *git.Repository, opts *git.PushOptions) error {
+	opts.Auth = r.Auth()
+
+	return repo.Push(opts)
+}
+
+// CreateCommit is unsupported, commits are pushed with git
+func (r Remote) CreateCommit(repo *git.Repository, branch string, hash plumbing.Hash) (string, error) {
+	return "", ErrUnsupported
+}
+
+// PromoteRelease doesn't promote anything, as there are no pre-releases
+func (r Remote) PromoteRelease(tag string) (bool, error) {
+	return false, nil
+}
+
+// DeleteRelease doesn't delete anything, as the release is the tag
+func (r Remote) DeleteRelease(tag string) error {
+	return nil
+}
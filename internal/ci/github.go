@@ -0,0 +1,468 @@
+package ci
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+	"golang.org/x/exp/rand"
+	"golang.org/x/oauth2"
+)
+
+type gitHub struct{}
+
+func (gitHub) Name() string {
+	return "GitHub Actions"
+}
+
+func (gitHub) Init() error {
+	return nil
+}
+
+func (gitHub) Detect() bool {
+	return true
+}
+
+// NewHost returns the repository the workflow runs for, authenticated with the workflow's token or a personal access
+// token
+func (gitHub) NewHost(accessToken string) Host {
+	return &gitHubHost{Remote: NewRemote("gen", accessToken), client: NewGitHubClient(accessToken)}
+}
+
+func (gitHub) SetOutputs(outputs map[string]string) error {
+	f, err := os.OpenFile(os.Getenv("GITHUB_OUTPUT"), os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("error opening output file: %w", err)
+	}
+	defer f.Close()
+
+	for k, v := range outputs {
+		if k == "cli_output" || strings.Contains(v, "\n") {
+			delimiter, err := randomDelimiter()
+			if err != nil {
+				return err
+			}
+
+			if err := printAndWriteString(f, fmt.Sprintf("%s<<%s\n%s\n%s\n", k, delimiter, v, delimiter)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := printAndWriteString(f, fmt.Sprintf("%s=%s\n", k, v)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (gitHub) Annotate(annotation Annotation) {
+	properties := []string{}
+	if annotation.File != "" {
+		properties = append(properties, "file="+annotation.File)
+	}
+	if annotation.Title != "" {
+		properties = append(properties, "title="+annotation.Title)
+	}
+
+	command := string(annotation.Level)
+	if len(properties) > 0 {
+		command += " " + strings.Join(properties, ",")
+	}
+
+	fmt.Printf("::%s::%s\n", command, strings.ReplaceAll(annotation.Message, "\n", "%0A"))
+}
+
+func (gitHub) AppendSummary(markdown string) error {
+	summaryFile := os.Getenv("GITHUB_STEP_SUMMARY")
+	if summaryFile == "" {
+		return nil
+	}
+
+	return appendToFile(summaryFile, markdown)
+}
+
+func printAndWriteString(f *os.File, out string) error {
+	fmt.Print(out)
+	// Don't persist outputs to GH actions if we are in test mode
+	if !environment.IsTestMode() {
+		if _, err := f.WriteString(out); err != nil {
+			return fmt.Errorf("error writing output: %w", err)
+		}
+	}
+	return nil
+}
+
+func randomDelimiter() (string, error) {
+	b := make([]byte, 15)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("error generating random delimiter: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func appendToFile(path, contents string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("error opening job summary file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(contents); err != nil {
+		return fmt.Errorf("error writing job summary: %w", err)
+	}
+
+	return nil
+}
+
+// GitHubHost is a repository hosted on GitHub, whose API is used directly for the features only GitHub has, e.g.
+// labelling pull requests or drafting releases
+type GitHubHost interface {
+	Host
+	Client() *github.Client
+}
+
+// NewGitHubClient returns a client of the GitHub API authenticated with the access token, which waits out GitHub's rate
+// limits where the wait is reasonable
+func NewGitHubClient(accessToken string) *github.Client {
+	tc := oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: accessToken}))
+	tc.Transport = newRateLimitTransport(tc.Transport)
+
+	return github.NewClient(tc)
+}
+
+type gitHubHost struct {
+	Remote
+	client *github.Client
+}
+
+func (h *gitHubHost) Client() *github.Client {
+	return h.client
+}
+
+func (h *gitHubHost) FindPR(titlePrefix string) (*PullRequest, error) {
+	owner, repo := gitHubRepo()
+	prs, _, err := h.client.PullRequests.List(context.Background(), owner, repo, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pull requests: %w", err)
+	}
+
+	for _, pr := range prs {
+		if strings.HasPrefix(pr.GetTitle(), titlePrefix) {
+			return GitHubPullRequest(pr), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (h *gitHubHost) CreatePR(newPR NewPullRequest) (*PullRequest, error) {
+	ctx := context.Background()
+	owner, repo := gitHubRepo()
+
+	h.upsertLabels(ctx, newPR.ManagedLabels)
+
+	prs, _, err := h.client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+		Head: owner + ":" + newPR.SourceBranch,
+		Base: newPR.TargetBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting pull requests: %w", err)
+	}
+
+	var pr *github.PullRequest
+	if len(prs) > 0 {
+		logging.Info("Updating PR")
+
+		pr, _, err = h.client.PullRequests.Edit(ctx, owner, repo, prs[0].GetNumber(), &github.PullRequest{
+			Title: github.String(newPR.Title),
+			Body:  github.String(newPR.Body),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update PR: %w", err)
+		}
+	} else {
+		logging.Info("Creating PR")
+
+		pr, _, err = h.client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+			Title:               github.String(newPR.Title),
+			Body:                github.String(newPR.Body),
+			Head:                github.String(newPR.SourceBranch),
+			Base:                github.String(newPR.TargetBranch),
+			MaintainerCanModify: github.Bool(true),
+		})
+		if err != nil {
+			messageSuffix := ""
+			if strings.Contains(err.Error(), "GitHub Actions is not permitted to create or approve pull requests") {
+				messageSuffix += "\nNavigate to Settings > Actions > Workflow permissions and ensure that allow GitHub Actions to create and approve pull requests is checked. For more information see https://www.speakeasy.com/docs/advanced-setup/github-setup"
+			}
+			return nil, fmt.Errorf("failed to create PR: %w%s", err, messageSuffix)
+		}
+	}
+
+	// Labels MUST always be set after updating the PR
+	h.setLabels(ctx, pr, newPR.Labels, newPR.ManagedLabels)
+
+	return GitHubPullRequest(pr), nil
+}
+
+// upsertLabels creates the managed labels missing from the repo and updates the descriptions of those that changed.
+// Labelling is best effort, so failures are only logged.
+func (h *gitHubHost) upsertLabels(ctx context.Context, managed map[string]string) {
+	if len(managed) == 0 {
+		return
+	}
+
+	owner, repo := gitHubRepo()
+	existing, _, err := h.client.Issues.ListLabels(ctx, owner, repo, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		logging.Debug("failed to list labels: %v", err)
+		return
+	}
+	descriptions := map[string]string{}
+	for _, label := range existing {
+		descriptions[label.GetName()] = label.GetDescription()
+	}
+
+	for name, description := range managed {
+		current, ok := descriptions[name]
+		switch {
+		case !ok:
+			_, _, err = h.client.Issues.CreateLabel(ctx, owner, repo, &github.Label{Name: github.String(name), Description: github.String(description)})
+		case current != description:
+			_, _, err = h.client.Issues.EditLabel(ctx, owner, repo, name, &github.Label{Name: github.String(name), Description: github.String(description)})
+		default:
+			continue
+		}
+		if err != nil {
+			logging.Debug("failed to upsert label %s: %v", name, err)
+			return
+		}
+	}
+}
+
+// setLabels adds the desired labels missing from the PR and removes its managed labels that aren't desired, leaving the
+// labels the action doesn't manage alone
+func (h *gitHubHost) setLabels(ctx context.Context, pr *github.PullRequest, desired []string, managed map[string]string) {
+	owner, repo := gitHubRepo()
+
+	actual := map[string]bool{}
+	for _, label := range pr.Labels {
+		actual[label.GetName()] = true
+	}
+
+	shouldAdd := []string{}
+	for _, name := range desired {
+		if !actual[name] {
+			shouldAdd = append(shouldAdd, name)
+		}
+	}
+	if len(shouldAdd) > 0 {
+		labels, _, err := h.client.Issues.AddLabelsToIssue(ctx, owner, repo, pr.GetNumber(), shouldAdd)
+		if err != nil {
+			logging.Info("failed to add labels %v: %s", shouldAdd, err.Error())
+		} else {
+			pr.Labels = labels
+		}
+	}
+
+	for name := range actual {
+		if _, ok := managed[name]; !ok || slices.Contains(desired, name) {
+			continue
+		}
+		if _, err := h.client.Issues.RemoveLabelForIssue(ctx, owner, repo, pr.GetNumber(), name); err != nil {
+			logging.Info("failed to remove labels %s: %s", name, err.Error())
+			continue
+		}
+		pr.Labels = slices.DeleteFunc(pr.Labels, func(label *github.Label) bool { return label.GetName() == name })
+	}
+}
+
+// CreateRelease drafts the release without publishing it when release.Draft is set, creating its tag first as drafts
+// don't create their tag until they're published
+func (h *gitHubHost) CreateRelease(release Release) (*Release, error) {
+	ctx := context.Background()
+	owner, repo := gitHubRepo()
+
+	if release.Draft {
+		draft, err := FindGitHubDraftRelease(h.client, release.Tag)
+		if err != nil {
+			return nil, err
+		}
+		if draft != nil {
+			return gitHubRelease(draft), ErrReleaseExists
+		}
+
+		if err := h.createTag(release.Tag, release.Commit); err != nil {
+			return nil, err
+		}
+	}
+
+	created, res, err := h.client.Repositories.CreateRelease(ctx, owner, repo, &github.RepositoryRelease{
+		TagName:         github.String(release.Tag),
+		TargetCommitish: github.String(release.Commit),
+		Name:            github.String(release.Name),
+		Body:            github.String(release.Notes),
+		Draft:           github.Bool(release.Draft),
+		Prerelease:      github.Bool(release.Prerelease),
+		// GitHub creates a discussion in the category with the release notes as its body
+		DiscussionCategoryName: stringOrNil(release.DiscussionCategory),
+	})
+	if err != nil {
+		if existing, _, err := h.client.Repositories.GetReleaseByTag(ctx, owner, repo, release.Tag); err == nil && existing != nil {
+			return gitHubRelease(existing), ErrReleaseExists
+		}
+
+		var errRes *github.ErrorResponse
+		if res != nil && res.StatusCode == http.StatusUnprocessableEntity && errors.As(err, &errRes) {
+			for _, e := range errRes.Errors {
+				if e.Code == "already_exists" {
+					return nil, ErrReleaseExists
+				}
+			}
+		}
+		return nil, fmt.Errorf("failed to create release for tag %s: %w", release.Tag, err)
+	}
+
+	return gitHubRelease(created), nil
+}
+
+// createTag creates the tag at the commit. A tag already at the commit is from a previous run of the same release.
+func (h *gitHubHost) createTag(tag, commit string) error {
+	owner, repo := gitHubRepo()
+	ref := "refs/tags/" + tag
+
+	existing, res, err := h.client.Git.GetRef(context.Background(), owner, repo, ref)
+	if err == nil && existing.GetObject().GetSHA() == commit {
+		return nil
+	}
+	if err != nil && (res == nil || res.StatusCode != http.StatusNotFound) {
+		return fmt.Errorf("failed to get tag %s: %w", tag, err)
+	}
+
+	if _, _, err := h.client.Git.CreateRef(context.Background(), owner, repo, &github.Reference{
+		Ref:    github.String(ref),
+		Object: &github.GitObject{SHA: github.String(commit)},
+	}); err != nil {
+		return fmt.Errorf("failed to create tag %s: %w", tag, err)
+	}
+
+	return nil
+}
+
+func (h *gitHubHost) PromoteRelease(tag string) (bool, error) {
+	owner, repo := gitHubRepo()
+
+	release, res, err := h.client.Repositories.GetReleaseByTag(context.Background(), owner, repo, tag)
+	switch {
+	case res != nil && res.StatusCode == http.StatusNotFound:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("failed to get release for tag %s: %w", tag, err)
+	case !release.GetPrerelease():
+		return false, nil
+	}
+
+	release.Prerelease = github.Bool(false)
+	release.MakeLatest = github.String("true")
+	release.Body = github.String(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(release.GetBody()), PublishingCompleted)))
+
+	if _, _, err := h.client.Repositories.EditRelease(context.Background(), owner, repo, release.GetID(), release); err != nil {
+		return false, fmt.Errorf("failed to promote release for tag %s: %w", tag, err)
+	}
+
+	return true, nil
+}
+
+func (h *gitHubHost) DeleteRelease(tag string) error {
+	owner, repo := gitHubRepo()
+
+	release, res, err := h.client.Repositories.GetReleaseByTag(context.Background(), owner, repo, tag)
+	switch {
+	case res != nil && res.StatusCode == http.StatusNotFound:
+		// The tag was created without a release
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to get release for tag %s: %w", tag, err)
+	}
+
+	if _, err := h.client.Repositories.DeleteRelease(context.Background(), owner, repo, release.GetID()); err != nil {
+		return fmt.Errorf("failed to delete release for tag %s: %w", tag, err)
+	}
+
+	return nil
+}
+
+// FindGitHubDraftRelease returns the draft release of the tag, nil if there's none. GitHub doesn't find draft releases
+// by their tag, so they're looked up among the repo's releases.
+func FindGitHubDraftRelease(client *github.Client, tag string) (*github.RepositoryRelease, error) {
+	owner, repo := gitHubRepo()
+
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		releases, res, err := client.Repositories.ListReleases(context.Background(), owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list releases: %w", err)
+		}
+		for _, release := range releases {
+			if release.GetDraft() && release.GetTagName() == tag {
+				return release, nil
+			}
+		}
+		if res.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = res.NextPage
+	}
+}
+
+func gitHubRelease(release *github.RepositoryRelease) *Release {
+	return &Release{
+		Tag:        release.GetTagName(),
+		Commit:     release.GetTargetCommitish(),
+		Name:       release.GetName(),
+		Notes:      release.GetBody(),
+		URL:        release.GetHTMLURL(),
+		Prerelease: release.GetPrerelease(),
+		Draft:      release.GetDraft(),
+	}
+}
+
+func stringOrNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return github.String(s)
+}
+
+// GitHubPullRequest returns the host pull request of the GitHub pull request
+func GitHubPullRequest(pr *github.PullRequest) *PullRequest {
+	labels := []string{}
+	for _, label := range pr.Labels {
+		labels = append(labels, label.GetName())
+	}
+
+	return &PullRequest{
+		Number:       pr.GetNumber(),
+		Title:        pr.GetTitle(),
+		Body:         pr.GetBody(),
+		SourceBranch: pr.GetHead().GetRef(),
+		URL:          pr.GetHTMLURL(),
+		Labels:       labels,
+	}
+}
+
+// gitHubRepo returns the owner and name of the repository the workflow runs for
+func gitHubRepo() (string, string) {
+	repo := os.Getenv("GITHUB_REPOSITORY")
+	return os.Getenv("GITHUB_REPOSITORY_OWNER"), repo[strings.LastIndex(repo, "/")+1:]
+}
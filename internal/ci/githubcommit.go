@@ -0,0 +1,163 @@
+package ci
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"github.com/google/go-github/v63/github"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// CreateCommit recreates the commit through the GitHub API, which signs commits without an author so they're verified
+func (h *gitHubHost) CreateCommit(repo *git.Repository, branch string, hash plumbing.Hash) (string, error) {
+	ctx := context.Background()
+	owner, repoName := gitHubRepo()
+
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return "", fmt.Errorf("error getting commit: %w", err)
+	}
+	if commit.NumParents() != 1 {
+		return "", fmt.Errorf("commits with %d parents can't be created through the API", commit.NumParents())
+	}
+	parent, err := commit.Parent(0)
+	if err != nil {
+		return "", fmt.Errorf("error getting parent commit: %w", err)
+	}
+
+	entries, err := apiTreeEntries(parent, commit, func(content []byte) (string, error) {
+		blob, _, err := h.client.Git.CreateBlob(ctx, owner, repoName, &github.Blob{
+			Content:  github.String(base64.StdEncoding.EncodeToString(content)),
+			Encoding: github.String("base64"),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to create blob: %w", err)
+		}
+		return blob.GetSHA(), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	logging.Info("Creating commit of %d changed files through the GitHub API", len(entries))
+
+	tree, _, err := h.client.Git.CreateTree(ctx, owner, repoName, parent.TreeHash.String(), entries)
+	if err != nil {
+		return "", fmt.Errorf("failed to create tree: %w", err)
+	}
+
+	created, _, err := h.client.Git.CreateCommit(ctx, owner, repoName, &github.Commit{
+		Message: github.String(commit.Message),
+		Tree:    tree,
+		Parents: []*github.Commit{{SHA: github.String(parent.Hash.String())}},
+	}, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create commit: %w", err)
+	}
+
+	ref := &github.Reference{
+		Ref:    github.String(plumbing.NewBranchReferenceName(branch).String()),
+		Object: &github.GitObject{SHA: created.SHA},
+	}
+	_, response, err := h.client.Git.GetRef(ctx, owner, repoName, ref.GetRef())
+	if err != nil && (response == nil || response.StatusCode != http.StatusNotFound) {
+		return "", fmt.Errorf("failed to get ref %s: %w", ref.GetRef(), err)
+	}
+	if err != nil {
+		_, _, err = h.client.Git.CreateRef(ctx, owner, repoName, ref)
+	} else {
+		// Forced like the git push, as the branch is reset at the beginning of the workflow
+		_, _, err = h.client.Git.UpdateRef(ctx, owner, repoName, ref, true)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to update branch %s: %w", branch, err)
+	}
+
+	logging.Info("Created verified commit %s on branch %s", created.GetSHA(), branch)
+
+	return created.GetSHA(), nil
+}
+
+// apiTreeEntries returns the tree entries of the files the commit changed from its parent, to create its tree on top of
+// the parent's through the API. Text files are sent inline, other content is created as blobs through createBlob.
+func apiTreeEntries(parent, commit *object.Commit, createBlob func(content []byte) (string, error)) ([]*github.TreeEntry, error) {
+	parentTree, err := parent.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("error getting parent tree: %w", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("error getting tree: %w", err)
+	}
+
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, fmt.Errorf("error diffing commit: %w", err)
+	}
+
+	entries := []*github.TreeEntry{}
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+
+		if action == merkletrie.Delete {
+			// Entries without a SHA or content delete the path
+			entries = append(entries, &github.TreeEntry{
+				Path: github.String(change.From.Name),
+				Mode: github.String(treeEntryMode(change.From.TreeEntry.Mode)),
+				Type: github.String("blob"),
+			})
+			continue
+		}
+
+		entry := &github.TreeEntry{
+			Path: github.String(change.To.Name),
+			Mode: github.String(treeEntryMode(change.To.TreeEntry.Mode)),
+			Type: github.String("blob"),
+		}
+
+		if change.To.TreeEntry.Mode == filemode.Submodule {
+			entry.Type = github.String("commit")
+			entry.SHA = github.String(change.To.TreeEntry.Hash.String())
+			entries = append(entries, entry)
+			continue
+		}
+
+		file, err := tree.TreeEntryFile(&change.To.TreeEntry)
+		if err != nil {
+			return nil, fmt.Errorf("error getting %s: %w", change.To.Name, err)
+		}
+		contents, err := file.Contents()
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", change.To.Name, err)
+		}
+
+		if utf8.ValidString(contents) {
+			entry.Content = github.String(contents)
+		} else {
+			sha, err := createBlob([]byte(contents))
+			if err != nil {
+				return nil, err
+			}
+			entry.SHA = github.String(sha)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// treeEntryMode formats the file mode as the API expects, e.g. 100644
+func treeEntryMode(mode filemode.FileMode) string {
+	return fmt.Sprintf("%06o", uint32(mode))
+}
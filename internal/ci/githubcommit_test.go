@@ -0,0 +1,79 @@
+package ci
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/google/go-github/v63/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPITreeEntries(t *testing.T) {
+	mfs := memfs.New()
+	repo, err := git.Init(memory.NewStorage(), mfs)
+	require.NoError(t, err)
+
+	write := func(name string, content []byte) {
+		f, err := mfs.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	}
+	write("fixtures/sample.txt", []byte("generated\n"))
+	write("fixtures/nested/names.txt", []byte("names\n"))
+
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = wt.Add("fixtures")
+	require.NoError(t, err)
+	_, err = wt.Commit("ci: generated", &git.CommitOptions{
+		Author: &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Unix(0, 0)},
+	})
+	require.NoError(t, err)
+
+	write("fixtures/sample.txt", []byte("regenerated\n"))
+	write("fixtures/logo.png", []byte{0x89, 0x50, 0x4e, 0x47, 0xff})
+	require.NoError(t, mfs.Remove("fixtures/nested/names.txt"))
+
+	_, err = wt.Add("fixtures/logo.png")
+	require.NoError(t, err)
+	hash, err := wt.Commit("ci: regenerated", &git.CommitOptions{
+		All:    true,
+		Author: &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Unix(0, 0)},
+	})
+	require.NoError(t, err)
+
+	commit, err := repo.CommitObject(hash)
+	require.NoError(t, err)
+	parent, err := commit.Parent(0)
+	require.NoError(t, err)
+
+	blobs := [][]byte{}
+	entries, err := apiTreeEntries(parent, commit, func(content []byte) (string, error) {
+		blobs = append(blobs, content)
+		return "blob-sha", nil
+	})
+	require.NoError(t, err)
+
+	byPath := map[string]*github.TreeEntry{}
+	for _, entry := range entries {
+		byPath[entry.GetPath()] = entry
+	}
+	require.Len(t, byPath, 3)
+
+	assert.Equal(t, "regenerated\n", byPath["fixtures/sample.txt"].GetContent())
+	assert.Equal(t, "100644", byPath["fixtures/sample.txt"].GetMode())
+
+	assert.Equal(t, "blob-sha", byPath["fixtures/logo.png"].GetSHA())
+	assert.Equal(t, [][]byte{{0x89, 0x50, 0x4e, 0x47, 0xff}}, blobs)
+
+	deleted := byPath["fixtures/nested/names.txt"]
+	assert.Nil(t, deleted.SHA)
+	assert.Nil(t, deleted.Content)
+}
@@ -0,0 +1,124 @@
+package ci
+
+import (
+	"os"
+	"strings"
+)
+
+type gitLab struct{}
+
+func init() {
+	register(gitLab{})
+}
+
+// gitLabVariables sets the GitHub Actions variables the action reads from the GitLab CI variables of the pipeline
+var gitLabVariables = map[string]func() string{
+	"GITHUB_SERVER_URL":       func() string { return os.Getenv("CI_SERVER_URL") },
+	"GITHUB_REPOSITORY":       func() string { return os.Getenv("CI_PROJECT_PATH") },
+	"GITHUB_REPOSITORY_OWNER": func() string { return os.Getenv("CI_PROJECT_NAMESPACE") },
+	"GITHUB_WORKSPACE":        func() string { return os.Getenv("CI_PROJECT_DIR") },
+	"GITHUB_RUN_ID":           func() string { return os.Getenv("CI_PIPELINE_ID") },
+	"GITHUB_SHA":              func() string { return os.Getenv("CI_COMMIT_SHA") },
+	"GITHUB_WORKFLOW":         func() string { return os.Getenv("CI_JOB_NAME") },
+	"GITHUB_BASE_REF":         func() string { return os.Getenv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME") },
+	"GITHUB_REF": func() string {
+		for _, branch := range []string{os.Getenv("CI_COMMIT_BRANCH"), os.Getenv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"), os.Getenv("CI_DEFAULT_BRANCH")} {
+			if branch != "" {
+				return "refs/heads/" + branch
+			}
+		}
+		return ""
+	},
+	"GITHUB_EVENT_NAME": func() string {
+		switch os.Getenv("CI_PIPELINE_SOURCE") {
+		case "schedule":
+			return "schedule"
+		case "push":
+			return "push"
+		case "merge_request_event":
+			return "pull_request"
+		case "web", "api", "trigger":
+			return "workflow_dispatch"
+		}
+		return ""
+	},
+}
+
+func (gitLab) Name() string {
+	return "GitLab CI"
+}
+
+func (gitLab) Detect() bool {
+	return os.Getenv("GITLAB_CI") == "true"
+}
+
+// NewHost returns the project the pipeline runs for, authenticated with a personal, project or group access token
+func (gitLab) NewHost(accessToken string) Host {
+	return &gitLabHost{Remote: NewRemote("oauth2", accessToken), client: NewGitLabClient(accessToken)}
+}
+
+// Init sets the GitHub Actions variables the action reads that aren't already set from their GitLab CI counterparts
+func (gitLab) Init() error {
+	return setGitHubVariables(gitLabVariables)
+}
+
+// SetOutputs writes the outputs to a dotenv file, exposed to later jobs by declaring it as the job's
+// artifacts:reports:dotenv
+func (gitLab) SetOutputs(outputs map[string]string) error {
+	return writeDotenv(gitLabFile("SPEAKEASY_DOTENV_FILE", "speakeasy.env"), outputs)
+}
+
+// Annotate logs the annotation as GitLab CI has no equivalent
+func (gitLab) Annotate(annotation Annotation) {
+	logAnnotation(annotation)
+}
+
+// AppendSummary appends to a markdown file, which can be attached to the job as an artifact
+func (gitLab) AppendSummary(markdown string) error {
+	return appendToFile(gitLabFile("SPEAKEASY_SUMMARY_FILE", "speakeasy-summary.md"), markdown)
+}
+
+func gitLabFile(variable, defaultName string) string {
+	return pipelineFile(variable, os.Getenv("CI_PROJECT_DIR"), defaultName)
+}
+
+type gitLabHost struct {
+	Remote
+	client *GitLabClient
+}
+
+func (h *gitLabHost) FindPR(titlePrefix string) (*PullRequest, error) {
+	mrs, err := h.client.OpenMergeRequests()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mr := range mrs {
+		if strings.HasPrefix(mr.Title, titlePrefix) {
+			return &PullRequest{Number: mr.IID, Title: mr.Title, SourceBranch: mr.SourceBranch, URL: mr.WebURL}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (h *gitLabHost) CreatePR(pr NewPullRequest) (*PullRequest, error) {
+	mr, err := h.client.CreateOrUpdateMergeRequest(pr.SourceBranch, pr.TargetBranch, pr.Title, pr.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PullRequest{Number: mr.IID, Title: mr.Title, SourceBranch: mr.SourceBranch, URL: mr.WebURL}, nil
+}
+
+func (h *gitLabHost) CreateRelease(release Release) (*Release, error) {
+	if err := h.client.CreateRelease(release.Tag, release.Commit, release.Name, release.Notes); err != nil {
+		return nil, err
+	}
+
+	return &release, nil
+}
+
+func (h *gitLabHost) DeleteRelease(tag string) error {
+	return h.client.DeleteRelease(tag)
+}
@@ -0,0 +1,155 @@
+package ci
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// GitLabClient creates merge requests and releases through the GitLab API for the project the pipeline runs for
+type GitLabClient struct {
+	baseURL   string
+	projectID string
+	token     string
+	client    *http.Client
+}
+
+// MergeRequest is a GitLab merge request
+type MergeRequest struct {
+	IID          int    `json:"iid"`
+	Title        string `json:"title"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	WebURL       string `json:"web_url"`
+}
+
+// NewGitLabClient returns a client authenticating with the personal, project or group access token
+func NewGitLabClient(token string) *GitLabClient {
+	return &GitLabClient{
+		baseURL:   os.Getenv("CI_API_V4_URL"),
+		projectID: os.Getenv("CI_PROJECT_ID"),
+		token:     token,
+		client:    http.DefaultClient,
+	}
+}
+
+// OpenMergeRequests returns the project's open merge requests
+func (c *GitLabClient) OpenMergeRequests() ([]MergeRequest, error) {
+	mrs := []MergeRequest{}
+	if _, err := c.do(http.MethodGet, "/merge_requests?state=opened&per_page=100", nil, &mrs); err != nil {
+		return nil, fmt.Errorf("error getting merge requests: %w", err)
+	}
+
+	return mrs, nil
+}
+
+// CreateOrUpdateMergeRequest updates the title and description of the open merge request from sourceBranch into
+// targetBranch, creating it if there's none
+func (c *GitLabClient) CreateOrUpdateMergeRequest(sourceBranch, targetBranch, title, description string) (*MergeRequest, error) {
+	mrs, err := c.OpenMergeRequests()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]string{"title": title, "description": description}
+
+	for _, mr := range mrs {
+		if mr.SourceBranch != sourceBranch || mr.TargetBranch != targetBranch {
+			continue
+		}
+
+		updated := &MergeRequest{}
+		if _, err := c.do(http.MethodPut, fmt.Sprintf("/merge_requests/%d", mr.IID), fields, updated); err != nil {
+			return nil, fmt.Errorf("failed to update merge request: %w", err)
+		}
+		return updated, nil
+	}
+
+	fields["source_branch"] = sourceBranch
+	fields["target_branch"] = targetBranch
+	fields["remove_source_branch"] = "true"
+
+	created := &MergeRequest{}
+	if _, err := c.do(http.MethodPost, "/merge_requests", fields, created); err != nil {
+		return nil, fmt.Errorf("failed to create merge request: %w", err)
+	}
+
+	return created, nil
+}
+
+// CreateRelease creates a release for the tag, creating the tag at ref if it doesn't exist. ErrReleaseExists is
+// returned when the tag already has a release.
+func (c *GitLabClient) CreateRelease(tagName, ref, name, description string) error {
+	status, err := c.do(http.MethodPost, "/releases", map[string]string{
+		"tag_name":    tagName,
+		"ref":         ref,
+		"name":        name,
+		"description": description,
+	}, nil)
+	if status == http.StatusConflict {
+		return ErrReleaseExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create release for tag %s: %w", tagName, err)
+	}
+
+	return nil
+}
+
+// DeleteRelease deletes the release of the tag, keeping the tag. Tags without a release are left alone.
+func (c *GitLabClient) DeleteRelease(tagName string) error {
+	status, err := c.do(http.MethodDelete, "/releases/"+url.PathEscape(tagName), nil, nil)
+	if status == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete release for tag %s: %w", tagName, err)
+	}
+
+	return nil
+}
+
+// do sends the request to the project's API at path, decoding the response into out if set, and returns the response's
+// status code
+func (c *GitLabClient) do(method, path string, body, out any) (int, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/projects/%s%s", c.baseURL, url.PathEscape(c.projectID), path), reqBody)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(res.Body)
+		return res.StatusCode, fmt.Errorf("%s %s: %s - %s", method, path, res.Status, msg)
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			return res.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return res.StatusCode, nil
+}
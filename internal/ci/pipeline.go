@@ -0,0 +1,76 @@
+package ci
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// setGitHubVariables sets the GitHub Actions variables that aren't already set to the values derived from the
+// platform's own variables
+func setGitHubVariables(variables map[string]func() string) error {
+	for variable, value := range variables {
+		if os.Getenv(variable) != "" {
+			continue
+		}
+		if v := value(); v != "" {
+			if err := os.Setenv(variable, v); err != nil {
+				return fmt.Errorf("failed to set %s: %w", variable, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeDotenv writes the outputs to a dotenv file, for platforms that pass values between jobs through files. Dotenv
+// values can't span lines so newlines are escaped as \n.
+func writeDotenv(path string, outputs map[string]string) error {
+	keys := make([]string, 0, len(outputs))
+	for k := range outputs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&sb, "%s=%s\n", k, strings.ReplaceAll(outputs[k], "\n", `\n`))
+	}
+	fmt.Print(sb.String())
+
+	if environment.IsTestMode() {
+		return nil
+	}
+
+	if err := os.WriteFile(path, []byte(sb.String()), 0o600); err != nil {
+		return fmt.Errorf("error writing output file: %w", err)
+	}
+
+	return nil
+}
+
+// logAnnotation logs the annotation, for platforms without annotations
+func logAnnotation(annotation Annotation) {
+	prefix := strings.ToUpper(string(annotation.Level))
+	if annotation.Title != "" {
+		prefix += ": " + annotation.Title
+	}
+	if annotation.File != "" {
+		prefix += " (" + annotation.File + ")"
+	}
+
+	fmt.Printf("%s: %s\n", prefix, annotation.Message)
+}
+
+// pipelineFile returns the path set by the variable or the default file name in the directory the pipeline runs in
+func pipelineFile(variable, dir, defaultName string) string {
+	if path := os.Getenv(variable); path != "" {
+		return path
+	}
+
+	return filepath.Join(dir, defaultName)
+}
@@ -0,0 +1,129 @@
+package ci
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+const (
+	maxRateLimitRetries = 3
+	maxRateLimitWait    = 2 * time.Minute
+	// GitHub recommends waiting at least a minute before retrying secondary rate limit responses without a Retry-After header
+	defaultSecondaryRateLimitWait = time.Minute
+)
+
+// rateLimitTransport respects GitHub's rate limit headers, retrying primary and secondary rate limit responses
+// where the wait is reasonable and logging the remaining quota.
+type rateLimitTransport struct {
+	base  http.RoundTripper
+	sleep func(time.Duration)
+	now   func() time.Time
+}
+
+func newRateLimitTransport(base http.RoundTripper) *rateLimitTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &rateLimitTransport{
+		base:  base,
+		sleep: time.Sleep,
+		now:   time.Now,
+	}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			if req.GetBody == nil {
+				return nil, fmt.Errorf("unable to retry rate limited request to %s: request body cannot be replayed", req.URL.Path)
+			}
+
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		res, err := t.base.RoundTrip(req)
+		if err != nil {
+			return res, err
+		}
+
+		if remaining := res.Header.Get("X-RateLimit-Remaining"); remaining != "" {
+			logging.Debug("GitHub API rate limit remaining: %s/%s (resource %s)", remaining, res.Header.Get("X-RateLimit-Limit"), res.Header.Get("X-RateLimit-Resource"))
+		}
+
+		wait, limited := t.rateLimitWait(res)
+		if !limited {
+			return res, nil
+		}
+
+		if attempt >= maxRateLimitRetries || wait > maxRateLimitWait {
+			logging.Info("GitHub API rate limit exceeded for %s %s, resets in %s", req.Method, req.URL.Path, wait.Round(time.Second))
+			return res, nil
+		}
+
+		logging.Info("GitHub API rate limit hit for %s %s, retrying in %s", req.Method, req.URL.Path, wait.Round(time.Second))
+
+		// Drain the body so the connection can be reused
+		_, _ = io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+
+		t.sleep(wait)
+	}
+}
+
+// rateLimitWait returns how long to wait before retrying and whether the response was rate limited
+func (t *rateLimitTransport) rateLimitWait(res *http.Response) (time.Duration, bool) {
+	if res.StatusCode != http.StatusForbidden && res.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if retryAfter := res.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+
+	if res.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			wait := time.Unix(reset, 0).Sub(t.now())
+			if wait < 0 {
+				wait = 0
+			}
+			return wait, true
+		}
+	}
+
+	// Secondary rate limits are returned as a 403 or 429 without quota headers being exhausted
+	if res.StatusCode == http.StatusTooManyRequests || isSecondaryRateLimitBody(res) {
+		return defaultSecondaryRateLimitWait, true
+	}
+
+	return 0, false
+}
+
+func isSecondaryRateLimitBody(res *http.Response) bool {
+	if res.Body == nil {
+		return false
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	// Restore the body so callers can still read the error response
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(string(body)), "secondary rate limit")
+}
@@ -0,0 +1,91 @@
+package ci
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitTransport_RetriesRetryAfter(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	transport := newRateLimitTransport(http.DefaultTransport)
+	transport.sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	res, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, 2, calls)
+	require.Equal(t, []time.Duration{3 * time.Second}, slept)
+}
+
+func TestRateLimitTransport_SecondaryRateLimitBody(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message": "You have exceeded a secondary rate limit."}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := newRateLimitTransport(http.DefaultTransport)
+	transport.sleep = func(time.Duration) {}
+
+	res, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, 2, calls)
+}
+
+func TestRateLimitTransport_DoesNotWaitForDistantReset(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	transport := newRateLimitTransport(http.DefaultTransport)
+	transport.sleep = func(time.Duration) { t.Fatal("expected not to wait for the rate limit reset") }
+
+	res, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusForbidden, res.StatusCode)
+	require.Equal(t, 1, calls)
+}
+
+func TestRateLimitTransport_PassesThroughForbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+	}))
+	defer server.Close()
+
+	transport := newRateLimitTransport(http.DefaultTransport)
+	transport.sleep = func(time.Duration) { t.Fatal("expected no retry") }
+
+	res, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusForbidden, res.StatusCode)
+}
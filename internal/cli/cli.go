@@ -42,7 +42,45 @@ var defaultSupportedTargets = []string{
 	"docs",
 }
 
+// languageMinimumVersions are the first CLI versions generating the languages added after the minimum supported version
+var languageMinimumVersions = map[string]*version.Version{
+	"unity":   version.Must(version.NewVersion("1.160.0")),
+	"docs":    version.Must(version.NewVersion("1.215.0")),
+	"postman": version.Must(version.NewVersion("1.395.0")),
+}
+
+// GetLanguageMinimumVersion returns the first CLI version generating the language, or nil if no version of the CLI
+// known to the action does
+func GetLanguageMinimumVersion(lang string) *version.Version {
+	if !slices.Contains(defaultSupportedTargets, lang) {
+		return nil
+	}
+	if minVersion, ok := languageMinimumVersions[lang]; ok {
+		return minVersion
+	}
+
+	return MinimumSupportedCLIVersion
+}
+
 func GetSupportedLanguages() []string {
+	supportedTargets, _ := listSupportedTargets()
+	return supportedTargets
+}
+
+// SupportedLanguages returns the targets the CLI supports, reporting via FeatureUnavailable when the CLI can't list
+// them and the targets known to the action are assumed instead
+func SupportedLanguages() ([]string, error) {
+	supportedTargets, ok := listSupportedTargets()
+	if !ok {
+		if err := FeatureUnavailable(FeatureSupportedTargets, "assuming the targets known to this action are supported"); err != nil {
+			return nil, err
+		}
+	}
+
+	return supportedTargets, nil
+}
+
+func listSupportedTargets() ([]string, bool) {
 	out, err := runSpeakeasyCommand("generate", "supported-targets")
 	if err == nil && out != "" {
 		out = strings.Trim(out, "\n")
@@ -50,22 +88,22 @@ func GetSupportedLanguages() []string {
 		supportedTargets := strings.Split(out, ",")
 		// quick sanity check
 		if len(supportedTargets) > 0 && slices.Contains(supportedTargets, "go") {
-			return supportedTargets
+			return supportedTargets, true
 		}
 	}
 
-	return defaultSupportedTargets
+	return defaultSupportedTargets, false
 }
 
 func TriggerGoGenerate() error {
 	tidyCmd := exec.Command("go", "mod", "tidy")
-	tidyCmd.Dir = filepath.Join(environment.GetWorkspace(), "repo", environment.GetWorkingDirectory())
+	tidyCmd.Dir = filepath.Join(environment.GetRepoDir(), environment.GetWorkingDirectory())
 	output, err := tidyCmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("error running command: go mod tidy - %w\n %s", err, string(output))
 	}
 	generateCmd := exec.Command("go", "generate", "./...")
-	generateCmd.Dir = filepath.Join(environment.GetWorkspace(), "repo", environment.GetWorkingDirectory())
+	generateCmd.Dir = filepath.Join(environment.GetRepoDir(), environment.GetWorkingDirectory())
 	output, err = generateCmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("error running command: go generate ./... - %w\n %s", err, output)
@@ -150,6 +188,15 @@ func GetChangelog(lang, genVersion, previousGenVersion string, targetVersions ma
 	return out, nil
 }
 
+func GetGenerationChangelog(genVersion, previousGenVersion string) (string, error) {
+	out, err := runSpeakeasyCommand("generate", "sdk", "changelog", "-t", "v"+strings.TrimPrefix(genVersion, "v"), "-p", "v"+strings.TrimPrefix(previousGenVersion, "v"))
+	if err != nil {
+		return "", err
+	}
+
+	return out, nil
+}
+
 func Suggest(docPath, maxSuggestions, docOutputPath string) (string, error) {
 	out, err := runSpeakeasyCommand("suggest", "--schema", docPath, "--auto-approve", "--output-file", docOutputPath, "--max-suggestions", maxSuggestions, "--level", "hint", "--serial")
 	if err != nil {
@@ -159,6 +206,38 @@ func Suggest(docPath, maxSuggestions, docOutputPath string) (string, error) {
 	return out, nil
 }
 
+// Test runs the target's generated tests against a mock server of the API
+func Test(target string) error {
+	out, err := runSpeakeasyCommand("test", "-t", target)
+	if err != nil {
+		if strings.Contains(out, "unknown command") {
+			return FeatureUnavailable(FeatureMockServerTests, "so the generated SDKs aren't tested against a mock server")
+		}
+		return fmt.Errorf("error running tests: %w - %s", err, out)
+	}
+	fmt.Println(out)
+	return nil
+}
+
+// LintAsyncAPI validates the AsyncAPI document at docPath, failing when the CLI doesn't support AsyncAPI documents as
+// generation will fail as well
+func LintAsyncAPI(docPath string) error {
+	out, err := runSpeakeasyCommand("lint", "asyncapi", "-s", docPath)
+	if err != nil {
+		if isUnknownCommand(out) {
+			return fmt.Errorf("the Speakeasy CLI in use doesn't support AsyncAPI documents, set speakeasy_version to a version that does")
+		}
+		return fmt.Errorf("error validating AsyncAPI document: %w - %s", err, out)
+	}
+	fmt.Println(out)
+	return nil
+}
+
+// isUnknownCommand returns whether the CLI failed because it doesn't have the command or its flags
+func isUnknownCommand(out string) bool {
+	return strings.Contains(out, "unknown command") || strings.Contains(out, "unknown flag") || strings.Contains(out, "unknown shorthand flag")
+}
+
 func MergeDocuments(files []string, output string) error {
 	args := []string{
 		"merge",
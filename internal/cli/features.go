@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// The CLI features the action falls back without when the CLI in use doesn't support them
+const (
+	FeatureSupportedTargets  = "Listing supported targets"
+	FeatureVersioningReports = "Versioning reports"
+	FeatureMockServerTests   = "Mock server tests"
+)
+
+// featureMinimumVersions are the first CLI versions supporting the features
+var featureMinimumVersions = map[string]*version.Version{
+	FeatureSupportedTargets:  MinimumSupportedCLIVersion,
+	FeatureVersioningReports: version.Must(version.NewVersion("1.311.0")),
+	FeatureMockServerTests:   version.Must(version.NewVersion("1.373.0")),
+}
+
+// FeatureUnavailable reports that the CLI in use doesn't support a feature, explaining how the run behaves without it.
+// It's reported as a warning annotation unless cli_feature_policy is fail, in which case an error is returned.
+func FeatureUnavailable(feature, consequence string) error {
+	required := "a newer Speakeasy CLI"
+	if minVersion, ok := featureMinimumVersions[feature]; ok {
+		required = fmt.Sprintf("Speakeasy CLI v%s or later", minVersion)
+	}
+
+	msg := fmt.Sprintf("%s requires %s but %s is in use, %s", feature, required, versionInUse(), consequence)
+
+	if environment.GetCLIFeaturePolicy() == environment.CLIFeaturePolicyFail {
+		return errors.New(msg)
+	}
+
+	ci.Warning(feature+" unavailable", msg)
+	return nil
+}
+
+// versionInUse describes the CLI version generation runs with, which is the pinned version if there is one
+func versionInUse() string {
+	if pinned := GetVersion(environment.GetPinnedSpeakeasyVersion()); pinned != "latest" {
+		return "pinned version v" + strings.TrimPrefix(pinned, "v")
+	}
+
+	if v, err := GetSpeakeasyVersion(); err == nil {
+		return "v" + v.String()
+	}
+
+	return "an unknown version"
+}
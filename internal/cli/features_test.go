@@ -0,0 +1,21 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureUnavailable(t *testing.T) {
+	t.Setenv("INPUT_SPEAKEASY_VERSION", "1.140.0")
+
+	t.Setenv("INPUT_CLI_FEATURE_POLICY", "warn")
+	assert.NoError(t, FeatureUnavailable(FeatureVersioningReports, "continuing without them"))
+
+	t.Setenv("INPUT_CLI_FEATURE_POLICY", "fail")
+	err := FeatureUnavailable(FeatureVersioningReports, "continuing without them")
+	assert.EqualError(t, err, "Versioning reports requires Speakeasy CLI v1.311.0 or later but pinned version v1.140.0 is in use, continuing without them")
+
+	err = FeatureUnavailable(FeatureMockServerTests, "so the generated SDKs aren't tested against a mock server")
+	assert.EqualError(t, err, "Mock server tests requires Speakeasy CLI v1.373.0 or later but pinned version v1.140.0 is in use, so the generated SDKs aren't tested against a mock server")
+}
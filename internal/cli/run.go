@@ -18,9 +18,12 @@ type RunResults struct {
 	LintingReportURL     string
 	ChangesReportURL     string
 	OpenAPIChangeSummary string
+	GenerationWarnings   []GenerationWarning
+	ValidationIssues     []ValidationIssue
 }
 
-func Run(sourcesOnly bool, installationURLs map[string]string, repoURL string, repoSubdirectories map[string]string, manualVersionBump *versioning.BumpType) (*RunResults, error) {
+// Run runs the workflow for the given target, or every target if "all"
+func Run(sourcesOnly bool, target string, installationURLs map[string]string, repoURL string, repoSubdirectories map[string]string, manualVersionBump *versioning.BumpType, setVersion string) (*RunResults, error) {
 	args := []string{
 		"run",
 	}
@@ -28,12 +31,7 @@ func Run(sourcesOnly bool, installationURLs map[string]string, repoURL string, r
 	if sourcesOnly {
 		args = append(args, "-s", "all")
 	} else {
-		specifiedTarget := environment.SpecifiedTarget()
-		if specifiedTarget != "" {
-			args = append(args, "-t", specifiedTarget)
-		} else {
-			args = append(args, "-t", "all")
-		}
+		args = append(args, "-t", target)
 		urls, err := json.Marshal(installationURLs)
 		if err != nil {
 			return nil, fmt.Errorf("error marshalling installation urls: %w", err)
@@ -57,8 +55,12 @@ func Run(sourcesOnly bool, installationURLs map[string]string, repoURL string, r
 		args = append(args, "--registry-tags", tagString)
 	}
 
-	if environment.SetVersion() != "" {
-		args = append(args, "--set-version", environment.SetVersion())
+	// The version a target is regenerated at takes precedence over set_version
+	if setVersion == "" {
+		setVersion = environment.SetVersion()
+	}
+	if setVersion != "" {
+		args = append(args, "--set-version", setVersion)
 	}
 
 	if environment.ForceGeneration() {
@@ -100,6 +102,8 @@ func Run(sourcesOnly bool, installationURLs map[string]string, repoURL string, r
 		LintingReportURL:     lintingReportURL,
 		ChangesReportURL:     changesReportURL,
 		OpenAPIChangeSummary: string(changeSummary),
+		GenerationWarnings:   getGenerationWarnings(out),
+		ValidationIssues:     getValidationIssues(out),
 	}, nil
 }
 
@@ -81,10 +81,19 @@ func runSpeakeasyCommand(args ...string) (string, error) {
 	cmdPath := filepath.Join(baseDir, "bin", "speakeasy")
 
 	cmd := exec.Command(cmdPath, args...)
-	cmd.Dir = filepath.Join(environment.GetWorkspace(), "repo", environment.GetWorkingDirectory())
+	cmd.Dir = filepath.Join(environment.GetRepoDir(), environment.GetWorkingDirectory())
 	cmd.Env = os.Environ()
 	cmd.Env = append(cmd.Env, "SPEAKEASY_RUN_LOCATION=action")
 	cmd.Env = append(cmd.Env, "SPEAKEASY_ENVIRONMENT=github")
+	if environment.TelemetryDisabled() {
+		cmd.Env = append(cmd.Env, "SPEAKEASY_DISABLE_TELEMETRY=true")
+	}
+	if cacheDir := environment.GetGenerationCacheDir(); cacheDir != "" {
+		if err := os.MkdirAll(cacheDir, os.ModePerm); err != nil {
+			return "", fmt.Errorf("failed to create generation cache directory: %w", err)
+		}
+		cmd.Env = append(cmd.Env, "XDG_CACHE_HOME="+cacheDir)
+	}
 	cmd.Env = append(cmd.Env, extraRunEnvVars...)
 
 	output, err := cmd.CombinedOutput()
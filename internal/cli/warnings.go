@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type GenerationWarningKind string
+
+const (
+	// GenerationWarningSkipped is an operation, schema or other part of the OpenAPI doc the generator skipped
+	GenerationWarningSkipped GenerationWarningKind = "skipped"
+	// GenerationWarningUnsupported is a construct in the OpenAPI doc the generator doesn't support
+	GenerationWarningUnsupported GenerationWarningKind = "unsupported"
+)
+
+// GenerationWarning is a warning logged by the generator about part of the OpenAPI doc not making it into the SDKs
+type GenerationWarning struct {
+	Kind    GenerationWarningKind
+	Message string
+}
+
+// ValidationIssue is an issue the CLI found validating the OpenAPI doc, at Line of the doc when known. Severity is
+// error, warn or hint.
+type ValidationIssue struct {
+	Severity string
+	Line     int
+	Message  string
+}
+
+var (
+	ansiEscapeRegex      = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
+	warningLineRegex     = regexp.MustCompile(`^\s*(?:WARN(?:ING)?\b[\s:]*|⚠\s*)(.+)$`)
+	validationIssueRegex = regexp.MustCompile(`^\s*(?:[A-Z]+\s+)?validation (error|warn|hint): (?:\[line (\d+)\]\s*)?(.+)$`)
+	skippedRegex         = regexp.MustCompile(`(?i)\b(skip(ped|ping)?|ignor(ed|ing))\b`)
+	unsupportedRegex     = regexp.MustCompile(`(?i)\b(unsupported|not (yet )?supported)\b`)
+)
+
+// getGenerationWarnings returns the distinct warnings in the CLI's output about skipped or unsupported parts of the
+// OpenAPI doc, in the order they were logged
+func getGenerationWarnings(out string) []GenerationWarning {
+	warnings := []GenerationWarning{}
+	seen := map[string]bool{}
+
+	for _, line := range strings.Split(ansiEscapeRegex.ReplaceAllString(out, ""), "\n") {
+		matches := warningLineRegex.FindStringSubmatch(line)
+		if len(matches) < 2 {
+			continue
+		}
+		message := strings.TrimSpace(matches[1])
+		// Linting the OpenAPI doc is reported separately by the linting report
+		if strings.HasPrefix(message, "validation ") {
+			continue
+		}
+
+		var kind GenerationWarningKind
+		switch {
+		case unsupportedRegex.MatchString(message):
+			kind = GenerationWarningUnsupported
+		case skippedRegex.MatchString(message):
+			kind = GenerationWarningSkipped
+		default:
+			continue
+		}
+
+		if seen[message] {
+			continue
+		}
+		seen[message] = true
+
+		warnings = append(warnings, GenerationWarning{Kind: kind, Message: message})
+	}
+
+	return warnings
+}
+
+// getValidationIssues returns the distinct issues in the CLI's output from validating the OpenAPI doc, in the order
+// they were logged
+func getValidationIssues(out string) []ValidationIssue {
+	issues := []ValidationIssue{}
+	seen := map[string]bool{}
+
+	for _, line := range strings.Split(ansiEscapeRegex.ReplaceAllString(out, ""), "\n") {
+		matches := validationIssueRegex.FindStringSubmatch(line)
+		if len(matches) < 4 || seen[matches[0]] {
+			continue
+		}
+		seen[matches[0]] = true
+
+		lineNumber, _ := strconv.Atoi(matches[2])
+		issues = append(issues, ValidationIssue{Severity: matches[1], Line: lineNumber, Message: strings.TrimSpace(matches[3])})
+	}
+
+	return issues
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getGenerationWarnings(t *testing.T) {
+	out := "INFO    Generating SDK for typescript...\n" +
+		"WARN    validation warn: [line 12] any-paths - unsupported path style\n" +
+		"WARN    Skipping operation getPets: no responses defined\n" +
+		"\x1b[33mWARN\x1b[0m    schema Pet: oneOf with discriminator mapping is not supported, falling back to any\n" +
+		"WARN    Skipping operation getPets: no responses defined\n" +
+		"WARN    Deprecated field used\n" +
+		"INFO    Generation complete"
+
+	assert.Equal(t, []GenerationWarning{
+		{Kind: GenerationWarningSkipped, Message: "Skipping operation getPets: no responses defined"},
+		{Kind: GenerationWarningUnsupported, Message: "schema Pet: oneOf with discriminator mapping is not supported, falling back to any"},
+	}, getGenerationWarnings(out))
+}
+
+func Test_getValidationIssues(t *testing.T) {
+	out := "INFO    Validating OpenAPI document...\n" +
+		"WARN    validation warn: [line 12] any-paths - unsupported path style\n" +
+		"\x1b[31mERROR\x1b[0m   validation error: [line 40] missing-response - operation getPets has no responses\n" +
+		"WARN    validation warn: [line 12] any-paths - unsupported path style\n" +
+		"INFO    validation hint: consider adding examples\n" +
+		"WARN    Skipping operation getPets: no responses defined"
+
+	assert.Equal(t, []ValidationIssue{
+		{Severity: "warn", Line: 12, Message: "any-paths - unsupported path style"},
+		{Severity: "error", Line: 40, Message: "missing-response - operation getPets has no responses"},
+		{Severity: "hint", Message: "consider adding examples"},
+	}, getValidationIssues(out))
+}
@@ -0,0 +1,27 @@
+// Package command runs the external tools generated SDKs are checked and updated with
+package command
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// Run runs the command in dir with env added to the action's environment, returning its combined output. The output
+// is included in the error of failed commands.
+func Run(dir string, env []string, args ...string) (string, error) {
+	logging.Info("Running %s in %s", strings.Join(args, " "), dir)
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(output), fmt.Errorf("error running `%s`: %w\n%s", strings.Join(args, " "), err, string(output))
+	}
+
+	return string(output), nil
+}
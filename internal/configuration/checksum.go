@@ -0,0 +1,67 @@
+package configuration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"gopkg.in/yaml.v3"
+)
+
+// GenerationConfigChecksumKey is the key of the generation config checksum in the management section of gen.lock
+const GenerationConfigChecksumKey = "generationConfigChecksum"
+
+// FileReader reads a file relative to the repo root, returning nil if it doesn't exist
+type FileReader func(path string) ([]byte, error)
+
+// GenerationConfigChecksum hashes the configuration that affects a target's generated output: its definition in the
+// workflow, its gen.yaml, which includes its feature flags, and the contents of the local overlays applied to its source
+func GenerationConfigChecksum(read FileReader, wf *workflow.Workflow, targetID string) (string, error) {
+	target, ok := wf.Targets[targetID]
+	if !ok {
+		return "", fmt.Errorf("target %s not found in workflow", targetID)
+	}
+
+	hash := sha256.New()
+
+	targetData, err := yaml.Marshal(target)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal target %s: %w", targetID, err)
+	}
+	hash.Write(targetData)
+
+	dir := "."
+	if target.Output != nil {
+		dir = *target.Output
+	}
+	genYAML, err := read(path.Join(environment.GetWorkingDirectory(), dir, ".speakeasy", "gen.yaml"))
+	if err != nil {
+		return "", err
+	}
+	hash.Write(genYAML)
+
+	if source, ok := wf.Sources[target.Source]; ok {
+		sourceData, err := yaml.Marshal(source)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal source %s: %w", target.Source, err)
+		}
+		hash.Write(sourceData)
+
+		for _, overlay := range source.Overlays {
+			if overlay.Document == nil || overlay.Document.IsRemote() {
+				continue
+			}
+
+			data, err := read(path.Join(environment.GetWorkingDirectory(), overlay.Document.Location.Resolve()))
+			if err != nil {
+				return "", err
+			}
+			hash.Write(data)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,55 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerationConfigChecksum(t *testing.T) {
+	output := "sdk"
+	wf := &workflow.Workflow{
+		Sources: map[string]workflow.Source{
+			"api": {
+				Inputs:   []workflow.Document{{Location: "https://example.com/openapi.yaml"}},
+				Overlays: []workflow.Overlay{{Document: &workflow.Document{Location: "overlay.yaml"}}},
+			},
+		},
+		Targets: map[string]workflow.Target{
+			"sdk": {Target: "go", Source: "api", Output: &output},
+		},
+	}
+
+	files := map[string]string{
+		"sdk/.speakeasy/gen.yaml": "go:\n  version: 1.0.0\n",
+		"overlay.yaml":            "overlay: 1.0.0\n",
+	}
+	read := func(path string) ([]byte, error) {
+		if data, ok := files[path]; ok {
+			return []byte(data), nil
+		}
+		return nil, nil
+	}
+
+	original, err := GenerationConfigChecksum(read, wf, "sdk")
+	require.NoError(t, err)
+
+	unchanged, err := GenerationConfigChecksum(read, wf, "sdk")
+	require.NoError(t, err)
+	assert.Equal(t, original, unchanged)
+
+	files["sdk/.speakeasy/gen.yaml"] = "go:\n  version: 1.0.0\n  maxMethodParams: 4\n"
+	configChanged, err := GenerationConfigChecksum(read, wf, "sdk")
+	require.NoError(t, err)
+	assert.NotEqual(t, original, configChanged)
+
+	files["overlay.yaml"] = "overlay: 1.0.0\nactions: []\n"
+	overlayChanged, err := GenerationConfigChecksum(read, wf, "sdk")
+	require.NoError(t, err)
+	assert.NotEqual(t, configChanged, overlayChanged)
+
+	_, err = GenerationConfigChecksum(read, wf, "missing")
+	assert.Error(t, err)
+}
@@ -1,8 +1,10 @@
 package configuration
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/speakeasy-api/sdk-gen-config/workflow"
 	"github.com/speakeasy-api/sdk-generation-action/internal/cli"
@@ -31,9 +33,7 @@ func GetWorkflowAndValidateLanguages(checkLangSupported bool) (*workflow.Workflo
 }
 
 func getWorkflow() (*workflow.Workflow, error) {
-	workspace := environment.GetWorkspace()
-
-	localPath := filepath.Join(workspace, "repo", environment.GetWorkingDirectory())
+	localPath := filepath.Join(environment.GetRepoDir(), environment.GetWorkingDirectory())
 
 	wf, _, err := workflow.Load(localPath)
 	if err != nil {
@@ -44,16 +44,86 @@ func getWorkflow() (*workflow.Workflow, error) {
 }
 
 func AssertLangsSupported(langs []string) error {
-	supportedLangs := cli.GetSupportedLanguages()
+	supportedLangs, err := cli.SupportedLanguages()
+	if err != nil {
+		return err
+	}
 	for _, l := range langs {
 		if l == "docs" {
-			return nil
+			continue
 		}
 
 		if !slices.Contains(supportedLangs, l) {
-			return fmt.Errorf("unsupported language: %s", l)
+			cliVersion := ""
+			if v, err := cli.GetSpeakeasyVersion(); err == nil {
+				cliVersion = v.String()
+			}
+			return unsupportedLanguageError(l, supportedLangs, cliVersion)
 		}
 	}
 
 	return nil
 }
+
+// unsupportedLanguageError explains why a target's language can't be generated, listing the languages the resolved CLI
+// supports along with the closest match, or the minimum CLI version of languages newer CLIs support
+func unsupportedLanguageError(lang string, supportedLangs []string, cliVersion string) error {
+	cliName := "the Speakeasy CLI"
+	if cliVersion != "" {
+		cliName = fmt.Sprintf("Speakeasy CLI v%s", cliVersion)
+	}
+
+	sorted := slices.Clone(supportedLangs)
+	slices.Sort(sorted)
+
+	msg := fmt.Sprintf("unsupported language: %s is not supported by %s, supported languages are %s", lang, cliName, strings.Join(sorted, ", "))
+
+	if minVersion := cli.GetLanguageMinimumVersion(lang); minVersion != nil {
+		msg += fmt.Sprintf(". %s requires Speakeasy CLI v%s or later", lang, minVersion)
+	} else if suggestion := closestLanguage(lang, sorted); suggestion != "" {
+		msg += fmt.Sprintf(". Did you mean %s?", suggestion)
+	}
+
+	return errors.New(msg)
+}
+
+// closestLanguage returns the supported language closest to the requested one, if any is a plausible typo of it
+func closestLanguage(lang string, supportedLangs []string) string {
+	closest := ""
+	closestDistance := 0
+	for _, supported := range supportedLangs {
+		distance := levenshtein(strings.ToLower(lang), supported)
+		if closest == "" || distance < closestDistance {
+			closest = supported
+			closestDistance = distance
+		}
+	}
+
+	if closest == "" || closestDistance > len(closest)/2 {
+		return ""
+	}
+
+	return closest
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,21 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsupportedLanguageError(t *testing.T) {
+	err := unsupportedLanguageError("typscript", []string{"typescript", "go", "python"}, "1.200.0")
+	assert.EqualError(t, err, "unsupported language: typscript is not supported by Speakeasy CLI v1.200.0, supported languages are go, python, typescript. Did you mean typescript?")
+
+	err = unsupportedLanguageError("swift", []string{"typescript", "go"}, "")
+	assert.EqualError(t, err, "unsupported language: swift is not supported by the Speakeasy CLI, supported languages are go, typescript. swift requires Speakeasy CLI v1.130.0 or later")
+
+	err = unsupportedLanguageError("postman", []string{"typescript", "go"}, "1.300.0")
+	assert.EqualError(t, err, "unsupported language: postman is not supported by Speakeasy CLI v1.300.0, supported languages are go, typescript. postman requires Speakeasy CLI v1.395.0 or later")
+
+	err = unsupportedLanguageError("cobol", []string{"typescript", "go"}, "1.200.0")
+	assert.EqualError(t, err, "unsupported language: cobol is not supported by Speakeasy CLI v1.200.0, supported languages are go, typescript")
+}
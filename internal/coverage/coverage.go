@@ -0,0 +1,128 @@
+// Package coverage reports which operations of an OpenAPI doc made it into the methods of the SDKs generated from it
+package coverage
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/apisurface"
+	"gopkg.in/yaml.v3"
+)
+
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Report is the coverage of an OpenAPI doc's operations by an SDK's methods
+type Report struct {
+	Operations int     `json:"operations"`
+	Covered    int     `json:"covered"`
+	Percentage float64 `json:"percentage"`
+	// Missing are the operations without a method, e.g. "GET /pets (listPets)"
+	Missing []string `json:"missing,omitempty"`
+}
+
+type operation struct {
+	OperationID  string `yaml:"operationId"`
+	NameOverride string `yaml:"x-speakeasy-name-override"`
+	Group        string `yaml:"x-speakeasy-group"`
+	Ignore       bool   `yaml:"x-speakeasy-ignore"`
+}
+
+// Supported returns whether the coverage of SDKs in the language can be measured
+func Supported(lang string) bool {
+	return apisurface.Supported(lang)
+}
+
+// Measure matches the operations of the OpenAPI doc at docPath to the methods of the SDK generated in dir by their
+// name, the operationId or x-speakeasy-name-override, optionally prefixed by the method's group. It returns nil for
+// documents that aren't OpenAPI docs, e.g. AsyncAPI documents, which don't have operations to match.
+func Measure(docPath, lang, dir string) (*Report, error) {
+	data, err := os.ReadFile(docPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI doc: %w", err)
+	}
+
+	var doc struct {
+		OpenAPI string                          `yaml:"openapi"`
+		Swagger string                          `yaml:"swagger"`
+		Paths   map[string]map[string]yaml.Node `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI doc: %w", err)
+	}
+	if doc.OpenAPI == "" && doc.Swagger == "" {
+		return nil, nil
+	}
+
+	methods, err := apisurface.Methods(lang, dir)
+	if err != nil {
+		return nil, err
+	}
+	methodNames := map[string]bool{}
+	for _, method := range methods {
+		methodNames[normalizeName(method.Name)] = true
+		methodNames[normalizeName(method.Group+method.Name)] = true
+	}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	report := &Report{Missing: []string{}}
+	for _, path := range paths {
+		for _, httpMethod := range httpMethods {
+			node, ok := doc.Paths[path][httpMethod]
+			if !ok {
+				continue
+			}
+
+			var op operation
+			if err := node.Decode(&op); err != nil {
+				return nil, fmt.Errorf("failed to parse operation %s %s: %w", strings.ToUpper(httpMethod), path, err)
+			}
+			if op.Ignore {
+				continue
+			}
+
+			report.Operations++
+
+			name := op.OperationID
+			if op.NameOverride != "" {
+				name = op.NameOverride
+			}
+			if name != "" && (methodNames[normalizeName(name)] || methodNames[normalizeName(op.Group+name)]) {
+				report.Covered++
+				continue
+			}
+
+			missing := fmt.Sprintf("%s %s", strings.ToUpper(httpMethod), path)
+			if name != "" {
+				missing += fmt.Sprintf(" (%s)", name)
+			}
+			report.Missing = append(report.Missing, missing)
+		}
+	}
+
+	report.Percentage = 100
+	if report.Operations > 0 {
+		report.Percentage = math.Round(float64(report.Covered)/float64(report.Operations)*1000) / 10
+	}
+
+	return report, nil
+}
+
+// normalizeName drops the casing and separators that differ between an operation's name and the methods generated
+// for it in each language
+func normalizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, name)
+}
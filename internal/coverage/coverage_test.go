@@ -0,0 +1,69 @@
+package coverage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeasure(t *testing.T) {
+	dir := t.TempDir()
+	docPath := filepath.Join(dir, "openapi.yaml")
+	require.NoError(t, os.WriteFile(docPath, []byte(`openapi: 3.1.0
+paths:
+  /pets:
+    parameters:
+      - name: limit
+        in: query
+    get:
+      operationId: pets_list
+    post:
+      operationId: createPet
+  /pets/{id}:
+    get:
+      operationId: getPet
+      x-speakeasy-name-override: get
+      x-speakeasy-group: pets
+    delete:
+      operationId: deletePet
+    patch:
+      operationId: internalPatch
+      x-speakeasy-ignore: true
+  /health:
+    get: {}
+`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pets.go"), []byte(`package sdk
+
+import "context"
+
+type Pets struct{}
+
+func (p *Pets) List(ctx context.Context) error { return nil }
+
+func (p *Pets) CreatePet(ctx context.Context) error { return nil }
+
+func (p *Pets) Get(ctx context.Context) error { return nil }
+`), 0o644))
+
+	report, err := Measure(docPath, "go", dir)
+	require.NoError(t, err)
+	assert.Equal(t, &Report{
+		Operations: 5,
+		Covered:    3,
+		Percentage: 60,
+		Missing:    []string{"GET /health", "DELETE /pets/{id} (deletePet)"},
+	}, report)
+}
+
+func TestMeasure_AsyncAPI(t *testing.T) {
+	dir := t.TempDir()
+	docPath := filepath.Join(dir, "asyncapi.yaml")
+	require.NoError(t, os.WriteFile(docPath, []byte("asyncapi: 3.0.0\ninfo:\n  title: Events\n  version: 1.0.0\n"), 0o644))
+
+	report, err := Measure(docPath, "go", dir)
+	require.NoError(t, err)
+	assert.Nil(t, report)
+}
@@ -0,0 +1,75 @@
+package document
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/cli"
+	"gopkg.in/yaml.v3"
+)
+
+// asyncAPIMajorVersions are the AsyncAPI specification versions the CLI generates from
+var asyncAPIMajorVersions = []string{"2.", "3."}
+
+type asyncAPIDocument struct {
+	AsyncAPI string `yaml:"asyncapi"`
+	Info     struct {
+		Title   string `yaml:"title"`
+		Version string `yaml:"version"`
+	} `yaml:"info"`
+	Channels map[string]yaml.Node `yaml:"channels"`
+}
+
+// lintAsyncAPI validates the AsyncAPI document at the path with the CLI, failing when the CLI doesn't support AsyncAPI
+var lintAsyncAPI = cli.LintAsyncAPI
+
+// parseAsyncAPIDocument returns the document if it is an AsyncAPI document, identified by its asyncapi version field
+func parseAsyncAPIDocument(data []byte) (*asyncAPIDocument, bool) {
+	var doc asyncAPIDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil || doc.AsyncAPI == "" {
+		return nil, false
+	}
+
+	return &doc, true
+}
+
+// checkAsyncAPIDocument fails when the AsyncAPI document is missing what generation relies on, then validates it with
+// the CLI, which also fails when the CLI in use can't generate from AsyncAPI documents
+func checkAsyncAPIDocument(doc *sourceDocument, asyncAPIDoc *asyncAPIDocument) error {
+	if problems := asyncAPIDocumentProblems(asyncAPIDoc); len(problems) > 0 {
+		return fmt.Errorf("invalid AsyncAPI document %s: %s", doc.Location, strings.Join(problems, "; "))
+	}
+
+	if err := lintAsyncAPI(doc.Path); err != nil {
+		return fmt.Errorf("failed to validate AsyncAPI document %s: %w", doc.Location, err)
+	}
+
+	return nil
+}
+
+func asyncAPIDocumentProblems(doc *asyncAPIDocument) []string {
+	problems := []string{}
+
+	supported := false
+	for _, prefix := range asyncAPIMajorVersions {
+		if strings.HasPrefix(doc.AsyncAPI, prefix) {
+			supported = true
+		}
+	}
+	if !supported {
+		problems = append(problems, fmt.Sprintf("AsyncAPI version %s is not supported, only versions 2.x and 3.x are", doc.AsyncAPI))
+	}
+
+	if doc.Info.Title == "" {
+		problems = append(problems, "info.title is required")
+	}
+	if doc.Info.Version == "" {
+		problems = append(problems, "info.version is required")
+	}
+	// Channels are optional from AsyncAPI 3.0, where operations reference them instead
+	if strings.HasPrefix(doc.AsyncAPI, "2.") && len(doc.Channels) == 0 {
+		problems = append(problems, "channels is required")
+	}
+
+	return problems
+}
@@ -0,0 +1,64 @@
+package document
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+)
+
+// sourceDocument is a source document read before generation
+type sourceDocument struct {
+	Location string
+	// Path is where the staged copy of the document is on disk
+	Path string
+	Data []byte
+}
+
+// CheckSourceDocuments checks the workflow's source documents before generating from them, failing when they are
+// AsyncAPI documents that are invalid or that the CLI can't generate from. The documents are read from the copies
+// staged by StageDocuments, documents that weren't staged aren't checked.
+func CheckSourceDocuments(wf *workflow.Workflow, staged *StagedDocuments) error {
+	for _, sourceID := range sortedSourceIDs(wf) {
+		asyncAPIDocs, otherDocs := []string{}, []string{}
+
+		for _, input := range wf.Sources[sourceID].Inputs {
+			localPath, ok := staged.Paths[string(input.Location)]
+			if !ok {
+				continue
+			}
+			data, err := os.ReadFile(localPath)
+			if err != nil {
+				return fmt.Errorf("failed to read staged document %s: %w", input.Location, err)
+			}
+			doc := &sourceDocument{Location: input.Location.Resolve(), Path: localPath, Data: data}
+
+			if asyncAPIDoc, ok := parseAsyncAPIDocument(doc.Data); ok {
+				asyncAPIDocs = append(asyncAPIDocs, doc.Location)
+				if err := checkAsyncAPIDocument(doc, asyncAPIDoc); err != nil {
+					return err
+				}
+				continue
+			}
+			otherDocs = append(otherDocs, doc.Location)
+		}
+
+		if len(asyncAPIDocs) > 0 && len(otherDocs) > 0 {
+			return fmt.Errorf("source %s mixes AsyncAPI documents (%s) with other documents (%s), use a separate source for each", sourceID, strings.Join(asyncAPIDocs, ", "), strings.Join(otherDocs, ", "))
+		}
+	}
+
+	return nil
+}
+
+func sortedSourceIDs(wf *workflow.Workflow) []string {
+	sourceIDs := make([]string, 0, len(wf.Sources))
+	for sourceID := range wf.Sources {
+		sourceIDs = append(sourceIDs, sourceID)
+	}
+	sort.Strings(sourceIDs)
+
+	return sourceIDs
+}
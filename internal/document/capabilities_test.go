@@ -0,0 +1,48 @@
+package document
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/speakeasy-api/sdk-generation-action/internal/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSourceDocuments_AsyncAPI(t *testing.T) {
+	repoDir := t.TempDir()
+	t.Setenv("INPUT_REPO_DIR", repoDir)
+	t.Setenv("INPUT_WORKING_DIRECTORY", "")
+
+	linted := []string{}
+	lintAsyncAPI = func(docPath string) error {
+		linted = append(linted, docPath)
+		return nil
+	}
+	t.Cleanup(func() { lintAsyncAPI = cli.LintAsyncAPI })
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "events.yaml"), []byte("asyncapi: 3.0.0\ninfo:\n  title: Events\n  version: 1.0.0\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "invalid.yaml"), []byte("asyncapi: 2.6.0\ninfo:\n  title: Events\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "openapi.yaml"), []byte("openapi: 3.0.3\npaths: {}\n"), 0o644))
+
+	staged := &StagedDocuments{Paths: map[string]string{}}
+	for _, name := range []string{"events.yaml", "invalid.yaml", "openapi.yaml"} {
+		staged.Paths[name] = filepath.Join(repoDir, name)
+	}
+
+	wf := &workflow.Workflow{Sources: map[string]workflow.Source{
+		"events": {Inputs: []workflow.Document{{Location: "events.yaml"}, {Location: "missing.yaml"}}},
+	}}
+	require.NoError(t, CheckSourceDocuments(wf, staged))
+	assert.Equal(t, []string{filepath.Join(repoDir, "events.yaml")}, linted)
+
+	wf.Sources["events"] = workflow.Source{Inputs: []workflow.Document{{Location: "invalid.yaml"}}}
+	err := CheckSourceDocuments(wf, staged)
+	assert.ErrorContains(t, err, "invalid AsyncAPI document invalid.yaml: info.version is required; channels is required")
+
+	wf.Sources["events"] = workflow.Source{Inputs: []workflow.Document{{Location: "events.yaml"}, {Location: "openapi.yaml"}}}
+	err = CheckSourceDocuments(wf, staged)
+	assert.ErrorContains(t, err, "source events mixes AsyncAPI documents (events.yaml) with other documents (openapi.yaml)")
+}
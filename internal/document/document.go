@@ -33,6 +33,11 @@ func GetOpenAPIFileInfo() (string, string, error) {
 		return "", "", err
 	}
 
+	resolvedOpenAPIFiles, err = convertPostmanCollections(resolvedOpenAPIFiles)
+	if err != nil {
+		return "", "", err
+	}
+
 	basePath := ""
 	filePath := ""
 
@@ -96,6 +101,35 @@ func GetOpenAPIFileInfo() (string, string, error) {
 	return filePath, version, nil
 }
 
+// convertPostmanCollections replaces any Postman collections among the files with their conversion to OpenAPI
+func convertPostmanCollections(files []string) ([]string, error) {
+	outFiles := make([]string, 0, len(files))
+
+	for i, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil || !isPostmanCollection(data) {
+			outFiles = append(outFiles, file)
+			continue
+		}
+
+		outPath, err := filepath.Abs(filepath.Join(environment.GetWorkspace(), "openapi", fmt.Sprintf("openapi_converted_%d.yaml", i)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get absolute path for converted openapi file: %w", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(outPath), os.ModePerm); err != nil {
+			return nil, fmt.Errorf("failed to create openapi directory: %w", err)
+		}
+
+		if _, err := convertPostmanCollection(file, outPath); err != nil {
+			return nil, err
+		}
+
+		outFiles = append(outFiles, outPath)
+	}
+
+	return outFiles, nil
+}
+
 func mergeFiles(files []string) (string, error) {
 	outPath := filepath.Join(environment.GetWorkspace(), ".openapi", "openapi_merged")
 
@@ -139,12 +173,10 @@ func applyOverlay(filePath string, overlayFiles []string) (string, error) {
 }
 
 func resolveFiles(files []file, typ string) ([]string, error) {
-	workspace := environment.GetWorkspace()
-
 	outFiles := []string{}
 
 	for i, file := range files {
-		localPath := filepath.Join(workspace, "repo", file.Location)
+		localPath := filepath.Join(environment.GetRepoDir(), file.Location)
 
 		if _, err := os.Stat(localPath); err == nil {
 			fmt.Printf("Found local %s file: %s\n", typ, localPath)
@@ -179,7 +211,12 @@ func resolveFiles(files []file, typ string) ([]string, error) {
 				return nil, fmt.Errorf("failed to get absolute path for %s file: %w", filePath, err)
 			}
 
-			if err := download.DownloadFile(u.String(), absPath, file.Header, file.Token); err != nil {
+			if download.IsCloudStorageURL(file.Location) {
+				err = download.DownloadCloudFile(file.Location, absPath)
+			} else {
+				err = download.DownloadFile(u.String(), absPath, file.Header, file.Token)
+			}
+			if err != nil {
 				return nil, fmt.Errorf("failed to download %s file: %w", typ, err)
 			}
 
@@ -0,0 +1,79 @@
+package document
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// PostmanConversionsFile stores the checksums of the OpenAPI documents the workflow's Postman collections were last
+// converted to, relative to the working directory
+const PostmanConversionsFile = ".speakeasy/postman-conversions.json"
+
+// PostmanConversions are the sha256 checksums of the OpenAPI documents Postman collections were converted to, keyed by
+// the collections' locations in workflow.yaml
+type PostmanConversions map[string]string
+
+// SavePostmanConversions stores the checksums alongside the workflow file so they are committed with the generated
+// changes, tracing changes to the SDKs back to the collections
+func SavePostmanConversions(conversions PostmanConversions) error {
+	data, err := json.MarshalIndent(conversions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal Postman conversions: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(environment.GetRepoDir(), environment.GetWorkingDirectory(), PostmanConversionsFile), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write Postman conversions: %w", err)
+	}
+
+	return nil
+}
+
+// postmanConverterPackage is the npm package used to convert Postman collections to OpenAPI
+const postmanConverterPackage = "postman-to-openapi@3"
+
+// isPostmanCollection returns whether the document is a Postman collection, identified by the collection schema its
+// info references
+func isPostmanCollection(data []byte) bool {
+	var collection struct {
+		Info struct {
+			PostmanID string `json:"_postman_id"`
+			Schema    string `json:"schema"`
+		} `json:"info"`
+	}
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return false
+	}
+
+	return collection.Info.PostmanID != "" || strings.Contains(collection.Info.Schema, "schema.getpostman.com")
+}
+
+// convertPostmanCollection converts the Postman collection at inPath to an OpenAPI document at outPath, returning the
+// checksum of the converted document so changes to it can be traced back to changes to the collection
+func convertPostmanCollection(inPath, outPath string) (string, error) {
+	logging.Info("Converting Postman collection %s to OpenAPI", inPath)
+
+	cmd := exec.Command("npx", "--yes", "--package", postmanConverterPackage, "p2o", inPath, "-f", outPath)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to convert Postman collection %s: %w\n%s", inPath, err, string(output))
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read converted Postman collection: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	logging.Info("Converted Postman collection %s to OpenAPI document with checksum %s", inPath, checksum)
+
+	return checksum, nil
+}
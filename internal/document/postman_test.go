@@ -0,0 +1,14 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPostmanCollection(t *testing.T) {
+	assert.True(t, isPostmanCollection([]byte(`{"info": {"_postman_id": "1234", "name": "API"}, "item": []}`)))
+	assert.True(t, isPostmanCollection([]byte(`{"info": {"name": "API", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"}}`)))
+	assert.False(t, isPostmanCollection([]byte(`{"openapi": "3.1.0", "info": {"title": "API", "version": "1.0.0"}}`)))
+	assert.False(t, isPostmanCollection([]byte("openapi: 3.1.0\n")))
+}
@@ -0,0 +1,117 @@
+package document
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// fileDownloader downloads the file at location to outPath
+type fileDownloader func(location, outPath string) error
+
+// resolveRelativeRefs downloads the files the document downloaded from location to localPath references through
+// relative $refs, recursively, mirroring their layout under dir so they are resolved against the downloaded copies. It
+// returns the path of the document within the mirror, or localPath when it doesn't reference other files.
+func resolveRelativeRefs(location, localPath, dir string, download fileDownloader) (string, error) {
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", localPath, err)
+	}
+
+	root, err := url.Parse(location)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", location, err)
+	}
+
+	refs, err := relativeRefs(root, data)
+	if err != nil || len(refs) == 0 {
+		// Documents that can't be parsed are left for the CLI to report
+		return localPath, nil
+	}
+
+	rootPath, err := mirrorPath(dir, root)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(rootPath), os.ModePerm); err != nil {
+		return "", fmt.Errorf("failed to create directory for %s: %w", location, err)
+	}
+	if err := os.WriteFile(rootPath, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", rootPath, err)
+	}
+
+	visited := map[string]bool{root.String(): true}
+	pending := refs
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		if visited[current.String()] {
+			continue
+		}
+		visited[current.String()] = true
+
+		outPath, err := mirrorPath(dir, current)
+		if err != nil {
+			return "", err
+		}
+		if err := os.MkdirAll(filepath.Dir(outPath), os.ModePerm); err != nil {
+			return "", fmt.Errorf("failed to create directory for %s: %w", current, err)
+		}
+
+		logging.Info("Downloading %s referenced by %s", current, location)
+		if err := download(current.String(), outPath); err != nil {
+			return "", fmt.Errorf("failed to download %s referenced by %s: %w", current, location, err)
+		}
+
+		refData, err := os.ReadFile(outPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", outPath, err)
+		}
+		refs, err := relativeRefs(current, refData)
+		if err != nil {
+			// Referenced files that aren't YAML or JSON can't reference others
+			continue
+		}
+		pending = append(pending, refs...)
+	}
+
+	return rootPath, nil
+}
+
+// relativeRefs returns the locations of the files the document references through relative $refs, resolved against
+// its own location. The query of the location is kept as it identifies the file's origin, e.g. the run of an artifact.
+func relativeRefs(base *url.URL, data []byte) ([]*url.URL, error) {
+	refs, err := collectRefs(data)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := []*url.URL{}
+	for _, ref := range refs {
+		u, err := url.Parse(ref)
+		if err != nil || u.IsAbs() || u.Host != "" {
+			continue
+		}
+
+		resolved := base.ResolveReference(u)
+		if resolved.RawQuery == "" {
+			resolved.RawQuery = base.RawQuery
+		}
+		locations = append(locations, resolved)
+	}
+
+	return locations, nil
+}
+
+// mirrorPath returns where the file at the location is downloaded to within dir
+func mirrorPath(dir string, location *url.URL) (string, error) {
+	rel := filepath.Join(location.Host, filepath.FromSlash(location.Path))
+	if !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("cannot download %s outside of %s", location, dir)
+	}
+
+	return filepath.Join(dir, rel), nil
+}
@@ -0,0 +1,61 @@
+package document
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveRelativeRefs(t *testing.T) {
+	files := map[string]string{
+		"artifact://acme/api/spec/v1/paths.yaml?run_id=1":   "/pets:\n  get:\n    responses: {}\n",
+		"artifact://acme/api/spec/common/pet.yaml?run_id=1": "Pet:\n  properties:\n    tag:\n      $ref: 'tag.yaml#/Tag'\n",
+		"artifact://acme/api/spec/common/tag.yaml?run_id=1": "Tag:\n  type: string\n",
+	}
+	downloaded := []string{}
+	download := func(location, outPath string) error {
+		data, ok := files[location]
+		if !ok {
+			return fmt.Errorf("%s not found", location)
+		}
+		downloaded = append(downloaded, location)
+		return os.WriteFile(outPath, []byte(data), 0o644)
+	}
+
+	dir := t.TempDir()
+	localPath := filepath.Join(dir, "ROOT.yaml")
+	require.NoError(t, os.WriteFile(localPath, []byte(`openapi: 3.1.0
+paths:
+  $ref: ./paths.yaml
+components:
+  schemas:
+    Pet:
+      $ref: '../common/pet.yaml#/Pet'
+    Other:
+      $ref: '#/components/schemas/Pet'
+    Remote:
+      $ref: 'https://example.com/schemas.yaml#/Remote'
+`), 0o644))
+
+	resolvedPath, err := resolveRelativeRefs("artifact://acme/api/spec/v1/openapi.yaml?run_id=1", localPath, filepath.Join(dir, "ROOT"), download)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "ROOT", "acme", "api", "spec", "v1", "openapi.yaml"), resolvedPath)
+	assert.ElementsMatch(t, []string{
+		"artifact://acme/api/spec/v1/paths.yaml?run_id=1",
+		"artifact://acme/api/spec/common/pet.yaml?run_id=1",
+		"artifact://acme/api/spec/common/tag.yaml?run_id=1",
+	}, downloaded)
+
+	data, err := os.ReadFile(filepath.Join(dir, "ROOT", "acme", "api", "spec", "common", "tag.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "Tag:\n  type: string\n", string(data))
+
+	require.NoError(t, os.WriteFile(localPath, []byte("openapi: 3.1.0\npaths: {}\n"), 0o644))
+	resolvedPath, err = resolveRelativeRefs("s3://bucket/openapi.yaml", localPath, filepath.Join(dir, "ROOT"), download)
+	require.NoError(t, err)
+	assert.Equal(t, localPath, resolvedPath)
+}
@@ -0,0 +1,360 @@
+package document
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+	"gopkg.in/yaml.v3"
+)
+
+// RemoteSpecValidatorsFile stores the validators of the remote specs last generated from, relative to the working directory
+const RemoteSpecValidatorsFile = ".speakeasy/remote-specs.json"
+
+// RemoteSpecValidators are the ETag and Last-Modified values, or checksum, of each remote spec, keyed by location
+type RemoteSpecValidators map[string]RemoteSpecValidator
+
+type RemoteSpecValidator struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	// SHA256 is the checksum of the spec's contents, and those of the files it references when spec_checksum_refs is set
+	SHA256 string `json:"sha256,omitempty"`
+	// Refs are the validators of the files the spec references through external $refs, keyed by location
+	Refs map[string]RemoteSpecValidator `json:"refs,omitempty"`
+}
+
+// CheckRemoteSpecsModified issues conditional requests for each of the workflow's source documents using the values
+// stored by the last generation, returning whether any of them may have changed along with their current validators.
+// Workflows with local or registry documents are always considered modified.
+func CheckRemoteSpecsModified(wf *workflow.Workflow, stored RemoteSpecValidators) (bool, RemoteSpecValidators, error) {
+	documents := []workflow.Document{}
+	for _, source := range wf.Sources {
+		documents = append(documents, source.Inputs...)
+		for _, overlay := range source.Overlays {
+			if overlay.Document != nil {
+				documents = append(documents, *overlay.Document)
+			}
+		}
+	}
+
+	if len(documents) == 0 {
+		return true, nil, nil
+	}
+
+	current := RemoteSpecValidators{}
+	modified := false
+
+	for _, doc := range documents {
+		if !doc.IsRemote() || doc.IsSpeakeasyRegistry() {
+			return true, nil, nil
+		}
+
+		location := doc.Location.Resolve()
+
+		validator, docModified, err := checkRemoteSpec(location, doc.Auth, stored[location])
+		if err != nil {
+			return true, nil, err
+		}
+
+		current[location] = validator
+		modified = modified || docModified
+	}
+
+	return modified, current, nil
+}
+
+// SaveRemoteSpecValidators stores the validators alongside the workflow file so they are committed with the generated changes
+func SaveRemoteSpecValidators(validators RemoteSpecValidators) error {
+	data, err := json.MarshalIndent(validators, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal remote spec validators: %w", err)
+	}
+
+	if err := os.WriteFile(remoteSpecValidatorsPath(), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write remote spec validators: %w", err)
+	}
+
+	return nil
+}
+
+func checkRemoteSpec(location string, auth *workflow.Auth, stored RemoteSpecValidator) (RemoteSpecValidator, bool, error) {
+	includeRefs := environment.IncludeSpecRefs()
+	if environment.GetSpecChecksumAlgorithm() == environment.SpecChecksumSHA256 {
+		return checkRemoteSpecChecksum(location, auth, stored, includeRefs)
+	}
+
+	validator, data, modified, err := requestRemoteSpec(location, auth, stored)
+	if err != nil {
+		return stored, true, err
+	}
+
+	if !modified && includeRefs {
+		// A multi-file spec is only unchanged when none of the files it references changed either
+		for refLocation, refStored := range stored.Refs {
+			_, _, refModified, err := requestRemoteSpec(refLocation, authForLocation(location, refLocation, auth), refStored)
+			if err != nil {
+				return stored, true, err
+			}
+			if refModified {
+				logging.Info("File %s referenced by remote spec %s has been modified", refLocation, location)
+				modified = true
+				break
+			}
+		}
+		if modified {
+			// The spec itself is needed to find the files it now references
+			validator, data, _, err = requestRemoteSpec(location, auth, RemoteSpecValidator{})
+			if err != nil {
+				return stored, true, err
+			}
+		}
+	}
+	if !modified {
+		logging.Info("Remote spec %s has not been modified", location)
+		return stored, false, nil
+	}
+
+	if !includeRefs {
+		// Servers that don't support conditional requests can't tell us the spec is unchanged
+		return validator, true, nil
+	}
+
+	refs, err := fetchReferencedFiles(location, auth, data)
+	if err != nil {
+		return stored, true, err
+	}
+	for refLocation, ref := range refs {
+		if validator.Refs == nil {
+			validator.Refs = map[string]RemoteSpecValidator{}
+		}
+		validator.Refs[refLocation] = ref.Validator
+	}
+
+	// Servers that don't support conditional requests can't tell us the spec is unchanged
+	return validator, true, nil
+}
+
+// checkRemoteSpecChecksum downloads the spec, and optionally the files it references through external $refs, comparing
+// the checksum of their contents against the stored one
+func checkRemoteSpecChecksum(location string, auth *workflow.Auth, stored RemoteSpecValidator, includeRefs bool) (RemoteSpecValidator, bool, error) {
+	_, data, _, err := requestRemoteSpec(location, auth, RemoteSpecValidator{})
+	if err != nil {
+		return stored, true, err
+	}
+
+	contents := map[string][]byte{location: data}
+	if includeRefs {
+		refs, err := fetchReferencedFiles(location, auth, data)
+		if err != nil {
+			return stored, true, err
+		}
+		for refLocation, ref := range refs {
+			contents[refLocation] = ref.Data
+		}
+	}
+
+	locations := make([]string, 0, len(contents))
+	for l := range contents {
+		locations = append(locations, l)
+	}
+	sort.Strings(locations)
+
+	hash := sha256.New()
+	for _, l := range locations {
+		fmt.Fprintf(hash, "%s\n%d\n", l, len(contents[l]))
+		hash.Write(contents[l])
+	}
+	checksum := hex.EncodeToString(hash.Sum(nil))
+
+	if checksum == stored.SHA256 {
+		logging.Info("Remote spec %s has not been modified", location)
+		return stored, false, nil
+	}
+
+	return RemoteSpecValidator{SHA256: checksum}, true, nil
+}
+
+type referencedFile struct {
+	Data      []byte
+	Validator RemoteSpecValidator
+}
+
+// fetchReferencedFiles downloads the files the spec at location references through external $refs, recursively,
+// keyed by their location
+func fetchReferencedFiles(location string, auth *workflow.Auth, data []byte) (map[string]referencedFile, error) {
+	files := map[string]referencedFile{}
+
+	root, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", location, err)
+	}
+	pending, err := externalRefs(root, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the references of %s: %w", location, err)
+	}
+
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		if _, ok := files[current]; ok || current == location {
+			continue
+		}
+
+		validator, data, _, err := requestRemoteSpec(current, authForLocation(location, current, auth), RemoteSpecValidator{})
+		if err != nil {
+			return nil, err
+		}
+		files[current] = referencedFile{Data: data, Validator: validator}
+
+		u, err := url.Parse(current)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", current, err)
+		}
+		refs, err := externalRefs(u, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the references of %s: %w", current, err)
+		}
+		pending = append(pending, refs...)
+	}
+
+	return files, nil
+}
+
+// authForLocation returns the spec's credentials for the files it references on the same host, they aren't sent to
+// other hosts
+func authForLocation(specLocation, location string, auth *workflow.Auth) *workflow.Auth {
+	spec, err := url.Parse(specLocation)
+	if err != nil {
+		return nil
+	}
+	u, err := url.Parse(location)
+	if err != nil || u.Host != spec.Host {
+		return nil
+	}
+
+	return auth
+}
+
+// requestRemoteSpec makes a conditional request for the file when validators are stored, returning its current
+// validators and contents when it was modified
+func requestRemoteSpec(location string, auth *workflow.Auth, stored RemoteSpecValidator) (RemoteSpecValidator, []byte, bool, error) {
+	req, err := http.NewRequest(http.MethodGet, location, nil)
+	if err != nil {
+		return stored, nil, true, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if auth != nil && auth.Header != "" {
+		req.Header.Set(auth.Header, os.Getenv(strings.ToUpper(strings.TrimPrefix(auth.Secret, "$"))))
+	}
+	if stored.ETag != "" {
+		req.Header.Set("If-None-Match", stored.ETag)
+	}
+	if stored.LastModified != "" {
+		req.Header.Set("If-Modified-Since", stored.LastModified)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return stored, nil, true, fmt.Errorf("failed to request %s: %w", location, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotModified {
+		return stored, nil, false, nil
+	}
+
+	if res.StatusCode/100 != 2 {
+		return stored, nil, true, fmt.Errorf("failed to request %s: %s", location, res.Status)
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return stored, nil, true, fmt.Errorf("failed to read %s: %w", location, err)
+	}
+
+	return RemoteSpecValidator{
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+	}, data, true, nil
+}
+
+// externalRefs returns the locations of the files the document references through $refs, resolved against its own
+// location and without fragments
+func externalRefs(base *url.URL, data []byte) ([]string, error) {
+	refs, err := collectRefs(data)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if u, err := url.Parse(ref); err == nil {
+			locations = append(locations, base.ResolveReference(u).String())
+		}
+	}
+
+	return locations, nil
+}
+
+// collectRefs returns the distinct values of the document's $refs to other files, without fragments
+func collectRefs(data []byte) ([]string, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	refs := []string{}
+	seen := map[string]bool{}
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if key.Value != "$ref" || value.Kind != yaml.ScalarNode {
+					continue
+				}
+
+				ref, _, _ := strings.Cut(value.Value, "#")
+				if ref != "" && !seen[ref] {
+					seen[ref] = true
+					refs = append(refs, ref)
+				}
+			}
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(&root)
+
+	return refs, nil
+}
+
+// ParseRemoteSpecValidators parses the contents of the RemoteSpecValidatorsFile, which may not exist yet
+func ParseRemoteSpecValidators(data []byte) (RemoteSpecValidators, error) {
+	validators := RemoteSpecValidators{}
+	if len(data) == 0 {
+		return validators, nil
+	}
+
+	if err := json.Unmarshal(data, &validators); err != nil {
+		return nil, fmt.Errorf("failed to parse remote spec validators: %w", err)
+	}
+
+	return validators, nil
+}
+
+func remoteSpecValidatorsPath() string {
+	return filepath.Join(environment.GetRepoDir(), environment.GetWorkingDirectory(), RemoteSpecValidatorsFile)
+}
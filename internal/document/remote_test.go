@@ -0,0 +1,124 @@
+package document
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRemoteSpec(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v2"`)
+		w.Header().Set("Last-Modified", "Wed, 14 Oct 2026 10:00:00 GMT")
+		_, _ = w.Write([]byte("openapi: 3.1.0"))
+	}))
+	defer server.Close()
+
+	validator, modified, err := checkRemoteSpec(server.URL, nil, RemoteSpecValidator{ETag: `"v1"`})
+	require.NoError(t, err)
+	assert.False(t, modified)
+	assert.Equal(t, RemoteSpecValidator{ETag: `"v1"`}, validator)
+
+	validator, modified, err = checkRemoteSpec(server.URL, nil, RemoteSpecValidator{ETag: `"v0"`})
+	require.NoError(t, err)
+	assert.True(t, modified)
+	assert.Equal(t, RemoteSpecValidator{ETag: `"v2"`, LastModified: "Wed, 14 Oct 2026 10:00:00 GMT"}, validator)
+}
+
+func TestCheckRemoteSpecChecksum(t *testing.T) {
+	schemas := "Pet:\n  type: object\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/openapi.yaml":
+			_, _ = w.Write([]byte("openapi: 3.1.0\ncomponents:\n  schemas:\n    Pet:\n      $ref: './schemas.yaml#/Pet'\n    Self:\n      $ref: '#/components/schemas/Pet'\n"))
+		case "/schemas.yaml":
+			_, _ = w.Write([]byte(schemas))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	location := server.URL + "/openapi.yaml"
+
+	withoutRefs, modified, err := checkRemoteSpecChecksum(location, nil, RemoteSpecValidator{}, false)
+	require.NoError(t, err)
+	assert.True(t, modified)
+	withRefs, modified, err := checkRemoteSpecChecksum(location, nil, RemoteSpecValidator{}, true)
+	require.NoError(t, err)
+	assert.True(t, modified)
+	assert.NotEqual(t, withoutRefs.SHA256, withRefs.SHA256)
+
+	_, modified, err = checkRemoteSpecChecksum(location, nil, withRefs, true)
+	require.NoError(t, err)
+	assert.False(t, modified)
+
+	// Only the referenced file changes
+	schemas = "Pet:\n  type: string\n"
+
+	_, modified, err = checkRemoteSpecChecksum(location, nil, withoutRefs, false)
+	require.NoError(t, err)
+	assert.False(t, modified)
+	_, modified, err = checkRemoteSpecChecksum(location, nil, withRefs, true)
+	require.NoError(t, err)
+	assert.True(t, modified)
+}
+
+func TestCheckRemoteSpecRefs(t *testing.T) {
+	t.Setenv("INPUT_SPEC_CHECKSUM_ALGORITHM", "etag")
+	t.Setenv("INPUT_SPEC_CHECKSUM_REFS", "true")
+
+	schemasETag := `"s1"`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag, body := `"r1"`, "openapi: 3.1.0\ncomponents:\n  schemas:\n    Pet:\n      $ref: './schemas.yaml#/Pet'\n"
+		if r.URL.Path == "/schemas.yaml" {
+			etag, body = schemasETag, "Pet:\n  type: object\n"
+		}
+
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	location := server.URL + "/openapi.yaml"
+
+	validator, modified, err := checkRemoteSpec(location, nil, RemoteSpecValidator{})
+	require.NoError(t, err)
+	assert.True(t, modified)
+	assert.Equal(t, RemoteSpecValidator{
+		ETag: `"r1"`,
+		Refs: map[string]RemoteSpecValidator{server.URL + "/schemas.yaml": {ETag: `"s1"`}},
+	}, validator)
+
+	_, modified, err = checkRemoteSpec(location, nil, validator)
+	require.NoError(t, err)
+	assert.False(t, modified)
+
+	// Only the referenced file changes
+	schemasETag = `"s2"`
+
+	validator, modified, err = checkRemoteSpec(location, nil, validator)
+	require.NoError(t, err)
+	assert.True(t, modified)
+	assert.Equal(t, `"s2"`, validator.Refs[server.URL+"/schemas.yaml"].ETag)
+
+	// Referenced files aren't tracked without spec_checksum_refs
+	t.Setenv("INPUT_SPEC_CHECKSUM_REFS", "false")
+	schemasETag = `"s3"`
+
+	_, modified, err = checkRemoteSpec(location, nil, validator)
+	require.NoError(t, err)
+	assert.False(t, modified)
+}
@@ -0,0 +1,60 @@
+package document
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// ResolvedDocument is the final document a source was generated from, after its inputs were downloaded, converted,
+// merged and had overlays applied
+type ResolvedDocument struct {
+	// Path is relative to the workspace when within it, so it can be used by later steps of the job
+	Path     string `json:"path"`
+	Checksum string `json:"checksum"`
+}
+
+// GetResolvedDocument returns the final document the CLI resolved the source to, or nil if it no longer exists
+func GetResolvedDocument(source workflow.Source) (*ResolvedDocument, error) {
+	dir := filepath.Join(environment.GetRepoDir(), environment.GetWorkingDirectory())
+
+	// The output location of a source is relative to the directory the CLI was run in
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chdir(dir); err != nil {
+		return nil, err
+	}
+	location, err := source.GetOutputLocation()
+	if chdirErr := os.Chdir(cwd); chdirErr != nil {
+		return nil, chdirErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get output location of source: %w", err)
+	}
+
+	if !filepath.IsAbs(location) {
+		location = filepath.Join(dir, location)
+	}
+
+	data, err := os.ReadFile(location)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read resolved document: %w", err)
+	}
+	sum := sha256.Sum256(data)
+
+	if rel, err := filepath.Rel(environment.GetWorkspace(), location); err == nil && filepath.IsLocal(rel) {
+		location = rel
+	}
+
+	return &ResolvedDocument{Path: location, Checksum: hex.EncodeToString(sum[:])}, nil
+}
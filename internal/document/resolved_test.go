@@ -0,0 +1,35 @@
+package document
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetResolvedDocument(t *testing.T) {
+	workspace := t.TempDir()
+	t.Setenv("GITHUB_WORKSPACE", workspace)
+	t.Setenv("INPUT_REPO_DIR", filepath.Join(workspace, "repo"))
+	t.Setenv("INPUT_WORKING_DIRECTORY", "api")
+
+	contents := []byte("openapi: 3.1.0\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(workspace, "repo", "api"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(workspace, "repo", "api", "openapi.yaml"), contents, 0o644))
+
+	doc, err := GetResolvedDocument(workflow.Source{Inputs: []workflow.Document{{Location: "openapi.yaml"}}})
+	require.NoError(t, err)
+
+	sum := sha256.Sum256(contents)
+	assert.Equal(t, &ResolvedDocument{Path: filepath.Join("repo", "api", "openapi.yaml"), Checksum: hex.EncodeToString(sum[:])}, doc)
+
+	output := "merged.yaml"
+	doc, err = GetResolvedDocument(workflow.Source{Inputs: []workflow.Document{{Location: "openapi.yaml"}, {Location: "other.yaml"}}, Output: &output})
+	require.NoError(t, err)
+	assert.Nil(t, doc)
+}
@@ -0,0 +1,308 @@
+package document
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/speakeasy-api/sdk-generation-action/internal/download"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// ArtifactDownloader downloads the file at an artifact:// location to outPath
+type ArtifactDownloader func(location, outPath string) error
+
+// InlineDocumentVariable is the environment variable workflow.yaml sources reference to generate from the document
+// passed to the action through openapi_doc_content or openapi_doc_path
+const InlineDocumentVariable = "OPENAPI_DOC_LOCATION"
+
+var locationVariableRegex = regexp.MustCompile(`^\$\{?(\w+)\}?$`)
+
+// StagedDocuments are the results of staging the workflow's source documents, keyed by their location in workflow.yaml
+type StagedDocuments struct {
+	// Paths are the local copies of the documents read while staging them, so they're checked without being downloaded
+	// again
+	Paths map[string]string
+	// PostmanConversions are the checksums of the OpenAPI documents Postman collections were converted to
+	PostmanConversions PostmanConversions
+}
+
+// StageDocuments prepares the workflow's source documents that the CLI can't generate from directly: documents located
+// in cloud storage (s3://, gs:// or az:// URLs) or in workflow run artifacts (artifact:// URLs) are downloaded, remote
+// documents referencing other files through relative $refs are downloaded along with them and Postman collections are
+// converted to OpenAPI. Such documents are referenced by an environment variable in
+// workflow.yaml (e.g. location: $OPENAPI_DOC_LOCATION), which the CLI expands, and the variable is pointed at the
+// prepared copy. Every other document is read too, remote ones being downloaded, to be checked, and fails when it would
+// need preparing.
+func StageDocuments(wf *workflow.Workflow, downloadArtifact ArtifactDownloader) (*StagedDocuments, error) {
+	documents := []workflow.Document{}
+	for _, source := range wf.Sources {
+		documents = append(documents, source.Inputs...)
+		for _, overlay := range source.Overlays {
+			if overlay.Document != nil {
+				documents = append(documents, *overlay.Document)
+			}
+		}
+	}
+
+	if err := stageInlineDocument(documents); err != nil {
+		return nil, err
+	}
+
+	staged := &StagedDocuments{Paths: map[string]string{}, PostmanConversions: PostmanConversions{}}
+	for i, doc := range documents {
+		matches := locationVariableRegex.FindStringSubmatch(string(doc.Location))
+		if matches == nil {
+			localPath, err := fetchDocument(fmt.Sprintf("document%d", i), doc)
+			if err != nil {
+				return nil, err
+			}
+			if localPath == "" {
+				continue
+			}
+			if err := checkUnstagedDocument(doc, localPath); err != nil {
+				return nil, err
+			}
+			staged.Paths[string(doc.Location)] = localPath
+			continue
+		}
+		variable := matches[1]
+
+		localPath, stagedPath, err := stageDocument(variable, doc, downloadArtifact, staged.PostmanConversions)
+		if err != nil {
+			return nil, err
+		}
+		if stagedPath != "" {
+			if err := os.Setenv(variable, stagedPath); err != nil {
+				return nil, fmt.Errorf("failed to set %s: %w", variable, err)
+			}
+			localPath = stagedPath
+		}
+		if localPath != "" {
+			staged.Paths[string(doc.Location)] = localPath
+		}
+	}
+
+	return staged, nil
+}
+
+// checkUnstagedDocument fails for documents the CLI can't generate from as they are, which are only prepared when
+// referenced by a variable
+func checkUnstagedDocument(doc workflow.Document, localPath string) error {
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return nil
+	}
+
+	if isPostmanCollection(data) {
+		return fmt.Errorf("%s is a Postman collection, reference it through an environment variable set to its location in workflow.yaml, e.g. location: $POSTMAN_COLLECTION, so it's converted to OpenAPI before generating", doc.Location)
+	}
+
+	if doc.IsRemote() {
+		location := doc.Location.Resolve()
+		base, err := url.Parse(location)
+		if err != nil {
+			return nil
+		}
+		if refs, err := relativeRefs(base, data); err == nil && len(refs) > 0 {
+			return fmt.Errorf("%s references other files through relative $refs, reference it through an environment variable set to its location in workflow.yaml, e.g. location: $OPENAPI_DOC_URL, so the files are downloaded with it before generating", doc.Location)
+		}
+	}
+
+	return nil
+}
+
+// fetchDocument returns the local path of a document the CLI reads as is, downloading remote documents to the staged
+// directory. Documents in the Speakeasy registry, cloud storage or artifacts and missing local documents are skipped
+// with an empty path.
+func fetchDocument(name string, doc workflow.Document) (string, error) {
+	location := doc.Location.Resolve()
+
+	switch {
+	case doc.IsSpeakeasyRegistry() || download.IsCloudStorageURL(location) || download.IsArtifactURL(location):
+		return "", nil
+	case doc.IsRemote():
+		stagedDir, err := stagedDocumentsDir()
+		if err != nil {
+			return "", err
+		}
+		localPath := filepath.Join(stagedDir, name+path.Ext(strings.SplitN(location, "?", 2)[0]))
+		if err := downloadRemoteDocument(location, location, doc.Auth, localPath); err != nil {
+			return "", fmt.Errorf("failed to download %s: %w", location, err)
+		}
+		return localPath, nil
+	}
+
+	localPath := location
+	if !filepath.IsAbs(localPath) {
+		localPath = filepath.Join(environment.GetRepoDir(), environment.GetWorkingDirectory(), location)
+	}
+	if _, err := os.Stat(localPath); err != nil {
+		// Left for the CLI to report
+		return "", nil
+	}
+
+	return localPath, nil
+}
+
+// downloadRemoteDocument downloads the file at refLocation, authenticating with the auth of the document at location
+// when the file is on the same host
+func downloadRemoteDocument(location, refLocation string, auth *workflow.Auth, outPath string) error {
+	header, token := "", ""
+	if auth := authForLocation(location, refLocation, auth); auth != nil {
+		header = auth.Header
+		token = os.Getenv(strings.ToUpper(strings.TrimPrefix(auth.Secret, "$")))
+	}
+
+	return download.DownloadFile(refLocation, outPath, header, token)
+}
+
+// stagedDocumentsDir returns the directory documents are staged in, creating it if needed
+func stagedDocumentsDir() (string, error) {
+	stagedDir, err := filepath.Abs(filepath.Join(environment.GetWorkspace(), "staged"))
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path for staged documents: %w", err)
+	}
+	if err := os.MkdirAll(stagedDir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("failed to create staged document directory: %w", err)
+	}
+
+	return stagedDir, nil
+}
+
+// stageInlineDocument points InlineDocumentVariable at the document passed through openapi_doc_content or
+// openapi_doc_path, if any
+func stageInlineDocument(documents []workflow.Document) error {
+	content, docPath := environment.GetOpenAPIDocContent(), environment.GetOpenAPIDocPath()
+	if content == "" && docPath == "" {
+		return nil
+	}
+
+	referenced := slices.ContainsFunc(documents, func(doc workflow.Document) bool {
+		matches := locationVariableRegex.FindStringSubmatch(string(doc.Location))
+		return matches != nil && matches[1] == InlineDocumentVariable
+	})
+	if !referenced {
+		return fmt.Errorf("an OpenAPI document was passed to the action but no source in workflow.yaml has location: $%s", InlineDocumentVariable)
+	}
+
+	var localPath string
+	if content != "" {
+		ext := ".yaml"
+		if strings.HasPrefix(strings.TrimSpace(content), "{") {
+			ext = ".json"
+		}
+
+		var err error
+		localPath, err = filepath.Abs(filepath.Join(environment.GetWorkspace(), "staged", InlineDocumentVariable+ext))
+		if err != nil {
+			return fmt.Errorf("failed to get absolute path for inline document: %w", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(localPath), os.ModePerm); err != nil {
+			return fmt.Errorf("failed to create staged document directory: %w", err)
+		}
+		if err := os.WriteFile(localPath, []byte(content), 0o644); err != nil {
+			return fmt.Errorf("failed to write inline document: %w", err)
+		}
+	} else {
+		localPath = docPath
+		if !filepath.IsAbs(localPath) {
+			localPath = filepath.Join(environment.GetWorkspace(), docPath)
+		}
+		if _, err := os.Stat(localPath); err != nil {
+			return fmt.Errorf("openapi_doc_path %s not found: %w", docPath, err)
+		}
+	}
+
+	logging.Info("Generating from the OpenAPI document passed to the action at %s", localPath)
+
+	if err := os.Setenv(InlineDocumentVariable, localPath); err != nil {
+		return fmt.Errorf("failed to set %s: %w", InlineDocumentVariable, err)
+	}
+
+	return nil
+}
+
+// stageDocument returns the local path the document was read from and the path of the prepared copy of the document,
+// an empty string if the CLI can use it as is. The local path is empty for documents that don't exist yet. The
+// checksums of Postman collections' conversions are added to conversions.
+func stageDocument(variable string, doc workflow.Document, downloadArtifact ArtifactDownloader, conversions PostmanConversions) (string, string, error) {
+	location := doc.Location.Resolve()
+
+	stagedDir, err := stagedDocumentsDir()
+	if err != nil {
+		return "", "", err
+	}
+
+	localPath := filepath.Join(stagedDir, variable+path.Ext(strings.SplitN(location, "?", 2)[0]))
+	downloaded := true
+	// Downloads the files the document references, which the CLI can't resolve against a downloaded copy itself
+	var downloadRef fileDownloader
+
+	switch {
+	case download.IsArtifactURL(location):
+		logging.Info("Downloading %s from %s", variable, location)
+		downloadRef = fileDownloader(downloadArtifact)
+		err = downloadArtifact(location, localPath)
+	case download.IsCloudStorageURL(location):
+		logging.Info("Downloading %s from %s", variable, location)
+		downloadRef = download.DownloadCloudFile
+		err = download.DownloadCloudFile(location, localPath)
+	case doc.IsRemote():
+		// Only needed to check whether it's a Postman collection or references other files, otherwise the CLI
+		// downloads it itself
+		downloaded = false
+		downloadRef = func(refLocation, outPath string) error {
+			return downloadRemoteDocument(location, refLocation, doc.Auth, outPath)
+		}
+		err = downloadRef(location, localPath)
+	default:
+		downloaded = false
+		localPath = location
+		if !filepath.IsAbs(localPath) {
+			localPath = filepath.Join(environment.GetRepoDir(), environment.GetWorkingDirectory(), location)
+		}
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to download %s: %w", location, err)
+	}
+
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		// Left for the CLI to report
+		return "", "", nil
+	}
+
+	if isPostmanCollection(data) {
+		convertedPath := filepath.Join(stagedDir, variable+".openapi.yaml")
+		checksum, err := convertPostmanCollection(localPath, convertedPath)
+		if err != nil {
+			return "", "", err
+		}
+		conversions[string(doc.Location)] = checksum
+		return localPath, convertedPath, nil
+	}
+
+	if downloadRef != nil {
+		resolvedPath, err := resolveRelativeRefs(location, localPath, filepath.Join(stagedDir, variable), downloadRef)
+		if err != nil {
+			return "", "", err
+		}
+		if resolvedPath != localPath {
+			return localPath, resolvedPath, nil
+		}
+	}
+
+	if downloaded {
+		return localPath, localPath, nil
+	}
+
+	return localPath, "", nil
+}
@@ -0,0 +1,50 @@
+package document
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStageInlineDocument(t *testing.T) {
+	t.Setenv("GITHUB_WORKSPACE", t.TempDir())
+	t.Setenv("INPUT_OPENAPI_DOC_CONTENT", `{"openapi": "3.1.0"}`)
+	t.Setenv("INPUT_OPENAPI_DOC_PATH", "")
+	t.Setenv(InlineDocumentVariable, "")
+
+	err := stageInlineDocument([]workflow.Document{{Location: "openapi.yaml"}})
+	assert.ErrorContains(t, err, "no source in workflow.yaml has location: $OPENAPI_DOC_LOCATION")
+
+	require.NoError(t, stageInlineDocument([]workflow.Document{{Location: "${OPENAPI_DOC_LOCATION}"}}))
+
+	data, err := os.ReadFile(os.Getenv(InlineDocumentVariable))
+	require.NoError(t, err)
+	assert.Equal(t, `{"openapi": "3.1.0"}`, string(data))
+	assert.Equal(t, ".json", filepath.Ext(os.Getenv(InlineDocumentVariable)))
+}
+
+func TestStageDocuments_UnreferencedPostmanCollection(t *testing.T) {
+	repoDir := t.TempDir()
+	t.Setenv("INPUT_REPO_DIR", repoDir)
+	t.Setenv("INPUT_WORKING_DIRECTORY", "")
+	t.Setenv("INPUT_OPENAPI_DOC_CONTENT", "")
+	t.Setenv("INPUT_OPENAPI_DOC_PATH", "")
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "openapi.yaml"), []byte("openapi: 3.1.0\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "collection.json"), []byte(`{"info": {"_postman_id": "1234"}, "item": []}`), 0o644))
+
+	wf := &workflow.Workflow{Sources: map[string]workflow.Source{
+		"api": {Inputs: []workflow.Document{{Location: "openapi.yaml"}}},
+	}}
+	staged, err := StageDocuments(wf, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"openapi.yaml": filepath.Join(repoDir, "openapi.yaml")}, staged.Paths)
+
+	wf.Sources["postman"] = workflow.Source{Inputs: []workflow.Document{{Location: "collection.json"}}}
+	_, err = StageDocuments(wf, nil)
+	assert.ErrorContains(t, err, "collection.json is a Postman collection, reference it through an environment variable")
+}
@@ -0,0 +1,61 @@
+package download
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const artifactScheme = "artifact"
+
+// ArtifactLocation identifies a file in a named artifact uploaded by a GitHub Actions workflow run
+type ArtifactLocation struct {
+	Owner string
+	Repo  string
+	Name  string
+	// Path is the path of the file within the artifact, which can be omitted for artifacts of a single file
+	Path string
+	// RunID is the workflow run that uploaded the artifact, or 0 for the most recent artifact with the name
+	RunID int64
+}
+
+// IsArtifactURL returns whether the location is an artifact://owner/repo/name[/path] URL of a workflow run artifact
+func IsArtifactURL(location string) bool {
+	_, err := ParseArtifactURL(location)
+	return err == nil
+}
+
+// ParseArtifactURL parses an artifact://owner/repo/name[/path][?run_id=123] URL
+func ParseArtifactURL(location string) (*ArtifactLocation, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse artifact url: %w", err)
+	}
+	if u.Scheme != artifactScheme {
+		return nil, fmt.Errorf("not an artifact url: %s", location)
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(u.Path, "/"), "/", 3)
+	if u.Host == "" || len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("artifact url %s must be of the form artifact://owner/repo/name[/path]", location)
+	}
+
+	artifact := &ArtifactLocation{
+		Owner: u.Host,
+		Repo:  parts[0],
+		Name:  parts[1],
+	}
+	if len(parts) == 3 {
+		artifact.Path = parts[2]
+	}
+
+	if runID := u.Query().Get("run_id"); runID != "" {
+		artifact.RunID, err = strconv.ParseInt(runID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid run_id in artifact url %s: %w", location, err)
+		}
+	}
+
+	return artifact, nil
+}
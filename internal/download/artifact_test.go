@@ -0,0 +1,22 @@
+package download
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArtifactURL(t *testing.T) {
+	artifact, err := ParseArtifactURL("artifact://acme/api/openapi-spec/dist/openapi.yaml?run_id=1234")
+	require.NoError(t, err)
+	assert.Equal(t, &ArtifactLocation{Owner: "acme", Repo: "api", Name: "openapi-spec", Path: "dist/openapi.yaml", RunID: 1234}, artifact)
+
+	artifact, err = ParseArtifactURL("artifact://acme/api/openapi-spec")
+	require.NoError(t, err)
+	assert.Equal(t, &ArtifactLocation{Owner: "acme", Repo: "api", Name: "openapi-spec"}, artifact)
+
+	_, err = ParseArtifactURL("artifact://acme/api")
+	assert.Error(t, err)
+	assert.False(t, IsArtifactURL("s3://bucket/openapi.yaml"))
+}
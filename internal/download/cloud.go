@@ -0,0 +1,204 @@
+package download
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3Scheme    = "s3"
+	gcsScheme   = "gs"
+	azureScheme = "az"
+)
+
+// emptyPayloadHash is the sha256 of an empty request body
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// IsCloudStorageURL returns whether the location is an s3://bucket/key, gs://bucket/object or
+// az://account/container/blob URL of an object in cloud storage
+func IsCloudStorageURL(location string) bool {
+	u, err := url.Parse(location)
+	if err != nil {
+		return false
+	}
+
+	switch u.Scheme {
+	case s3Scheme, gcsScheme, azureScheme:
+		return u.Host != "" && strings.TrimPrefix(u.Path, "/") != ""
+	default:
+		return false
+	}
+}
+
+// DownloadCloudFile downloads an object from cloud storage, authenticating with the provider's standard credential
+// environment variables as set by its official credentials actions (including when they use OIDC):
+//   - S3: AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN and AWS_REGION (or AWS_DEFAULT_REGION)
+//   - GCS: CLOUDSDK_AUTH_ACCESS_TOKEN or GOOGLE_OAUTH_ACCESS_TOKEN
+//   - Azure Blob Storage: AZURE_STORAGE_SAS_TOKEN
+//
+// Without credentials the object is requested anonymously.
+func DownloadCloudFile(location string, outPath string) error {
+	u, err := url.Parse(location)
+	if err != nil {
+		return fmt.Errorf("failed to parse cloud storage url: %w", err)
+	}
+
+	var req *http.Request
+	var unauthorizedMsg string
+
+	switch u.Scheme {
+	case s3Scheme:
+		req, err = newS3Request(u.Host, strings.TrimPrefix(u.Path, "/"))
+		unauthorizedMsg = "unauthorized, please ensure AWS credentials with access to the object are available in the environment"
+	case gcsScheme:
+		req, err = newGCSRequest(u.Host, strings.TrimPrefix(u.Path, "/"))
+		unauthorizedMsg = "unauthorized, please ensure CLOUDSDK_AUTH_ACCESS_TOKEN or GOOGLE_OAUTH_ACCESS_TOKEN is set to a token with access to the object"
+	case azureScheme:
+		req, err = newAzureRequest(u.Host, strings.TrimPrefix(u.Path, "/"))
+		unauthorizedMsg = "unauthorized, please ensure AZURE_STORAGE_SAS_TOKEN is set to a SAS token with access to the blob"
+	default:
+		return fmt.Errorf("unsupported cloud storage url: %s", location)
+	}
+	if err != nil {
+		return err
+	}
+
+	return downloadRequest(req, outPath, unauthorizedMsg)
+}
+
+func newS3Request(bucket, key string) (*http.Request, error) {
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", bucket, region, escapeObjectPath(key)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	accessKeyID := os.Getenv("AWS_ACCESS_KEY_ID")
+	secretAccessKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKeyID == "" || secretAccessKey == "" {
+		return req, nil
+	}
+
+	if token := os.Getenv("AWS_SESSION_TOKEN"); token != "" {
+		req.Header.Set("X-Amz-Security-Token", token)
+	}
+	signS3Request(req, accessKeyID, secretAccessKey, region, time.Now())
+
+	return req, nil
+}
+
+// signS3Request signs the request and all of its headers with AWS Signature Version 4
+func signS3Request(req *http.Request, accessKeyID, secretAccessKey, region string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	canonicalHeaders := ""
+	for _, name := range names {
+		canonicalHeaders += name + ":" + headers[name] + "\n"
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		emptyPayloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, region)
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(canonicalRequestHash[:])}, "\n")
+
+	key := []byte("AWS4" + secretAccessKey)
+	for _, part := range []string{date, region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", accessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// escapeObjectPath percent-encodes each segment of the object's path, leaving only unreserved characters as S3 signing
+// requires
+func escapeObjectPath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		escaped := ""
+		for _, b := range []byte(segment) {
+			if ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z') || ('0' <= b && b <= '9') || strings.IndexByte("-_.~", b) >= 0 {
+				escaped += string(b)
+			} else {
+				escaped += fmt.Sprintf("%%%02X", b)
+			}
+		}
+		segments[i] = escaped
+	}
+	return strings.Join(segments, "/")
+}
+
+func newGCSRequest(bucket, object string) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("https://storage.googleapis.com/storage/v1/b/%s/o/%s?alt=media", url.PathEscape(bucket), url.PathEscape(object)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	token := os.Getenv("CLOUDSDK_AUTH_ACCESS_TOKEN")
+	if token == "" {
+		token = os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return req, nil
+}
+
+func newAzureRequest(account, blob string) (*http.Request, error) {
+	u := fmt.Sprintf("https://%s.blob.core.windows.net/%s", account, escapeObjectPath(blob))
+	if sas := strings.TrimPrefix(os.Getenv("AZURE_STORAGE_SAS_TOKEN"), "?"); sas != "" {
+		u += "?" + sas
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	return req, nil
+}
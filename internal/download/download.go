@@ -20,6 +20,10 @@ func DownloadFile(url string, outPath string, header string, token string) error
 		req.Header.Add(header, token)
 	}
 
+	return downloadRequest(req, outPath, "unauthorized, please ensure auth_header and auth_token inputs are set correctly and a valid token has been provided")
+}
+
+func downloadRequest(req *http.Request, outPath string, unauthorizedMsg string) error {
 	res, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to download file: %w", err)
@@ -32,7 +36,7 @@ func DownloadFile(url string, outPath string, header string, token string) error
 	case 404:
 		return fmt.Errorf("file not found")
 	case 401:
-		return fmt.Errorf("unauthorized, please ensure auth_header and auth_token inputs are set correctly and a valid token has been provided")
+		return fmt.Errorf("%s", unauthorizedMsg)
 	default:
 		if res.StatusCode/100 != 2 {
 			return fmt.Errorf("failed to download file: %s", res.Status)
@@ -0,0 +1,89 @@
+package environment
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// workflowDispatchOverrides are the workflow_dispatch inputs that override the action's inputs when a run is triggered manually
+var workflowDispatchOverrides = []string{"force", "target", "version_bump", "dry_run"}
+
+func IsWorkflowDispatch() bool {
+	return os.Getenv("GITHUB_EVENT_NAME") == "workflow_dispatch"
+}
+
+func IsScheduledRun() bool {
+	return os.Getenv("GITHUB_EVENT_NAME") == "schedule"
+}
+
+// IsPullRequestEvent returns whether the run was triggered by a pull request, e.g. one changing the OpenAPI doc
+func IsPullRequestEvent() bool {
+	event := os.Getenv("GITHUB_EVENT_NAME")
+	return event == "pull_request" || event == "pull_request_target"
+}
+
+// GetPullRequestLabels returns the labels of the pull request that triggered the run
+func GetPullRequestLabels() ([]string, error) {
+	if !IsPullRequestEvent() || GetWorkflowEventPayloadPath() == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(GetWorkflowEventPayloadPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workflow event payload: %w", err)
+	}
+
+	var payload struct {
+		PullRequest struct {
+			Labels []struct {
+				Name string `json:"name"`
+			} `json:"labels"`
+		} `json:"pull_request"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workflow event payload: %w", err)
+	}
+
+	labels := make([]string, 0, len(payload.PullRequest.Labels))
+	for _, label := range payload.PullRequest.Labels {
+		labels = append(labels, label.Name)
+	}
+
+	return labels, nil
+}
+
+// applyWorkflowDispatchOverrides sets the action inputs from the manually triggered run's github.event.inputs so
+// on-demand runs don't require the values to be wired through the workflow file
+func applyWorkflowDispatchOverrides() error {
+	if !IsWorkflowDispatch() || GetWorkflowEventPayloadPath() == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(GetWorkflowEventPayloadPath())
+	if err != nil {
+		return fmt.Errorf("failed to read workflow event payload: %w", err)
+	}
+
+	var payload struct {
+		Inputs map[string]any `json:"inputs"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal workflow event payload: %w", err)
+	}
+
+	for _, name := range workflowDispatchOverrides {
+		value, ok := payload.Inputs[name]
+		if !ok || value == nil || fmt.Sprint(value) == "" {
+			continue
+		}
+
+		fmt.Printf("Using %s=%v from workflow_dispatch inputs\n", name, value)
+		if err := os.Setenv("INPUT_"+strings.ToUpper(name), fmt.Sprint(value)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
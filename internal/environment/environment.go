@@ -1,14 +1,17 @@
 package environment
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/speakeasy-api/sdk-generation-action/internal/freeze"
 )
 
 type Mode string
@@ -31,11 +34,17 @@ const (
 	ActionLog                Action = "log-result"
 	ActionPublishEvent       Action = "publish-event"
 	ActionTag                Action = "tag"
+	ActionChangelog          Action = "changelog"
+	ActionYank               Action = "yank"
+	ActionGraduate           Action = "graduate"
 )
 
 const (
 	DefaultMaxValidationWarnings = 1000
 	DefaultMaxValidationErrors   = 1000
+	// DefaultPackageGrowthWarning is the percentage a target's package size or dependency count can grow by in a
+	// regeneration before a warning is shown
+	DefaultPackageGrowthWarning = 25
 )
 
 var (
@@ -48,12 +57,66 @@ func init() {
 	if os.Getenv("SPEAKEASY_ENVIRONMENT") == "local" {
 		baseDir, _ = os.Getwd()
 	}
+
+	// Set when running outside of the docker container (e.g. via the composite action), where "/" is not writable
+	if dir := os.Getenv("SPEAKEASY_ACTION_BASE_DIR"); dir != "" {
+		baseDir = dir
+	}
+
+	// Composite action steps don't receive INPUT_ environment variables, so the inputs are passed through as JSON
+	if inputsJSON := os.Getenv("SPEAKEASY_ACTION_INPUTS"); inputsJSON != "" {
+		if err := setInputsFromJSON(inputsJSON); err != nil {
+			fmt.Printf("Error: Failed to parse action inputs: %s\n", err)
+		}
+	}
+
+	if err := applyWorkflowDispatchOverrides(); err != nil {
+		fmt.Printf("Error: Failed to apply workflow_dispatch inputs: %s\n", err)
+	}
 }
 
+func setInputsFromJSON(inputsJSON string) error {
+	var inputs map[string]string
+	if err := json.Unmarshal([]byte(inputsJSON), &inputs); err != nil {
+		return err
+	}
+
+	for name, value := range inputs {
+		key := "INPUT_" + strings.ToUpper(strings.ReplaceAll(name, " ", "_"))
+		if _, ok := os.LookupEnv(key); ok {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetBaseDir returns the directory the Speakeasy CLI and other tooling is installed to
 func GetBaseDir() string {
+	if dir := os.Getenv("INPUT_BASE_DIR"); dir != "" {
+		return dir
+	}
+
 	return baseDir
 }
 
+// GetRepoDir returns the directory the repository is cloned to, a relative repo_dir is resolved against the workspace
+func GetRepoDir() string {
+	repoDir := os.Getenv("INPUT_REPO_DIR")
+	if repoDir == "" {
+		repoDir = "repo"
+	}
+
+	if filepath.IsAbs(repoDir) {
+		return repoDir
+	}
+
+	return filepath.Join(GetWorkspace(), repoDir)
+}
+
 func IsDebugMode() bool {
 	return os.Getenv("INPUT_DEBUG") == "true" || os.Getenv("RUNNER_DEBUG") == "1"
 }
@@ -89,6 +152,271 @@ func PushCodeSamplesOnly() bool {
 	return os.Getenv("INPUT_PUSH_CODE_SAMPLES_ONLY") == "true"
 }
 
+// GetVersionBump returns the version bump to apply to the generated SDKs, overriding the automatically determined bump
+func GetVersionBump() string {
+	return os.Getenv("INPUT_VERSION_BUMP")
+}
+
+// GetMaxAutomaticVersionBump returns the largest bump that may be applied without the version_bump input, runs whose
+// changes require a larger bump fail
+func GetMaxAutomaticVersionBump() string {
+	return os.Getenv("INPUT_MAX_AUTOMATIC_VERSION_BUMP")
+}
+
+// GetMinSpecChangeVersionBump returns the smallest bump to apply when the OpenAPI doc version advances
+func GetMinSpecChangeVersionBump() string {
+	return os.Getenv("INPUT_MIN_SPEC_CHANGE_VERSION_BUMP")
+}
+
+// PreOneSemver returns whether automatic major bumps are applied as minor bumps, and minor as patch, to SDKs below 1.0.0
+func PreOneSemver() bool {
+	return os.Getenv("INPUT_PRE_1_0_SEMVER") == "true"
+}
+
+type CLIFeaturePolicy string
+
+const (
+	// CLIFeaturePolicyWarn continues without features the CLI in use doesn't support, annotating the run with a warning
+	CLIFeaturePolicyWarn CLIFeaturePolicy = "warn"
+	// CLIFeaturePolicyFail fails the run when the CLI in use doesn't support a feature
+	CLIFeaturePolicyFail CLIFeaturePolicy = "fail"
+)
+
+func GetCLIFeaturePolicy() CLIFeaturePolicy {
+	policy := os.Getenv("INPUT_CLI_FEATURE_POLICY")
+	if policy == "" {
+		return CLIFeaturePolicyWarn
+	}
+
+	return CLIFeaturePolicy(policy)
+}
+
+type SemverCheckPolicy string
+
+const (
+	// SemverCheckOff skips checking version bumps against the changes to the SDKs' public APIs
+	SemverCheckOff SemverCheckPolicy = "off"
+	// SemverCheckWarn annotates the run with a warning when public API was removed without a breaking version bump
+	SemverCheckWarn SemverCheckPolicy = "warn"
+	// SemverCheckFail fails the run when public API was removed without a breaking version bump
+	SemverCheckFail SemverCheckPolicy = "fail"
+	// SemverCheckEscalate regenerates with a breaking version bump when public API was removed without one
+	SemverCheckEscalate SemverCheckPolicy = "escalate"
+)
+
+func GetSemverCheckPolicy() SemverCheckPolicy {
+	policy := os.Getenv("INPUT_SEMVER_CHECK")
+	if policy == "" {
+		return SemverCheckWarn
+	}
+
+	return SemverCheckPolicy(policy)
+}
+
+type SpecChecksumAlgorithm string
+
+const (
+	// SpecChecksumETag detects changes to remote specs from their ETag and Last-Modified headers, using conditional requests
+	SpecChecksumETag SpecChecksumAlgorithm = "etag"
+	// SpecChecksumSHA256 detects changes to remote specs from the sha256 checksum of their contents
+	SpecChecksumSHA256 SpecChecksumAlgorithm = "sha256"
+)
+
+func GetSpecChecksumAlgorithm() SpecChecksumAlgorithm {
+	algorithm := os.Getenv("INPUT_SPEC_CHECKSUM_ALGORITHM")
+	if algorithm == "" {
+		return SpecChecksumETag
+	}
+
+	return SpecChecksumAlgorithm(algorithm)
+}
+
+// IncludeSpecRefs returns whether the files remote specs reference through external $refs are included in detecting
+// their changes, by their checksums or ETag and Last-Modified headers
+func IncludeSpecRefs() bool {
+	return os.Getenv("INPUT_SPEC_CHECKSUM_REFS") == "true"
+}
+
+// IsDryRun returns whether to generate without pushing changes, opening pull requests or creating releases
+func IsDryRun() bool {
+	return os.Getenv("INPUT_DRY_RUN") == "true"
+}
+
+type MajorBumpApproval string
+
+const (
+	// MajorBumpApprovalOff commits major version bumps like any other
+	MajorBumpApprovalOff MajorBumpApproval = "off"
+	// MajorBumpApprovalIssue holds back major version bumps until the issue opened to approve them is labelled approved
+	MajorBumpApprovalIssue MajorBumpApproval = "issue"
+	// MajorBumpApprovalEnvironment holds back major version bumps and sets the approval_required output, so a job gated
+	// by a GitHub environment's required reviewers can re-run the workflow with version_bump major
+	MajorBumpApprovalEnvironment MajorBumpApproval = "environment"
+)
+
+// GetMajorBumpApproval returns how automatically determined major version bumps are approved before being committed
+func GetMajorBumpApproval() MajorBumpApproval {
+	approval := os.Getenv("INPUT_MAJOR_BUMP_APPROVAL")
+	if approval == "" {
+		return MajorBumpApprovalOff
+	}
+
+	return MajorBumpApproval(approval)
+}
+
+type CommitMethod string
+
+const (
+	// CommitMethodGit commits locally and pushes with git
+	CommitMethodGit CommitMethod = "git"
+	// CommitMethodAPI creates commits through the GitHub API, which signs them so they're verified as the token's bot or
+	// app
+	CommitMethodAPI CommitMethod = "api"
+)
+
+// GetCommitMethod returns how generated changes are committed and pushed
+func GetCommitMethod() CommitMethod {
+	method := os.Getenv("INPUT_COMMIT_METHOD")
+	if method == "" {
+		return CommitMethodGit
+	}
+
+	return CommitMethod(method)
+}
+
+type CleanOutputPolicy string
+
+const (
+	// CleanOutputOff leaves the files of targets' output directories in place before generation
+	CleanOutputOff CleanOutputPolicy = "off"
+	// CleanOutputGenerated deletes the files recorded as generated in targets' gen.lock before generation
+	CleanOutputGenerated CleanOutputPolicy = "generated"
+	// CleanOutputAll deletes every file of targets' output directories other than the protected paths before generation
+	CleanOutputAll CleanOutputPolicy = "all"
+)
+
+// GetCleanOutput returns which files of targets' output directories are deleted before generation, so files no longer
+// generated from the spec are deleted with it
+func GetCleanOutput() CleanOutputPolicy {
+	policy := os.Getenv("INPUT_CLEAN_OUTPUT")
+	if policy == "" {
+		return CleanOutputOff
+	}
+
+	return CleanOutputPolicy(policy)
+}
+
+// GetCleanOutputProtected returns gitignore style patterns, relative to each target's output directory, of files never
+// deleted by clean_output
+func GetCleanOutputProtected() []string {
+	return parseListInput(os.Getenv("INPUT_CLEAN_OUTPUT_PROTECTED"))
+}
+
+// PruneOrphanedFiles returns whether files the last generation of a target produced that its new generation no longer
+// produces are deleted
+func PruneOrphanedFiles() bool {
+	return os.Getenv("INPUT_PRUNE_ORPHANED_FILES") != "false"
+}
+
+// GetReleaseFreezeWindows returns the windows during which releases are frozen, in the release_freeze_timezone time zone
+func GetReleaseFreezeWindows() ([]freeze.Window, error) {
+	timezone := os.Getenv("INPUT_RELEASE_FREEZE_TIMEZONE")
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", timezone)
+	}
+
+	return freeze.Parse(parseListInput(os.Getenv("INPUT_RELEASE_FREEZE")), loc)
+}
+
+// GetMinReleaseInterval returns how long after the last generation changes are batched rather than generated and
+// released, or 0 if every change is generated. The interval is a Go duration, e.g. "12h", or a number of days, e.g. "7d".
+func GetMinReleaseInterval() (time.Duration, error) {
+	return parseInterval(os.Getenv("INPUT_MIN_RELEASE_INTERVAL"))
+}
+
+// ReleaseAfterPublish returns whether the GitHub releases of SDKs published to package registries, and their
+// RELEASES.md entry, are deferred until publishing succeeds, their tags being pushed immediately
+func ReleaseAfterPublish() bool {
+	return os.Getenv("INPUT_RELEASE_AFTER_PUBLISH") == "true"
+}
+
+// WaitForChecks returns whether direct mode waits for the required checks of the generation commit to complete
+func WaitForChecks() bool {
+	return os.Getenv("INPUT_WAIT_FOR_CHECKS") == "true"
+}
+
+// GetWaitForChecksTimeout returns how long direct mode waits for the required checks of the generation commit, 30
+// minutes by default
+func GetWaitForChecksTimeout() (time.Duration, error) {
+	timeout, err := parseInterval(os.Getenv("INPUT_WAIT_FOR_CHECKS_TIMEOUT"))
+	if err != nil || timeout != 0 {
+		return timeout, err
+	}
+
+	return 30 * time.Minute, nil
+}
+
+// GetRequiredChecks returns the names of the checks direct mode waits for, rather than those required by the branch's
+// rulesets and branch protection
+func GetRequiredChecks() []string {
+	return parseListInput(os.Getenv("INPUT_REQUIRED_CHECKS"))
+}
+
+// GetGeneratorUpgradeInterval returns how often changes caused only by a new Speakeasy CLI version are batched into a
+// generator upgrade PR, or 0 if they're generated like any other change. The interval is a Go duration or a number of
+// days, e.g. "7d".
+func GetGeneratorUpgradeInterval() (time.Duration, error) {
+	return parseInterval(os.Getenv("INPUT_GENERATOR_UPGRADE_INTERVAL"))
+}
+
+// parseInterval parses a Go duration, e.g. "12h", or a number of days, e.g. "7d", returning 0 for an empty interval
+func parseInterval(interval string) (time.Duration, error) {
+	if interval == "" {
+		return 0, nil
+	}
+
+	if days, ok := strings.CutSuffix(interval, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid number of days %q", days)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(interval)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", interval)
+	}
+
+	return d, nil
+}
+
+// IsHousekeepingEnabled returns whether PR mode runs close the generation PRs superseded by their PR and delete stale
+// generation branches
+func IsHousekeepingEnabled() bool {
+	return os.Getenv("INPUT_HOUSEKEEPING") == "true"
+}
+
+// GetStaleBranchAge returns how long after their last commit generation branches without an open PR are deleted by
+// housekeeping, 30 days by default
+func GetStaleBranchAge() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("INPUT_STALE_BRANCH_DAYS"))
+	if err != nil {
+		days = 30
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// UpdateDigestIssue returns whether dry runs should summarize the changes they detect on a weekly digest issue
+func UpdateDigestIssue() bool {
+	return os.Getenv("INPUT_DIGEST_ISSUE") == "true"
+}
+
 func SetVersion() string {
 	return os.Getenv("INPUT_SET_VERSION")
 }
@@ -109,6 +437,133 @@ func SpecifiedCodeSamplesTargets() []string {
 	return parseArrayInput(os.Getenv("INPUT_CODE_SAMPLES"))
 }
 
+// GetCommitExcludes returns glob patterns, relative to the working directory, of paths that should never be committed
+func GetCommitExcludes() []string {
+	return parseListInput(os.Getenv("INPUT_COMMIT_EXCLUDE"))
+}
+
+// GetUserExtendableFiles returns glob patterns, relative to each target's output directory, of files the generator
+// scaffolds for users to extend, which are three-way merged on regeneration rather than overwritten
+func GetUserExtendableFiles() []string {
+	return parseListInput(os.Getenv("INPUT_USER_EXTENDABLE_FILES"))
+}
+
+// GetNamespacedTargets returns the IDs of the targets generated from separate specs as namespaced sub-packages of a
+// single SDK, which are regenerated and released together under a combined version
+func GetNamespacedTargets() []string {
+	return parseListInput(os.Getenv("INPUT_NAMESPACED_TARGETS"))
+}
+
+// GetServerStubTargets returns the IDs of the targets generating server stubs, which are versioned separately from the
+// SDKs and never published
+func GetServerStubTargets() []string {
+	return parseListInput(os.Getenv("INPUT_SERVER_STUB_TARGETS"))
+}
+
+// GetArtifactTargets returns the IDs of the non-SDK targets, e.g. docs or Postman collections, delivered as workflow
+// artifacts rather than committed
+func GetArtifactTargets() []string {
+	return parseListInput(os.Getenv("INPUT_ARTIFACT_TARGETS"))
+}
+
+// SetArtifactTargetDirs sets the output directories of the artifact targets, relative to the working directory, whose
+// contents aren't committed apart from their management config
+func SetArtifactTargetDirs(dirs []string) error {
+	return os.Setenv("SPEAKEASY_ARTIFACT_TARGET_DIRS", strings.Join(dirs, "\n"))
+}
+
+// GetArtifactTargetDirs returns the directories set by SetArtifactTargetDirs
+func GetArtifactTargetDirs() []string {
+	return parseListInput(os.Getenv("SPEAKEASY_ARTIFACT_TARGET_DIRS"))
+}
+
+// GetWebhookTargets returns the IDs of the targets generating webhook handling code keyed to the IDs of the SDK targets
+// they are versioned and released together with, which are validated by ValidateInputs
+func GetWebhookTargets() map[string]string {
+	targets, _ := parseWebhookTargets(os.Getenv("INPUT_WEBHOOK_TARGETS"))
+	return targets
+}
+
+func parseWebhookTargets(input string) (map[string]string, error) {
+	targets := map[string]string{}
+	if input == "" {
+		return targets, nil
+	}
+
+	if err := json.Unmarshal([]byte(input), &targets); err != nil {
+		return map[string]string{}, err
+	}
+
+	return targets, nil
+}
+
+// SpecEnvironment is an API environment, e.g. staging or production, whose spec is a source of the workflow. The targets
+// generated from the source are the SDK flavors of the environment.
+type SpecEnvironment struct {
+	// Source is the ID of the workflow source of the environment's spec
+	Source string `json:"source"`
+	// NPMDistTag is the npm dist-tag the environment's TypeScript SDKs are published under, defaulting to that of the
+	// release channel
+	NPMDistTag string `json:"npm_dist_tag,omitempty"`
+}
+
+// GetSpecEnvironments returns the API environments keyed to their names, which are validated by ValidateInputs
+func GetSpecEnvironments() map[string]SpecEnvironment {
+	environments, _ := parseSpecEnvironments(os.Getenv("INPUT_SPEC_ENVIRONMENTS"))
+	return environments
+}
+
+func parseSpecEnvironments(input string) (map[string]SpecEnvironment, error) {
+	environments := map[string]SpecEnvironment{}
+	if input == "" {
+		return environments, nil
+	}
+
+	if err := json.Unmarshal([]byte(input), &environments); err != nil {
+		return map[string]SpecEnvironment{}, err
+	}
+
+	sources := map[string]string{}
+	for name, env := range environments {
+		if env.Source == "" {
+			return map[string]SpecEnvironment{}, fmt.Errorf("environment %s has no source", name)
+		}
+		if other, ok := sources[env.Source]; ok {
+			return map[string]SpecEnvironment{}, fmt.Errorf("environments %s and %s share the source %s", other, name, env.Source)
+		}
+		sources[env.Source] = name
+	}
+
+	return environments, nil
+}
+
+// GetStepHooks returns the shell commands run at the hook points of the action's steps, keyed to the hook point, e.g.
+// "before:generate" or "after:commit", which are validated by ValidateInputs
+func GetStepHooks() map[string]string {
+	hooks, _ := parseStepHooks(os.Getenv("INPUT_STEP_HOOKS"))
+	return hooks
+}
+
+func parseStepHooks(input string) (map[string]string, error) {
+	hooks := map[string]string{}
+	if input == "" {
+		return hooks, nil
+	}
+
+	if err := json.Unmarshal([]byte(input), &hooks); err != nil {
+		return map[string]string{}, err
+	}
+
+	for point := range hooks {
+		when, step, ok := strings.Cut(point, ":")
+		if !ok || (when != "before" && when != "after") || step == "" {
+			return map[string]string{}, fmt.Errorf("invalid hook point %q, expected before:<step> or after:<step>", point)
+		}
+	}
+
+	return hooks, nil
+}
+
 func GetMode() Mode {
 	mode := os.Getenv("INPUT_MODE")
 	if mode == "" {
@@ -167,6 +622,44 @@ func GetOpenAPIDocLocation() string {
 	return os.Getenv("INPUT_OPENAPI_DOC_LOCATION")
 }
 
+// GetOpenAPIDocContent returns the content of an OpenAPI document passed inline to generate from
+func GetOpenAPIDocContent() string {
+	return os.Getenv("INPUT_OPENAPI_DOC_CONTENT")
+}
+
+// GetOpenAPIDocPath returns the path, relative to the workspace, of an OpenAPI document a prior step left to generate from
+func GetOpenAPIDocPath() string {
+	return os.Getenv("INPUT_OPENAPI_DOC_PATH")
+}
+
+// GetOpenAPIDocVersionOverride returns the version to record for the OpenAPI documents in place of their info.version
+func GetOpenAPIDocVersionOverride() string {
+	return os.Getenv("INPUT_OPENAPI_DOC_VERSION_OVERRIDE")
+}
+
+type DocVersionComparison string
+
+const (
+	// DocVersionSemver compares OpenAPI doc versions as semver, falling back to any difference for other versions
+	DocVersionSemver DocVersionComparison = "semver"
+	// DocVersionLexicographic compares OpenAPI doc versions as strings
+	DocVersionLexicographic DocVersionComparison = "lexicographic"
+	// DocVersionDate compares OpenAPI doc versions as dates, e.g. 2024-10-15 or 20241015
+	DocVersionDate DocVersionComparison = "date"
+	// DocVersionChecksum ignores OpenAPI doc versions, comparing the checksums of the documents instead
+	DocVersionChecksum DocVersionComparison = "checksum"
+)
+
+// GetDocVersionComparison returns how to determine whether the OpenAPI doc version advanced since the last generation
+func GetDocVersionComparison() DocVersionComparison {
+	comparison := os.Getenv("INPUT_OPENAPI_DOC_VERSION_COMPARISON")
+	if comparison == "" {
+		return DocVersionSemver
+	}
+
+	return DocVersionComparison(comparison)
+}
+
 func GetOpenAPIDocs() string {
 	return os.Getenv("INPUT_OPENAPI_DOCS")
 }
@@ -237,7 +730,335 @@ func GetCliOutput() string {
 	return os.Getenv("INPUT_CLI_OUTPUT")
 }
 
+func CreateCheckRun() bool {
+	return os.Getenv("INPUT_CREATE_CHECK_RUN") == "true"
+}
+
+func TrackDeployments() bool {
+	return os.Getenv("INPUT_TRACK_DEPLOYMENTS") == "true"
+}
+
+// LanguageOverride overrides how a language's SDKs are named and installed, in place of what's configured for their targets
+type LanguageOverride struct {
+	InstallationURL *string `json:"installation_url,omitempty"`
+	Published       *bool   `json:"published,omitempty"`
+	// PackageName is written into gen.yaml before generation
+	PackageName *string `json:"package_name,omitempty"`
+	// InitialVersion is written into gen.yaml before the language is first generated
+	InitialVersion *string `json:"initial_version,omitempty"`
+	// SpeakeasyVersion pins the CLI version the language's targets are generated with
+	SpeakeasyVersion *string `json:"speakeasy_version,omitempty"`
+}
+
+// GetLanguageOverrides returns the installation overrides keyed by language, which are validated by ValidateInputs
+func GetLanguageOverrides() map[string]LanguageOverride {
+	overrides, _ := parseLanguageOverrides(os.Getenv("INPUT_LANGUAGE_OVERRIDES"))
+	return overrides
+}
+
+func parseLanguageOverrides(input string) (map[string]LanguageOverride, error) {
+	overrides := map[string]LanguageOverride{}
+	if input == "" {
+		return overrides, nil
+	}
+
+	if err := json.Unmarshal([]byte(input), &overrides); err != nil {
+		return map[string]LanguageOverride{}, err
+	}
+
+	return overrides, nil
+}
+
+// RegistryPreflight returns whether to check the registries of published targets before committing a new version
+func RegistryPreflight() bool {
+	return os.Getenv("INPUT_REGISTRY_PREFLIGHT") == "true"
+}
+
+// GetRegistryOwners returns the registry accounts expected to maintain published packages
+func GetRegistryOwners() []string {
+	return parseListInput(os.Getenv("INPUT_REGISTRY_OWNERS"))
+}
+
+// GetUnityPackageName returns the Unity Package Manager name (e.g. com.example.sdk) to publish C# SDKs under, if any
+func GetUnityPackageName() string {
+	return os.Getenv("INPUT_UNITY_PACKAGE_NAME")
+}
+
+// GetCocoaPodsTrunkToken returns the token to push Swift SDK releases to the CocoaPods trunk with
+func GetCocoaPodsTrunkToken() string {
+	return os.Getenv("INPUT_COCOAPODS_TRUNK_TOKEN")
+}
+
+func GetReleaseDiscussionCategory() string {
+	return os.Getenv("INPUT_RELEASE_DISCUSSION_CATEGORY")
+}
+
+func GetFromRelease() string {
+	return os.Getenv("INPUT_FROM_RELEASE")
+}
+
+func GetToRelease() string {
+	return os.Getenv("INPUT_TO_RELEASE")
+}
+
+func GetYankVersion() string {
+	return os.Getenv("INPUT_YANK_VERSION")
+}
+
+func GetYankReason() string {
+	if reason := os.Getenv("INPUT_YANK_REASON"); reason != "" {
+		return reason
+	}
+
+	return "This version has been withdrawn, please upgrade to a newer version"
+}
+
+func GetNPMToken() string {
+	return os.Getenv("INPUT_NPM_TOKEN")
+}
+
+func GetRubyGemsAPIKey() string {
+	return os.Getenv("INPUT_RUBYGEMS_API_KEY")
+}
+
+func GetGenerationCacheDirInput() string {
+	return os.Getenv("INPUT_GENERATION_CACHE_DIR")
+}
+
+// GetGenerationCacheDir returns the directory the CLI's cache is persisted to between runs, a relative
+// generation_cache_dir is resolved against the workspace so it is accessible outside of the action's container
+func GetGenerationCacheDir() string {
+	dir := GetGenerationCacheDirInput()
+	if dir == "" || filepath.IsAbs(dir) {
+		return dir
+	}
+
+	return filepath.Join(GetWorkspace(), dir)
+}
+
+type LanguageFailurePolicy string
+
+const (
+	// LanguageFailureFail aborts the run if any language fails to generate
+	LanguageFailureFail LanguageFailurePolicy = "fail"
+	// LanguageFailureContinue commits the languages that generated successfully then fails the run
+	LanguageFailureContinue LanguageFailurePolicy = "continue"
+	// LanguageFailureIsolate commits the languages that generated successfully and reports failures via the failed_targets output
+	LanguageFailureIsolate LanguageFailurePolicy = "isolate"
+)
+
+func GetLanguageFailurePolicy() LanguageFailurePolicy {
+	policy := os.Getenv("INPUT_ON_LANGUAGE_FAILURE")
+	if policy == "" {
+		return LanguageFailureFail
+	}
+
+	return LanguageFailurePolicy(policy)
+}
+
+type ReleaseNotesFormat string
+
+const (
+	// ReleaseNotesSpeakeasyDefault lists the generated targets and releases under a "Based on" summary of the generation
+	ReleaseNotesSpeakeasyDefault ReleaseNotesFormat = "speakeasy-default"
+	// ReleaseNotesConventional follows the layout of conventional-changelog, with the regeneration listed as a feature
+	ReleaseNotesConventional ReleaseNotesFormat = "conventional"
+	// ReleaseNotesKeepAChangelog follows the keepachangelog.com layout of Changed and Added sections
+	ReleaseNotesKeepAChangelog ReleaseNotesFormat = "keep-a-changelog"
+)
+
+// GetReleaseNotesTemplate returns the path relative to the repo of a Go template to render GitHub release bodies with
+func GetReleaseNotesTemplate() string {
+	return os.Getenv("INPUT_RELEASE_NOTES_TEMPLATE")
+}
+
+// GetReleaseNotesFormat returns the format of the release notes written to RELEASES.md and the GitHub release body
+func GetReleaseNotesFormat() ReleaseNotesFormat {
+	format := os.Getenv("INPUT_RELEASE_NOTES_FORMAT")
+	if format == "" {
+		return ReleaseNotesSpeakeasyDefault
+	}
+
+	return ReleaseNotesFormat(format)
+}
+
+type TagConflictStrategy string
+
+const (
+	// TagConflictFail fails the release when the SDK's release tag already exists at another commit
+	TagConflictFail TagConflictStrategy = "fail"
+	// TagConflictSkip skips releasing and publishing the SDK
+	TagConflictSkip TagConflictStrategy = "skip"
+	// TagConflictBumpPatch regenerates the SDK at the next patch version whose tag doesn't exist before it's committed
+	TagConflictBumpPatch TagConflictStrategy = "bump-patch"
+	// TagConflictOverwrite deletes the existing tag and release and recreates them at the released commit
+	TagConflictOverwrite TagConflictStrategy = "overwrite"
+)
+
+// GetTagConflictStrategy returns how to handle an SDK's release tag already existing at a commit other than the released one
+func GetTagConflictStrategy() TagConflictStrategy {
+	strategy := os.Getenv("INPUT_TAG_CONFLICT")
+	if strategy == "" {
+		return TagConflictFail
+	}
+
+	return TagConflictStrategy(strategy)
+}
+
+type ReleaseChannel string
+
+const (
+	// ReleaseChannelStable publishes releases to the packages' stable channels, e.g. the npm latest dist-tag and PyPI
+	ReleaseChannelStable ReleaseChannel = "stable"
+	// ReleaseChannelCanary publishes releases to early-access channels, i.e. the npm next dist-tag and TestPyPI, and marks
+	// their GitHub releases as pre-releases
+	ReleaseChannelCanary ReleaseChannel = "canary"
+)
+
+// NPMDistTag returns the npm dist-tag releases to the channel are published under
+func (c ReleaseChannel) NPMDistTag() string {
+	if c == ReleaseChannelCanary {
+		return "next"
+	}
+
+	return "latest"
+}
+
+// GetReleaseChannel returns the channel to publish releases to. Canary releases are promoted to the stable channel by
+// running with promote set.
+func GetReleaseChannel() ReleaseChannel {
+	if os.Getenv("INPUT_PROMOTE") == "true" {
+		return ReleaseChannelStable
+	}
+
+	channel := os.Getenv("INPUT_RELEASE_CHANNEL")
+	if channel == "" {
+		return ReleaseChannelStable
+	}
+
+	return ReleaseChannel(channel)
+}
+
+// IsResumable returns whether to checkpoint the run's outputs with the generated changes so re-runs can resume from them
+func IsResumable() bool {
+	return os.Getenv("INPUT_RESUMABLE") == "true"
+}
+
+// InitLFS returns whether to fetch Git LFS objects after cloning repos that use LFS
+func InitLFS() bool {
+	return os.Getenv("INPUT_LFS") != "false"
+}
+
+// InitSubmodules returns whether to initialize submodules after cloning repos that have them
+func InitSubmodules() bool {
+	return os.Getenv("INPUT_SUBMODULES") != "false"
+}
+
+// GoModTidy returns whether to tidy and vet generated Go modules before committing
+func GoModTidy() bool {
+	return os.Getenv("INPUT_GO_MOD_TIDY") != "false"
+}
+
+// RefreshLockfiles returns whether to re-resolve the dependencies pinned by the lockfiles of regenerated SDKs
+func RefreshLockfiles() bool {
+	return os.Getenv("INPUT_REFRESH_LOCKFILES") == "true"
+}
+
+// VerifyTypeScriptBuild returns whether to build generated TypeScript packages and check their exports before committing
+func VerifyTypeScriptBuild() bool {
+	return os.Getenv("INPUT_VERIFY_TYPESCRIPT_BUILD") == "true"
+}
+
+// GetPythonSmokeTestVersions returns the Python versions (e.g. 3.9) to smoke test generated Python packages with
+func GetPythonSmokeTestVersions() []string {
+	return parseListInput(os.Getenv("INPUT_PYTHON_SMOKE_TEST_VERSIONS"))
+}
+
+// ComposerValidate returns whether to validate generated PHP packages and check they're installable on their minimum PHP version
+func ComposerValidate() bool {
+	return os.Getenv("INPUT_COMPOSER_VALIDATE") == "true"
+}
+
+// VerifyExamples returns whether to check the usage examples generated into the docs of go and python SDKs compile
+func VerifyExamples() bool {
+	return os.Getenv("INPUT_VERIFY_EXAMPLES") == "true"
+}
+
+// GetMaxPackageGrowth returns the percentage a target's package size or dependency count can grow by in a regeneration
+// before generation fails, or 0 if growth only warns
+func GetMaxPackageGrowth() int {
+	maxGrowth, _ := strconv.Atoi(os.Getenv("INPUT_MAX_PACKAGE_GROWTH"))
+	return maxGrowth
+}
+
+// RunMockServerTests returns whether to run the generated tests of regenerated SDKs against a mock server of the API
+func RunMockServerTests() bool {
+	return os.Getenv("INPUT_MOCK_SERVER_TESTS") == "true"
+}
+
+// UpdateWorkspaces returns whether to add generated targets to the workspace manifests of monorepos
+func UpdateWorkspaces() bool {
+	return os.Getenv("INPUT_UPDATE_WORKSPACES") != "false"
+}
+
+// VerifyPushTarget returns whether to refuse pushing when HEAD isn't the branch that was cloned or checked out
+func VerifyPushTarget() bool {
+	return os.Getenv("INPUT_VERIFY_PUSH_TARGET") != "false"
+}
+
+// GetCommitCIDirective returns the directive appended to generation commit messages to control whether they trigger CI
+func GetCommitCIDirective() string {
+	switch directive := os.Getenv("INPUT_COMMIT_CI_DIRECTIVE"); directive {
+	case "skip-ci":
+		return "[skip ci]"
+	case "skip-actions":
+		return "[skip actions]"
+	default:
+		return directive
+	}
+}
+
+// TelemetryDisabled returns whether to skip sending usage telemetry to Speakeasy from both the action and the CLI
+func TelemetryDisabled() bool {
+	return os.Getenv("INPUT_DISABLE_TELEMETRY") == "true"
+}
+
+// GetOTLPEndpoint returns the OTLP/HTTP endpoint to export traces of the run to
+func GetOTLPEndpoint() string {
+	return os.Getenv("INPUT_OTLP_ENDPOINT")
+}
+
+// GetTraceparent returns the W3C traceparent of the trace the run's spans should be parented to
+func GetTraceparent() string {
+	return os.Getenv("INPUT_TRACEPARENT")
+}
+
+// GetCatalogInfoPath returns the path relative to the repo of the Backstage catalog-info file to update the Speakeasy
+// annotations of, or an empty string if it isn't updated
+func GetCatalogInfoPath() string {
+	return os.Getenv("INPUT_CATALOG_INFO")
+}
+
+// GetStatusEndpoint returns the URL to post the run's status document to
+func GetStatusEndpoint() string {
+	return os.Getenv("INPUT_STATUS_ENDPOINT")
+}
+
+// GetStatusEndpointToken returns the bearer token to authenticate posting the status document with
+func GetStatusEndpointToken() string {
+	return os.Getenv("INPUT_STATUS_ENDPOINT_TOKEN")
+}
+
+// GetStatsDAddress returns the host:port of the StatsD server to push phase durations to
+func GetStatsDAddress() string {
+	return os.Getenv("INPUT_STATSD_ADDRESS")
+}
+
 func GetRef() string {
+	if ref := os.Getenv("INPUT_REF"); ref != "" {
+		return ref
+	}
+
 	// handle pr based action triggers
 	if strings.Contains(os.Getenv("GITHUB_REF"), "refs/pull") || strings.Contains(os.Getenv("GITHUB_REF"), "refs/pulls") {
 		return os.Getenv("GITHUB_BASE_REF")
@@ -253,6 +1074,15 @@ func GetRepo() string {
 	return os.Getenv("GITHUB_REPOSITORY")
 }
 
+func GetRunID() string {
+	return os.Getenv("GITHUB_RUN_ID")
+}
+
+// GetSHA returns the commit the workflow run was triggered for, which re-runs of the run share
+func GetSHA() string {
+	return os.Getenv("GITHUB_SHA")
+}
+
 func GetGithubServerURL() string {
 	return os.Getenv("GITHUB_SERVER_URL")
 }
@@ -277,6 +1107,21 @@ func SetCLIVersionToUse(version string) error {
 	return os.Setenv("PINNED_VERSION", version)
 }
 
+// GetCLIVersionToUse returns the CLI version set to execute `run` with, or an empty string for the latest version
+func GetCLIVersionToUse() string {
+	return os.Getenv("PINNED_VERSION")
+}
+
+// SetVersionOverride sets the version the SDKs are generated at, in place of the set_version input
+func SetVersionOverride(version string) error {
+	return os.Setenv("INPUT_SET_VERSION", version)
+}
+
+// GetGraduateVersion returns the stable version to graduate the SDKs to in the graduate action
+func GetGraduateVersion() string {
+	return os.Getenv("INPUT_GRADUATE_VERSION")
+}
+
 func parseArrayInput(input string) []string {
 	if input == "" {
 		return []string{}
@@ -288,3 +1133,15 @@ func parseArrayInput(input string) []string {
 
 	return strings.Split(input, ",")
 }
+
+// parseListInput parses an array input, ignoring surrounding whitespace and empty items
+func parseListInput(input string) []string {
+	items := []string{}
+	for _, item := range parseArrayInput(input) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,39 @@
+package environment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMinReleaseInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval string
+		want     time.Duration
+		wantErr  bool
+	}{
+		{name: "unset", interval: ""},
+		{name: "duration", interval: "12h", want: 12 * time.Hour},
+		{name: "days", interval: "7d", want: 7 * 24 * time.Hour},
+		{name: "zero days", interval: "0d"},
+		{name: "negative days", interval: "-1d", wantErr: true},
+		{name: "fractional days", interval: "1.5d", wantErr: true},
+		{name: "invalid", interval: "weekly", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("INPUT_MIN_RELEASE_INTERVAL", tt.interval)
+
+			got, err := GetMinReleaseInterval()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package environment
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/speakeasy-api/sdk-generation-action/internal/utils"
+)
+
+var (
+	validModes   = []Mode{ModeDirect, ModePR, ModeTest}
+	validActions = []Action{ActionValidate, ActionRunWorkflow, ActionSuggest, ActionFinalize, ActionFinalizeSuggestion, ActionRelease, ActionLog, ActionPublishEvent, ActionTag, ActionChangelog, ActionYank, ActionGraduate}
+
+	booleanInputs = []string{"force", "push_code_samples_only", "output_tests", "create_check_run", "track_deployments", "debug", "dry_run", "digest_issue", "disable_telemetry", "resumable", "verify_push_target", "lfs", "submodules", "update_workspaces", "go_mod_tidy", "verify_typescript_build", "composer_validate", "registry_preflight", "pre_1_0_semver", "mock_server_tests", "verify_examples", "spec_checksum_refs", "housekeeping", "promote", "refresh_lockfiles", "prune_orphaned_files", "wait_for_checks", "release_after_publish"}
+	integerInputs = []string{"max_suggestions", "max_validation_warnings", "max_validation_errors", "max_package_growth", "stale_branch_days"}
+
+	validVersionBumps            = []string{"major", "minor", "patch", "graduate", "prerelease"}
+	validVersionBumpLimits       = []string{"major", "minor", "patch"}
+	validLanguageFailurePolicies = []LanguageFailurePolicy{LanguageFailureFail, LanguageFailureContinue, LanguageFailureIsolate}
+	validCLIFeaturePolicies      = []CLIFeaturePolicy{CLIFeaturePolicyWarn, CLIFeaturePolicyFail}
+	validSemverCheckPolicies     = []SemverCheckPolicy{SemverCheckOff, SemverCheckWarn, SemverCheckFail, SemverCheckEscalate}
+	validSpecChecksumAlgorithms  = []SpecChecksumAlgorithm{SpecChecksumETag, SpecChecksumSHA256}
+	validDocVersionComparisons   = []DocVersionComparison{DocVersionSemver, DocVersionLexicographic, DocVersionDate, DocVersionChecksum}
+	validReleaseNotesFormats     = []ReleaseNotesFormat{ReleaseNotesSpeakeasyDefault, ReleaseNotesConventional, ReleaseNotesKeepAChangelog}
+	validTagConflictStrategies   = []TagConflictStrategy{TagConflictFail, TagConflictSkip, TagConflictBumpPatch, TagConflictOverwrite}
+	validMajorBumpApprovals      = []MajorBumpApproval{MajorBumpApprovalOff, MajorBumpApprovalIssue, MajorBumpApprovalEnvironment}
+	validReleaseChannels         = []ReleaseChannel{ReleaseChannelStable, ReleaseChannelCanary}
+	validCleanOutputPolicies     = []CleanOutputPolicy{CleanOutputOff, CleanOutputGenerated, CleanOutputAll}
+	validCommitMethods           = []CommitMethod{CommitMethodGit, CommitMethodAPI}
+)
+
+// ValidateInputs checks the action's inputs up front, returning a single error listing every invalid input
+func ValidateInputs() error {
+	problems := []string{}
+
+	for _, name := range booleanInputs {
+		if value := getInput(name); value != "" && value != "true" && value != "false" {
+			problems = append(problems, fmt.Sprintf("%s must be true or false, got %q", name, value))
+		}
+	}
+
+	for _, name := range integerInputs {
+		if value := getInput(name); value != "" {
+			if i, err := strconv.Atoi(value); err != nil || i < 0 {
+				problems = append(problems, fmt.Sprintf("%s must be a non-negative integer, got %q", name, value))
+			}
+		}
+	}
+
+	if mode := getInput("mode"); mode != "" && !slices.Contains(validModes, Mode(mode)) {
+		problems = append(problems, fmt.Sprintf("mode must be one of %s, got %q", joinValues(validModes), mode))
+	}
+
+	action := getInput("action")
+	if action != "" && !slices.Contains(validActions, Action(action)) {
+		problems = append(problems, fmt.Sprintf("action must be one of %s, got %q", joinValues(validActions), action))
+	}
+
+	if policy := getInput("on_language_failure"); policy != "" && !slices.Contains(validLanguageFailurePolicies, LanguageFailurePolicy(policy)) {
+		problems = append(problems, fmt.Sprintf("on_language_failure must be one of %s, got %q", joinValues(validLanguageFailurePolicies), policy))
+	}
+
+	if policy := getInput("cli_feature_policy"); policy != "" && !slices.Contains(validCLIFeaturePolicies, CLIFeaturePolicy(policy)) {
+		problems = append(problems, fmt.Sprintf("cli_feature_policy must be one of %s, got %q", joinValues(validCLIFeaturePolicies), policy))
+	}
+
+	if policy := getInput("semver_check"); policy != "" && !slices.Contains(validSemverCheckPolicies, SemverCheckPolicy(policy)) {
+		problems = append(problems, fmt.Sprintf("semver_check must be one of %s, got %q", joinValues(validSemverCheckPolicies), policy))
+	}
+
+	algorithm := getInput("spec_checksum_algorithm")
+	if algorithm != "" && !slices.Contains(validSpecChecksumAlgorithms, SpecChecksumAlgorithm(algorithm)) {
+		problems = append(problems, fmt.Sprintf("spec_checksum_algorithm must be one of %s, got %q", joinValues(validSpecChecksumAlgorithms), algorithm))
+	}
+
+	if format := getInput("release_notes_format"); format != "" && !slices.Contains(validReleaseNotesFormats, ReleaseNotesFormat(format)) {
+		problems = append(problems, fmt.Sprintf("release_notes_format must be one of %s, got %q", joinValues(validReleaseNotesFormats), format))
+	}
+
+	if strategy := getInput("tag_conflict"); strategy != "" && !slices.Contains(validTagConflictStrategies, TagConflictStrategy(strategy)) {
+		problems = append(problems, fmt.Sprintf("tag_conflict must be one of %s, got %q", joinValues(validTagConflictStrategies), strategy))
+	}
+
+	if approval := getInput("major_bump_approval"); approval != "" && !slices.Contains(validMajorBumpApprovals, MajorBumpApproval(approval)) {
+		problems = append(problems, fmt.Sprintf("major_bump_approval must be one of %s, got %q", joinValues(validMajorBumpApprovals), approval))
+	}
+
+	if channel := getInput("release_channel"); channel != "" && !slices.Contains(validReleaseChannels, ReleaseChannel(channel)) {
+		problems = append(problems, fmt.Sprintf("release_channel must be one of %s, got %q", joinValues(validReleaseChannels), channel))
+	}
+
+	if policy := getInput("clean_output"); policy != "" && !slices.Contains(validCleanOutputPolicies, CleanOutputPolicy(policy)) {
+		problems = append(problems, fmt.Sprintf("clean_output must be one of %s, got %q", joinValues(validCleanOutputPolicies), policy))
+	}
+
+	if method := getInput("commit_method"); method != "" && !slices.Contains(validCommitMethods, CommitMethod(method)) {
+		problems = append(problems, fmt.Sprintf("commit_method must be one of %s, got %q", joinValues(validCommitMethods), method))
+	}
+
+	if bump := GetVersionBump(); bump != "" && !slices.Contains(validVersionBumps, bump) {
+		problems = append(problems, fmt.Sprintf("version_bump must be one of %s, got %q", joinValues(validVersionBumps), bump))
+	}
+	if GetVersionBump() != "" && SetVersion() != "" {
+		problems = append(problems, "version_bump and set_version cannot be used together")
+	}
+
+	maxBump, minBump := GetMaxAutomaticVersionBump(), GetMinSpecChangeVersionBump()
+	if maxBump != "" && !slices.Contains(validVersionBumpLimits, maxBump) {
+		problems = append(problems, fmt.Sprintf("max_automatic_version_bump must be one of %s, got %q", joinValues(validVersionBumpLimits), maxBump))
+	}
+	if minBump != "" && !slices.Contains(validVersionBumpLimits, minBump) {
+		problems = append(problems, fmt.Sprintf("min_spec_change_version_bump must be one of %s, got %q", joinValues(validVersionBumpLimits), minBump))
+	}
+	if maxBump != "" && minBump != "" && slices.Index(validVersionBumpLimits, minBump) < slices.Index(validVersionBumpLimits, maxBump) {
+		problems = append(problems, fmt.Sprintf("min_spec_change_version_bump %q exceeds max_automatic_version_bump %q", minBump, maxBump))
+	}
+
+	overrides, err := parseLanguageOverrides(getInput("language_overrides"))
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("language_overrides must be a JSON object of languages to installation_url, published, package_name, initial_version and speakeasy_version overrides: %s", err))
+	}
+	for _, lang := range slices.Sorted(maps.Keys(overrides)) {
+		if packageName := overrides[lang].PackageName; packageName != nil {
+			if err := utils.ValidatePackageName(lang, *packageName); err != nil {
+				problems = append(problems, fmt.Sprintf("language_overrides has an invalid package_name: %s", err))
+			}
+		}
+		if speakeasyVersion := overrides[lang].SpeakeasyVersion; speakeasyVersion != nil && *speakeasyVersion != "latest" {
+			if _, err := version.NewSemver(*speakeasyVersion); err != nil {
+				problems = append(problems, fmt.Sprintf("language_overrides has an invalid %s speakeasy_version %q: %s", lang, *speakeasyVersion, err))
+			}
+		}
+		if initialVersion := overrides[lang].InitialVersion; initialVersion != nil {
+			if _, err := version.NewSemver(*initialVersion); err != nil {
+				problems = append(problems, fmt.Sprintf("language_overrides has an invalid %s initial_version %q: %s", lang, *initialVersion, err))
+			}
+		}
+	}
+
+	if _, err := parseWebhookTargets(getInput("webhook_targets")); err != nil {
+		problems = append(problems, fmt.Sprintf("webhook_targets must be a JSON object of webhook target IDs to the IDs of the SDK targets they are released with: %s", err))
+	}
+
+	if _, err := parseSpecEnvironments(getInput("spec_environments")); err != nil {
+		problems = append(problems, fmt.Sprintf("spec_environments must be a JSON object of environment names to their workflow source and npm dist-tag: %s", err))
+	}
+
+	if _, err := parseStepHooks(getInput("step_hooks")); err != nil {
+		problems = append(problems, fmt.Sprintf("step_hooks must be a JSON object of hook points to shell commands: %s", err))
+	}
+
+	if _, err := GetMinReleaseInterval(); err != nil {
+		problems = append(problems, fmt.Sprintf("min_release_interval must be a duration, e.g. 12h, or a number of days, e.g. 7d: %s", err))
+	}
+
+	if _, err := GetWaitForChecksTimeout(); err != nil {
+		problems = append(problems, fmt.Sprintf("wait_for_checks_timeout must be a duration, e.g. 30m: %s", err))
+	}
+
+	if _, err := GetGeneratorUpgradeInterval(); err != nil {
+		problems = append(problems, fmt.Sprintf("generator_upgrade_interval must be a duration, e.g. 168h, or a number of days, e.g. 7d: %s", err))
+	}
+
+	if _, err := GetReleaseFreezeWindows(); err != nil {
+		problems = append(problems, fmt.Sprintf("release_freeze must be a list of weekly windows or date ranges in release_freeze_timezone: %s", err))
+	}
+
+	if GetOpenAPIDocContent() != "" && GetOpenAPIDocPath() != "" {
+		problems = append(problems, "openapi_doc_content and openapi_doc_path cannot be used together")
+	}
+	if comparison := getInput("openapi_doc_version_comparison"); comparison != "" && !slices.Contains(validDocVersionComparisons, DocVersionComparison(comparison)) {
+		problems = append(problems, fmt.Sprintf("openapi_doc_version_comparison must be one of %s, got %q", joinValues(validDocVersionComparisons), comparison))
+	}
+	if docVersion := GetOpenAPIDocVersionOverride(); docVersion != "" && GetDocVersionComparison() == DocVersionSemver {
+		if _, err := version.NewSemver(docVersion); err != nil {
+			problems = append(problems, fmt.Sprintf("openapi_doc_version_override must be a semver version, got %q", docVersion))
+		}
+	}
+
+	if UpdateDigestIssue() && !IsDryRun() {
+		problems = append(problems, "digest_issue can only be used with dry_run")
+	}
+
+	if GetAccessToken() == "" && GetAction() != ActionLog {
+		problems = append(problems, "github_access_token is required")
+	}
+
+	if setVersion := SetVersion(); setVersion != "" {
+		if _, err := version.NewSemver(setVersion); err != nil {
+			problems = append(problems, fmt.Sprintf("set_version must be a semantic version, got %q", setVersion))
+		}
+		if PushCodeSamplesOnly() {
+			problems = append(problems, "set_version cannot be used with push_code_samples_only as no SDK is generated")
+		}
+	}
+
+	if GetAction() == ActionChangelog && GetFromRelease() == "" {
+		problems = append(problems, "from_release is required for the changelog action")
+	}
+	if GetAction() != ActionChangelog && (GetFromRelease() != "" || GetToRelease() != "") {
+		problems = append(problems, "from_release and to_release can only be used with the changelog action")
+	}
+
+	if GetAction() == ActionYank {
+		if yankVersion := GetYankVersion(); yankVersion == "" {
+			problems = append(problems, "yank_version is required for the yank action")
+		} else if _, err := version.NewSemver(yankVersion); err != nil {
+			problems = append(problems, fmt.Sprintf("yank_version must be a semantic version, got %q", yankVersion))
+		}
+	}
+
+	if graduateVersion := GetGraduateVersion(); graduateVersion != "" {
+		if v, err := version.NewSemver(graduateVersion); err != nil || v.Prerelease() != "" {
+			problems = append(problems, fmt.Sprintf("graduate_version must be a stable semantic version, got %q", graduateVersion))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("invalid action inputs:\n  - %s", strings.Join(problems, "\n  - "))
+}
+
+func getInput(name string) string {
+	return os.Getenv("INPUT_" + strings.ToUpper(name))
+}
+
+func joinValues[T ~string](values []T) string {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = string(v)
+	}
+	return strings.Join(strs, ", ")
+}
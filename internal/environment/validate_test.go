@@ -0,0 +1,224 @@
+package environment
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateInputs(t *testing.T) {
+	tests := []struct {
+		name     string
+		inputs   map[string]string
+		wantErrs []string
+	}{
+		{
+			name: "valid inputs",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN": "token",
+				"INPUT_MODE":                "pr",
+				"INPUT_FORCE":               "true",
+				"INPUT_SET_VERSION":         "1.2.3",
+			},
+		},
+		{
+			name: "log-result doesn't require a token",
+			inputs: map[string]string{
+				"INPUT_ACTION": "log-result",
+			},
+		},
+		{
+			name: "reports every problem",
+			inputs: map[string]string{
+				"INPUT_MODE":                   "preview",
+				"INPUT_FORCE":                  "yes",
+				"INPUT_MAX_SUGGESTIONS":        "-1",
+				"INPUT_SET_VERSION":            "latest",
+				"INPUT_PUSH_CODE_SAMPLES_ONLY": "true",
+				"INPUT_TO_RELEASE":             "v1.0.0",
+			},
+			wantErrs: []string{
+				`force must be true or false, got "yes"`,
+				`max_suggestions must be a non-negative integer, got "-1"`,
+				`mode must be one of direct, pr, test, got "preview"`,
+				"github_access_token is required",
+				`set_version must be a semantic version, got "latest"`,
+				"set_version cannot be used with push_code_samples_only",
+				"from_release and to_release can only be used with the changelog action",
+			},
+		},
+		{
+			name: "changelog requires from_release",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN": "token",
+				"INPUT_ACTION":              "changelog",
+			},
+			wantErrs: []string{"from_release is required for the changelog action"},
+		},
+		{
+			name: "yank requires a version",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN": "token",
+				"INPUT_ACTION":              "yank",
+			},
+			wantErrs: []string{"yank_version is required for the yank action"},
+		},
+		{
+			name: "language overrides must be JSON",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN": "token",
+				"INPUT_LANGUAGE_OVERRIDES":  "python: published",
+			},
+			wantErrs: []string{"language_overrides must be a JSON object"},
+		},
+		{
+			name: "initial versions must be semver",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN": "token",
+				"INPUT_LANGUAGE_OVERRIDES":  `{"python": {"initial_version": "beta"}}`,
+			},
+			wantErrs: []string{`language_overrides has an invalid python initial_version "beta"`},
+		},
+		{
+			name: "minimum bump can't exceed the maximum",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN":          "token",
+				"INPUT_MAX_AUTOMATIC_VERSION_BUMP":   "minor",
+				"INPUT_MIN_SPEC_CHANGE_VERSION_BUMP": "major",
+			},
+			wantErrs: []string{`min_spec_change_version_bump "major" exceeds max_automatic_version_bump "minor"`},
+		},
+		{
+			name: "inline document content and path are exclusive",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN": "token",
+				"INPUT_OPENAPI_DOC_CONTENT": "openapi: 3.1.0",
+				"INPUT_OPENAPI_DOC_PATH":    "openapi.yaml",
+			},
+			wantErrs: []string{"openapi_doc_content and openapi_doc_path cannot be used together"},
+		},
+		{
+			name: "doc version override must be semver",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN":          "token",
+				"INPUT_OPENAPI_DOC_VERSION_OVERRIDE": "latest",
+			},
+			wantErrs: []string{`openapi_doc_version_override must be a semver version, got "latest"`},
+		},
+		{
+			name: "step hooks must name a hook point",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN": "token",
+				"INPUT_STEP_HOOKS":          `{"generate": "./validate.sh"}`,
+			},
+			wantErrs: []string{`step_hooks must be a JSON object of hook points to shell commands: invalid hook point "generate"`},
+		},
+		{
+			name: "spec environments must have separate sources",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN": "token",
+				"INPUT_SPEC_ENVIRONMENTS":   `{"staging": {"source": "api"}, "production": {"source": "api"}}`,
+			},
+			wantErrs: []string{"spec_environments must be a JSON object of environment names to their workflow source and npm dist-tag: environments", "share the source api"},
+		},
+		{
+			name: "tag conflict strategy must be known",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN": "token",
+				"INPUT_TAG_CONFLICT":        "retag",
+			},
+			wantErrs: []string{`tag_conflict must be one of fail, skip, bump-patch, overwrite, got "retag"`},
+		},
+		{
+			name: "major bump approval must be known",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN": "token",
+				"INPUT_MAJOR_BUMP_APPROVAL": "review",
+			},
+			wantErrs: []string{`major_bump_approval must be one of off, issue, environment, got "review"`},
+		},
+		{
+			name: "release channel must be known",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN": "token",
+				"INPUT_RELEASE_CHANNEL":     "beta",
+			},
+			wantErrs: []string{`release_channel must be one of stable, canary, got "beta"`},
+		},
+		{
+			name: "clean output policy must be known",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN": "token",
+				"INPUT_CLEAN_OUTPUT":        "true",
+			},
+			wantErrs: []string{`clean_output must be one of off, generated, all, got "true"`},
+		},
+		{
+			name: "commit method must be known",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN": "token",
+				"INPUT_COMMIT_METHOD":       "graphql",
+			},
+			wantErrs: []string{`commit_method must be one of git, api, got "graphql"`},
+		},
+		{
+			name: "min release interval must be a duration",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN":  "token",
+				"INPUT_MIN_RELEASE_INTERVAL": "weekly",
+			},
+			wantErrs: []string{`min_release_interval must be a duration, e.g. 12h, or a number of days, e.g. 7d: invalid duration "weekly"`},
+		},
+		{
+			name: "wait for checks timeout must be a duration",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN":     "token",
+				"INPUT_WAIT_FOR_CHECKS_TIMEOUT": "half an hour",
+			},
+			wantErrs: []string{`wait_for_checks_timeout must be a duration, e.g. 30m: invalid duration "half an hour"`},
+		},
+		{
+			name: "generator upgrade interval must be a duration",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN":        "token",
+				"INPUT_GENERATOR_UPGRADE_INTERVAL": "-1d",
+			},
+			wantErrs: []string{`generator_upgrade_interval must be a duration, e.g. 168h, or a number of days, e.g. 7d: invalid number of days "-1"`},
+		},
+		{
+			name: "release freeze windows must be valid",
+			inputs: map[string]string{
+				"INPUT_GITHUB_ACCESS_TOKEN":     "token",
+				"INPUT_RELEASE_FREEZE":          "Fri 18:00-Mon 08:00\nchristmas",
+				"INPUT_RELEASE_FREEZE_TIMEZONE": "Europe/London",
+			},
+			wantErrs: []string{`release_freeze must be a list of weekly windows or date ranges in release_freeze_timezone: invalid freeze window "christmas"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names := []string{"mode", "action", "github_access_token", "set_version", "from_release", "to_release", "version_bump", "language_overrides", "yank_version", "max_automatic_version_bump", "min_spec_change_version_bump", "openapi_doc_content", "openapi_doc_path", "openapi_doc_version_override", "openapi_doc_version_comparison", "semver_check", "spec_checksum_algorithm", "webhook_targets", "step_hooks", "tag_conflict", "major_bump_approval", "release_freeze", "release_freeze_timezone", "min_release_interval", "release_channel", "spec_environments", "generator_upgrade_interval", "clean_output", "commit_method", "wait_for_checks_timeout"}
+			names = append(names, booleanInputs...)
+			names = append(names, integerInputs...)
+			for _, name := range names {
+				t.Setenv("INPUT_"+strings.ToUpper(name), "")
+			}
+			for k, v := range tt.inputs {
+				t.Setenv(k, v)
+			}
+
+			err := ValidateInputs()
+			if len(tt.wantErrs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, want := range tt.wantErrs {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Package freeze parses the windows during which releases are frozen, e.g. over weekends or holidays
+package freeze
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Window is a period during which releases are frozen
+type Window interface {
+	// Contains returns whether releases are frozen at the time
+	Contains(t time.Time) bool
+	// End returns when the freeze containing the time ends
+	End(t time.Time) time.Time
+}
+
+// Parse parses freeze windows in the location's time zone. Windows are either weekly, e.g. "Fri 18:00-Mon 08:00", or
+// inclusive date ranges, e.g. "2024-12-20..2025-01-02" or "2024-12-25".
+func Parse(specs []string, loc *time.Location) ([]Window, error) {
+	windows := make([]Window, 0, len(specs))
+	for _, spec := range specs {
+		var window Window
+		var err error
+		if strings.Contains(spec, "..") || !strings.Contains(spec, " ") {
+			window, err = parseDates(spec, loc)
+		} else {
+			window, err = parseWeekly(spec, loc)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid freeze window %q: %w", spec, err)
+		}
+		windows = append(windows, window)
+	}
+
+	return windows, nil
+}
+
+// Frozen returns whether releases are frozen at the time and, if they are, when the freeze ends, following on to
+// overlapping windows
+func Frozen(windows []Window, t time.Time) (bool, time.Time) {
+	end := t
+	// Bounded so windows covering all time can't loop forever
+	for i := 0; i < 100; i++ {
+		extended := false
+		for _, window := range windows {
+			if window.Contains(end) {
+				end = window.End(end)
+				extended = true
+			}
+		}
+		if !extended {
+			break
+		}
+	}
+
+	return !end.Equal(t), end
+}
+
+// weekly is a window repeating every week, from start to end as offsets from the start of Sunday
+type weekly struct {
+	start, end time.Duration
+	loc        *time.Location
+}
+
+func parseWeekly(spec string, loc *time.Location) (Window, error) {
+	from, to, ok := strings.Cut(spec, "-")
+	if !ok {
+		return nil, fmt.Errorf(`expected "<day> <hh:mm>-<day> <hh:mm>"`)
+	}
+
+	start, err := parseWeekTime(from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseWeekTime(to)
+	if err != nil {
+		return nil, err
+	}
+	if start == end {
+		return nil, fmt.Errorf("the window is empty")
+	}
+
+	return weekly{start: start, end: end, loc: loc}, nil
+}
+
+func parseWeekTime(s string) (time.Duration, error) {
+	day, clock, ok := strings.Cut(strings.TrimSpace(s), " ")
+	if !ok {
+		return 0, fmt.Errorf(`expected "<day> <hh:mm>", got %q`, s)
+	}
+
+	weekday := -1
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(day, d.String()) || strings.EqualFold(day, d.String()[:3]) {
+			weekday = int(d)
+		}
+	}
+	if weekday == -1 {
+		return 0, fmt.Errorf("unknown day %q", day)
+	}
+
+	t, err := time.Parse("15:04", strings.TrimSpace(clock))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected hh:mm", clock)
+	}
+
+	return time.Duration(weekday)*24*time.Hour + time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// sinceWeekStart returns the wall clock time since the start of the Sunday of the time's week
+func (w weekly) sinceWeekStart(t time.Time) time.Duration {
+	t = t.In(w.loc)
+
+	return time.Duration(t.Weekday())*24*time.Hour + time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+}
+
+func (w weekly) Contains(t time.Time) bool {
+	offset := w.sinceWeekStart(t)
+	if w.start < w.end {
+		return offset >= w.start && offset < w.end
+	}
+
+	// The window wraps around the end of the week
+	return offset >= w.start || offset < w.end
+}
+
+func (w weekly) End(t time.Time) time.Time {
+	days := int(w.end/(24*time.Hour)) - int(t.In(w.loc).Weekday())
+	if w.sinceWeekStart(t) >= w.end {
+		days += 7
+	}
+	clock := w.end % (24 * time.Hour)
+
+	t = t.In(w.loc)
+	return time.Date(t.Year(), t.Month(), t.Day()+days, int(clock/time.Hour), int(clock%time.Hour/time.Minute), 0, 0, w.loc)
+}
+
+// dates is a window from the start of the first date until the end of the last
+type dates struct {
+	first, last time.Time
+}
+
+func parseDates(spec string, loc *time.Location) (Window, error) {
+	from, to, ok := strings.Cut(spec, "..")
+	if !ok {
+		to = from
+	}
+
+	first, err := time.ParseInLocation(time.DateOnly, strings.TrimSpace(from), loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", from)
+	}
+	last, err := time.ParseInLocation(time.DateOnly, strings.TrimSpace(to), loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", to)
+	}
+	if last.Before(first) {
+		return nil, fmt.Errorf("%s is before %s", to, from)
+	}
+
+	return dates{first: first, last: last}, nil
+}
+
+func (d dates) Contains(t time.Time) bool {
+	return !t.Before(d.first) && t.Before(d.last.AddDate(0, 0, 1))
+}
+
+func (d dates) End(time.Time) time.Time {
+	return d.last.AddDate(0, 0, 1)
+}
@@ -0,0 +1,62 @@
+package freeze
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrozen(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	windows, err := Parse([]string{"Fri 18:00-Mon 08:00", "2024-12-23..2024-12-26", "2025-01-01"}, loc)
+	require.NoError(t, err)
+
+	at := func(value string) time.Time {
+		parsed, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+		require.NoError(t, err)
+		return parsed
+	}
+
+	tests := []struct {
+		name       string
+		time       time.Time
+		wantFrozen bool
+		wantEnd    time.Time
+	}{
+		{name: "weekday", time: at("2024-11-13 12:00")},
+		{name: "friday before the freeze", time: at("2024-11-15 17:59")},
+		{name: "friday evening", time: at("2024-11-15 18:00"), wantFrozen: true, wantEnd: at("2024-11-18 08:00")},
+		{name: "sunday", time: at("2024-11-17 10:00"), wantFrozen: true, wantEnd: at("2024-11-18 08:00")},
+		{name: "monday after the freeze", time: at("2024-11-18 08:00")},
+		{name: "weekend followed by dates", time: at("2024-12-21 10:00"), wantFrozen: true, wantEnd: at("2024-12-27 00:00")},
+		{name: "single date", time: at("2025-01-01 23:59"), wantFrozen: true, wantEnd: at("2025-01-02 00:00")},
+		{name: "other time zone", time: time.Date(2024, 11, 15, 22, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frozen, end := Frozen(windows, tt.time)
+			assert.Equal(t, tt.wantFrozen, frozen)
+			if tt.wantFrozen {
+				assert.True(t, tt.wantEnd.Equal(end), "want %s, got %s", tt.wantEnd, end)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for spec, want := range map[string]string{
+		"Fri 18:00":              `expected "<day> <hh:mm>-<day> <hh:mm>"`,
+		"Fry 18:00-Mon 08:00":    `unknown day "Fry"`,
+		"Fri 6pm-Mon 08:00":      `invalid time "6pm", expected hh:mm`,
+		"Fri 18:00-Fri 18:00":    "the window is empty",
+		"2024-12-26..2024-12-23": "2024-12-23 is before 2024-12-26",
+		"christmas":              `invalid date "christmas", expected YYYY-MM-DD`,
+	} {
+		_, err := Parse([]string{spec}, time.UTC)
+		assert.ErrorContains(t, err, want, spec)
+	}
+}
@@ -0,0 +1,58 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// pushThroughAPI recreates the local commit on the branch through the host's API, which signs it, then resets the local
+// branch to it. It returns false when the host can't create commits, to push them with git instead.
+func (g *Git) pushThroughAPI(branchName string, hash plumbing.Hash) (string, bool, error) {
+	created, err := g.host.CreateCommit(g.repo, branchName, hash)
+	if errors.Is(err, ci.ErrUnsupported) {
+		logging.Info("Commits can't be created through the API of the host, pushing with git")
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	if err := g.resetToRemoteCommit(branchName, plumbing.NewHash(created)); err != nil {
+		return "", false, err
+	}
+
+	return created, true, nil
+}
+
+// resetToRemoteCommit fetches the branch and resets it to the commit created through the API, which has the same tree
+// as the local commit it replaces
+func (g *Git) resetToRemoteCommit(branchName string, hash plumbing.Hash) error {
+	remote, err := g.repo.Remote("origin")
+	if err != nil {
+		return fmt.Errorf("error getting remote: %w", err)
+	}
+	if err := remote.Fetch(&git.FetchOptions{
+		Auth: g.auth(),
+		RefSpecs: []config.RefSpec{
+			config.RefSpec(fmt.Sprintf("+refs/heads/%s:refs/remotes/origin/%s", branchName, branchName)),
+		},
+	}); err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("error fetching branch %s: %w", branchName, err)
+	}
+
+	w, err := g.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("error getting worktree: %w", err)
+	}
+	if err := w.Reset(&git.ResetOptions{Commit: hash, Mode: git.HardReset}); err != nil {
+		return fmt.Errorf("error resetting branch %s to %s: %w", branchName, hash, err)
+	}
+
+	return nil
+}
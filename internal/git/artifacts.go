@@ -0,0 +1,128 @@
+package git
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/speakeasy-api/sdk-generation-action/internal/download"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// DownloadArtifactFile downloads a file from the workflow run artifact at an artifact:// location (see
+// download.ParseArtifactURL) to outPath
+func (g *Git) DownloadArtifactFile(location, outPath string) error {
+	loc, err := download.ParseArtifactURL(location)
+	if err != nil {
+		return err
+	}
+
+	artifact, err := g.findArtifact(loc)
+	if err != nil {
+		return err
+	}
+
+	logging.Info("Downloading artifact %s uploaded by workflow run %d of %s/%s", loc.Name, artifact.GetWorkflowRun().GetID(), loc.Owner, loc.Repo)
+
+	u, _, err := g.client.Actions.DownloadArtifact(context.Background(), loc.Owner, loc.Repo, artifact.GetID(), 10)
+	if err != nil {
+		return fmt.Errorf("failed to get download url of artifact %s: %w", loc.Name, err)
+	}
+
+	res, err := http.Get(u.String())
+	if err != nil {
+		return fmt.Errorf("failed to download artifact %s: %w", loc.Name, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to download artifact %s: %s", loc.Name, res.Status)
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to download artifact %s: %w", loc.Name, err)
+	}
+
+	contents, err := readArtifactFile(data, loc.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read artifact %s: %w", loc.Name, err)
+	}
+
+	if err := os.WriteFile(outPath, contents, 0o644); err != nil {
+		return fmt.Errorf("failed to write artifact file: %w", err)
+	}
+
+	return nil
+}
+
+// findArtifact finds the artifact with the name uploaded by the run, or the most recent one when no run is given
+func (g *Git) findArtifact(loc *download.ArtifactLocation) (*github.Artifact, error) {
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		var artifacts *github.ArtifactList
+		var res *github.Response
+		var err error
+		if loc.RunID != 0 {
+			artifacts, res, err = g.client.Actions.ListWorkflowRunArtifacts(context.Background(), loc.Owner, loc.Repo, loc.RunID, opts)
+		} else {
+			artifacts, res, err = g.client.Actions.ListArtifacts(context.Background(), loc.Owner, loc.Repo, opts)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list artifacts of %s/%s: %w", loc.Owner, loc.Repo, err)
+		}
+
+		// Artifacts are listed most recent first
+		for _, artifact := range artifacts.Artifacts {
+			if artifact.GetName() == loc.Name && !artifact.GetExpired() {
+				return artifact, nil
+			}
+		}
+
+		if res.NextPage == 0 {
+			return nil, fmt.Errorf("artifact %s not found in %s/%s", loc.Name, loc.Owner, loc.Repo)
+		}
+		opts.Page = res.NextPage
+	}
+}
+
+// readArtifactFile reads the file at filePath from the zipped artifact, or its only file if no path is given
+func readArtifactFile(data []byte, filePath string) ([]byte, error) {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	var match *zip.File
+	files := 0
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		files++
+		if filePath == "" || path.Clean(f.Name) == path.Clean(filePath) {
+			match = f
+		}
+	}
+
+	switch {
+	case filePath == "" && files != 1:
+		return nil, fmt.Errorf("artifact contains %d files, specify the path of the document within it", files)
+	case match == nil:
+		return nil, fmt.Errorf("%s not found in artifact", filePath)
+	}
+
+	rc, err := match.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
@@ -0,0 +1,41 @@
+package git
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadArtifactFile(t *testing.T) {
+	zipped := func(files map[string]string) []byte {
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		for name, contents := range files {
+			f, err := w.Create(name)
+			require.NoError(t, err)
+			_, err = f.Write([]byte(contents))
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		return buf.Bytes()
+	}
+
+	single := zipped(map[string]string{"openapi.yaml": "openapi: 3.1.0"})
+	contents, err := readArtifactFile(single, "")
+	require.NoError(t, err)
+	assert.Equal(t, "openapi: 3.1.0", string(contents))
+
+	multiple := zipped(map[string]string{"dist/openapi.yaml": "openapi: 3.1.0", "dist/openapi.json": "{}"})
+	contents, err = readArtifactFile(multiple, "dist/openapi.json")
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(contents))
+
+	_, err = readArtifactFile(multiple, "")
+	assert.ErrorContains(t, err, "contains 2 files")
+
+	_, err = readArtifactFile(multiple, "openapi.yaml")
+	assert.ErrorContains(t, err, "not found")
+}
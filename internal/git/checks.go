@@ -0,0 +1,187 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+const SpeakeasyCheckRunName = "Speakeasy SDK Generation"
+
+// maxCheckRunAnnotations is the most annotations GitHub accepts in a single check run update
+const maxCheckRunAnnotations = 50
+
+// CheckRunAnnotation is a message attached to a file of the repo in the check run, at Line when known. Level is notice,
+// warning or failure.
+type CheckRunAnnotation struct {
+	Path    string
+	Line    int
+	Level   string
+	Title   string
+	Message string
+}
+
+// CreateCheckRun starts an in progress check run against the commit the repo was cloned at
+func (g *Git) CreateCheckRun() (int64, error) {
+	if g.repo == nil {
+		return 0, fmt.Errorf("repo not cloned")
+	}
+
+	headRef, err := g.repo.Head()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get head ref: %w", err)
+	}
+
+	checkRun, _, err := g.client.Checks.CreateCheckRun(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), github.CreateCheckRunOptions{
+		Name:       SpeakeasyCheckRunName,
+		HeadSHA:    headRef.Hash().String(),
+		DetailsURL: github.String(fmt.Sprintf("%s/%s/actions/runs/%s", os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID"))),
+		Status:     github.String("in_progress"),
+		StartedAt:  &github.Timestamp{Time: time.Now()},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create check run: %w", err)
+	}
+
+	return checkRun.GetID(), nil
+}
+
+// CompleteCheckRun concludes the check run with the given conclusion, summary and annotations. GitHub accepts 50
+// annotations per update, so the rest are added by further updates, which append to them.
+func (g *Git) CompleteCheckRun(checkRunID int64, conclusion, title, summary, text string, annotations []CheckRunAnnotation) error {
+	newOutput := func(batch []CheckRunAnnotation) *github.CheckRunOutput {
+		output := &github.CheckRunOutput{
+			Title:   github.String(title),
+			Summary: github.String(summary),
+		}
+		if text != "" {
+			output.Text = github.String(stripCodes(text))
+		}
+		for _, annotation := range batch {
+			line := max(annotation.Line, 1)
+			output.Annotations = append(output.Annotations, &github.CheckRunAnnotation{
+				Path:            github.String(annotation.Path),
+				StartLine:       github.Int(line),
+				EndLine:         github.Int(line),
+				AnnotationLevel: github.String(annotation.Level),
+				Title:           github.String(annotation.Title),
+				Message:         github.String(stripCodes(annotation.Message)),
+			})
+		}
+		return output
+	}
+
+	batch := annotations[:min(len(annotations), maxCheckRunAnnotations)]
+	if _, _, err := g.client.Checks.UpdateCheckRun(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), checkRunID, github.UpdateCheckRunOptions{
+		Name:        SpeakeasyCheckRunName,
+		Status:      github.String("completed"),
+		Conclusion:  github.String(conclusion),
+		CompletedAt: &github.Timestamp{Time: time.Now()},
+		Output:      newOutput(batch),
+	}); err != nil {
+		return fmt.Errorf("failed to complete check run: %w", err)
+	}
+
+	for start := len(batch); start < len(annotations); start += maxCheckRunAnnotations {
+		batch := annotations[start:min(len(annotations), start+maxCheckRunAnnotations)]
+		if _, _, err := g.client.Checks.UpdateCheckRun(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), checkRunID, github.UpdateCheckRunOptions{
+			Name:   SpeakeasyCheckRunName,
+			Output: newOutput(batch),
+		}); err != nil {
+			return fmt.Errorf("failed to annotate check run: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// RequiredChecks returns the names of the status checks the rulesets and branch protection of the branch direct mode
+// merges into require
+func (g *Git) RequiredChecks() ([]string, error) {
+	ctx := context.Background()
+	owner := os.Getenv("GITHUB_REPOSITORY_OWNER")
+	branchName := branchFromRef(environment.GetRef())
+
+	rules, _, err := g.client.Repositories.GetRulesForBranch(ctx, owner, getRepo(), branchName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rules of branch %s: %w", branchName, err)
+	}
+
+	required := []string{}
+	for _, rule := range rules {
+		if rule.Type != "required_status_checks" || rule.Parameters == nil {
+			continue
+		}
+		var params github.RequiredStatusChecksRuleParameters
+		if err := json.Unmarshal(*rule.Parameters, &params); err != nil {
+			return nil, fmt.Errorf("failed to parse required status checks rule: %w", err)
+		}
+		for _, check := range params.RequiredStatusChecks {
+			required = append(required, check.Context)
+		}
+	}
+
+	// Reading branch protection requires admin access, which the token may not have
+	checks, _, err := g.client.Repositories.GetRequiredStatusChecks(ctx, owner, getRepo(), branchName)
+	if err != nil {
+		logging.Debug("failed to get required status checks of branch %s: %v", branchName, err)
+	} else {
+		for _, check := range checks.GetChecks() {
+			required = append(required, check.Context)
+		}
+		for _, name := range checks.GetContexts() {
+			required = append(required, name)
+		}
+	}
+
+	slices.Sort(required)
+
+	return slices.Compact(required), nil
+}
+
+// CheckConclusions returns the conclusions of the latest check runs and commit statuses of the commit by name, pending
+// for those that haven't completed
+func (g *Git) CheckConclusions(sha string) (map[string]string, error) {
+	ctx := context.Background()
+	owner := os.Getenv("GITHUB_REPOSITORY_OWNER")
+
+	conclusions := map[string]string{}
+
+	opts := &github.ListCheckRunsOptions{Filter: github.String("latest"), ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		results, response, err := g.client.Checks.ListCheckRunsForRef(ctx, owner, getRepo(), sha, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list check runs of %s: %w", sha, err)
+		}
+		for _, run := range results.CheckRuns {
+			conclusion := "pending"
+			if run.GetStatus() == "completed" {
+				conclusion = run.GetConclusion()
+			}
+			conclusions[run.GetName()] = conclusion
+		}
+
+		if response == nil || response.NextPage == 0 {
+			break
+		}
+		opts.Page = response.NextPage
+	}
+
+	combined, _, err := g.client.Repositories.GetCombinedStatus(ctx, owner, getRepo(), sha, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statuses of %s: %w", sha, err)
+	}
+	for _, status := range combined.Statuses {
+		// Commit statuses are pending, success, failure or error
+		conclusions[status.GetContext()] = status.GetState()
+	}
+
+	return conclusions, nil
+}
@@ -0,0 +1,61 @@
+package git
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGit_CompleteCheckRun(t *testing.T) {
+	updates := []github.UpdateCheckRunOptions{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/acme/sdks/check-runs/42", r.URL.Path)
+		var update github.UpdateCheckRunOptions
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+		updates = append(updates, update)
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 42})
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_REPOSITORY", "acme/sdks")
+	t.Setenv("GITHUB_REPOSITORY_OWNER", "acme")
+
+	client := github.NewClient(nil)
+	baseURL, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+	client.BaseURL = baseURL
+	g := Git{client: client}
+
+	annotations := []CheckRunAnnotation{{Path: ".speakeasy/workflow.yaml", Level: "failure", Title: "python failed to generate", Message: "error running workflow"}}
+	for i := range 60 {
+		annotations = append(annotations, CheckRunAnnotation{Path: "openapi.yaml", Line: i + 1, Level: "warning", Title: "validation warn", Message: fmt.Sprintf("issue %d", i)})
+	}
+
+	require.NoError(t, g.CompleteCheckRun(42, "failure", "SDK generation failed", "No SDKs were regenerated.", "", annotations))
+
+	require.Len(t, updates, 2)
+	assert.Equal(t, "completed", updates[0].GetStatus())
+	assert.Equal(t, "failure", updates[0].GetConclusion())
+	require.Len(t, updates[0].Output.Annotations, 50)
+	assert.Equal(t, &github.CheckRunAnnotation{
+		Path:            github.String(".speakeasy/workflow.yaml"),
+		StartLine:       github.Int(1),
+		EndLine:         github.Int(1),
+		AnnotationLevel: github.String("failure"),
+		Title:           github.String("python failed to generate"),
+		Message:         github.String("error running workflow"),
+	}, updates[0].Output.Annotations[0])
+
+	// The remaining annotations are appended by a further update that leaves the conclusion as is
+	assert.Nil(t, updates[1].Conclusion)
+	require.Len(t, updates[1].Output.Annotations, 11)
+	assert.Equal(t, 60, updates[1].Output.Annotations[10].GetStartLine())
+	assert.Equal(t, "SDK generation failed", updates[1].Output.GetTitle())
+}
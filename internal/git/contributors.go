@@ -0,0 +1,121 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// githubFile is a file in a GitHub repo referenced by a spec location
+type githubFile struct {
+	Owner string
+	Repo  string
+	Ref   string
+	Path  string
+}
+
+// parseGitHubFileURL parses raw.githubusercontent.com and github.com blob URLs, returning nil for any other location
+func parseGitHubFileURL(location string) *githubFile {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(u.Path, "/"), "/")
+
+	switch u.Host {
+	case "raw.githubusercontent.com":
+		if len(parts) < 4 {
+			return nil
+		}
+		return &githubFile{Owner: parts[0], Repo: parts[1], Ref: parts[2], Path: strings.Join(parts[3:], "/")}
+	case "github.com":
+		if len(parts) < 5 || parts[2] != "blob" {
+			return nil
+		}
+		return &githubFile{Owner: parts[0], Repo: parts[1], Ref: parts[3], Path: strings.Join(parts[4:], "/")}
+	}
+
+	return nil
+}
+
+// GetUpstreamSpecContributors returns the authors of commits to specs hosted in other GitHub repos between the spec
+// versions of this repo's latest release and of the generation at until, so they can be credited in the release notes.
+// Specs that can't be accessed are skipped.
+func (g *Git) GetUpstreamSpecContributors(specLocations []string, until time.Time) ([]string, error) {
+	files := []*githubFile{}
+	for _, location := range specLocations {
+		file := parseGitHubFileURL(location)
+		if file == nil || strings.EqualFold(fmt.Sprintf("%s/%s", file.Owner, file.Repo), os.Getenv("GITHUB_REPOSITORY")) {
+			continue
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	latestRelease, res, err := g.client.Repositories.GetLatestRelease(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo())
+	if err != nil {
+		if res != nil && res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest release: %w", err)
+	}
+
+	contributors := []string{}
+	for _, file := range files {
+		commits, err := g.listSpecCommits(file, latestRelease.GetCreatedAt().Time, until)
+		if err != nil {
+			logging.Info("Failed to list commits to %s/%s/%s, skipping crediting its contributors: %s", file.Owner, file.Repo, file.Path, err.Error())
+			continue
+		}
+
+		for _, commit := range commits {
+			contributor := commit.GetCommit().GetAuthor().GetName()
+			if login := commit.GetAuthor().GetLogin(); login != "" {
+				if strings.HasSuffix(login, "[bot]") {
+					continue
+				}
+				contributor = "@" + login
+			}
+			if contributor != "" && !slices.Contains(contributors, contributor) {
+				contributors = append(contributors, contributor)
+			}
+		}
+	}
+
+	return contributors, nil
+}
+
+// listSpecCommits returns all the commits to the spec file between since and until
+func (g *Git) listSpecCommits(file *githubFile, since, until time.Time) ([]*github.RepositoryCommit, error) {
+	opts := &github.CommitsListOptions{
+		SHA:         file.Ref,
+		Path:        file.Path,
+		Since:       since,
+		Until:       until,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	commits := []*github.RepositoryCommit{}
+	for {
+		page, res, err := g.client.Repositories.ListCommits(context.Background(), file.Owner, file.Repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, page...)
+
+		if res.NextPage == 0 {
+			return commits, nil
+		}
+		opts.Page = res.NextPage
+	}
+}
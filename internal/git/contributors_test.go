@@ -0,0 +1,65 @@
+package git
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGit_GetUpstreamSpecContributors(t *testing.T) {
+	released := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	generated := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/sdks/releases/latest":
+			_ = json.NewEncoder(w).Encode(map[string]any{"created_at": released})
+		case "/repos/acme/specs/commits":
+			assert.Equal(t, "main", r.URL.Query().Get("sha"))
+			assert.Equal(t, "openapi.yaml", r.URL.Query().Get("path"))
+			assert.Equal(t, released.Format(time.RFC3339), r.URL.Query().Get("since"))
+			assert.Equal(t, generated.Format(time.RFC3339), r.URL.Query().Get("until"))
+
+			if r.URL.Query().Get("page") == "" {
+				w.Header().Set("Link", fmt.Sprintf(`<%s/repos/acme/specs/commits?page=2>; rel="next"`, server.URL))
+				_ = json.NewEncoder(w).Encode([]map[string]any{
+					{"author": map[string]any{"login": "alice"}},
+					{"author": map[string]any{"login": "dependabot[bot]"}},
+				})
+				return
+			}
+			_ = json.NewEncoder(w).Encode([]map[string]any{
+				{"author": map[string]any{"login": "alice"}},
+				{"commit": map[string]any{"author": map[string]any{"name": "Bob"}}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_REPOSITORY", "acme/sdks")
+	t.Setenv("GITHUB_REPOSITORY_OWNER", "acme")
+
+	client := github.NewClient(nil)
+	baseURL, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+	client.BaseURL = baseURL
+	g := Git{client: client}
+
+	contributors, err := g.GetUpstreamSpecContributors([]string{
+		"https://raw.githubusercontent.com/acme/specs/main/openapi.yaml",
+		"https://raw.githubusercontent.com/acme/sdks/main/openapi.yaml",
+	}, generated)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@alice", "Bob"}, contributors)
+}
@@ -0,0 +1,71 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+const (
+	DeploymentStateInProgress = "in_progress"
+	DeploymentStateSuccess    = "success"
+	DeploymentStateFailure    = "failure"
+)
+
+// CreateDeployment records a deployment of the release tag to the given environment (one environment per package registry)
+func (g *Git) CreateDeployment(environmentName, tag, packageURL string) error {
+	deployment, _, err := g.client.Repositories.CreateDeployment(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), &github.DeploymentRequest{
+		Ref:              github.String(tag),
+		Environment:      github.String(environmentName),
+		Description:      github.String(fmt.Sprintf("Publishing %s to %s", tag, environmentName)),
+		AutoMerge:        github.Bool(false),
+		RequiredContexts: &[]string{},
+		// Only the latest published version of a package is live
+		ProductionEnvironment: github.Bool(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create deployment for %s in environment %s: %w", tag, environmentName, err)
+	}
+
+	logging.Info("Created deployment %d for %s in environment %s", deployment.GetID(), tag, environmentName)
+
+	return g.createDeploymentStatus(deployment.GetID(), DeploymentStateInProgress, packageURL)
+}
+
+// SetDeploymentState updates the most recent deployment of the release tag to the given environment
+func (g *Git) SetDeploymentState(environmentName, tag, state, packageURL string) error {
+	deployments, _, err := g.client.Repositories.ListDeployments(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), &github.DeploymentsListOptions{
+		Ref:         tag,
+		Environment: environmentName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list deployments for %s in environment %s: %w", tag, environmentName, err)
+	}
+
+	if len(deployments) == 0 {
+		return fmt.Errorf("no deployment found for %s in environment %s", tag, environmentName)
+	}
+
+	// Deployments are returned newest first
+	return g.createDeploymentStatus(deployments[0].GetID(), state, packageURL)
+}
+
+func (g *Git) createDeploymentStatus(deploymentID int64, state, packageURL string) error {
+	req := &github.DeploymentStatusRequest{
+		State:        github.String(state),
+		LogURL:       github.String(fmt.Sprintf("%s/%s/actions/runs/%s", os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID"))),
+		AutoInactive: github.Bool(true),
+	}
+	if packageURL != "" {
+		req.EnvironmentURL = github.String(packageURL)
+	}
+
+	if _, _, err := g.client.Repositories.CreateDeploymentStatus(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), deploymentID, req); err != nil {
+		return fmt.Errorf("failed to set deployment %d to %s: %w", deploymentID, state, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+const generatorUpgradeBranchPrefix = "speakeasy-generator-upgrade-"
+
+// FindGeneratorUpgradePR returns the open generator upgrade PR, or nil if there's none, and when the last generator
+// upgrade PR was opened, the zero time if there's never been one
+func (g *Git) FindGeneratorUpgradePR() (*ci.PullRequest, time.Time, error) {
+	prs, _, err := g.client.PullRequests.List(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), &github.PullRequestListOptions{
+		State:       "all",
+		Sort:        "created",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("error getting pull requests: %w", err)
+	}
+
+	open, last := generatorUpgradePR(prs)
+	if open == nil {
+		return nil, last, nil
+	}
+
+	return ci.GitHubPullRequest(open), last, nil
+}
+
+// generatorUpgradePR returns the open generator upgrade PR among the PRs and when the last generator upgrade PR was opened
+func generatorUpgradePR(prs []*github.PullRequest) (*github.PullRequest, time.Time) {
+	var open *github.PullRequest
+	var last time.Time
+	for _, pr := range prs {
+		if !isOwnGenerationPR(pr) || !strings.HasPrefix(pr.GetTitle(), getGeneratorUpgradePRTitlePrefix()) {
+			continue
+		}
+		if open == nil && pr.GetState() == "open" {
+			open = pr
+		}
+		if created := pr.GetCreatedAt().Time; created.After(last) {
+			last = created
+		}
+	}
+
+	return open, last
+}
+
+// CheckoutGeneratorUpgradeBranch checks out the branch of the generator upgrade PR, or a new branch if pr is nil, at
+// the current commit keeping the generated changes. The branch is force pushed so it's regenerated from the default
+// branch like the branch of the SDK update PR.
+func (g *Git) CheckoutGeneratorUpgradeBranch(pr *ci.PullRequest) (string, error) {
+	branchName := fmt.Sprintf("%s%d", generatorUpgradeBranchPrefix, time.Now().Unix())
+	if pr != nil {
+		branchName = pr.SourceBranch
+	}
+
+	logging.Info("Checking out generator upgrade branch %s", branchName)
+
+	if _, err := runGitCommand("checkout", "-B", branchName); err != nil {
+		return "", fmt.Errorf("error checking out branch %s: %w", branchName, err)
+	}
+
+	g.expectedBranch = branchName
+
+	return branchName, nil
+}
@@ -0,0 +1,49 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratorUpgradePR(t *testing.T) {
+	t.Setenv("GITHUB_REPOSITORY", "speakeasy-api/petstore")
+	t.Setenv("GITHUB_WORKFLOW", "Generate")
+
+	pr := func(title, ref, state string, created time.Time) *github.PullRequest {
+		return &github.PullRequest{
+			Title:     github.String(title),
+			State:     github.String(state),
+			CreatedAt: &github.Timestamp{Time: created},
+			Head: &github.PullRequestBranch{
+				Ref:  github.String(ref),
+				Repo: &github.Repository{FullName: github.String("speakeasy-api/petstore")},
+			},
+		}
+	}
+	week := 7 * 24 * time.Hour
+	now := time.Date(2024, 11, 15, 12, 0, 0, 0, time.UTC)
+
+	open, last := generatorUpgradePR([]*github.PullRequest{
+		pr("chore: 🐝 Update SDK - Generate", "speakeasy-sdk-regen-1", "open", now),
+		pr("chore: 🐝 Upgrade Speakeasy generator - Generate", "speakeasy-generator-upgrade-2", "closed", now.Add(-week)),
+		pr("chore: 🐝 Upgrade Speakeasy generator - Generate", "speakeasy-generator-upgrade-1", "closed", now.Add(-2*week)),
+	})
+	assert.Nil(t, open)
+	assert.Equal(t, now.Add(-week), last)
+
+	open, last = generatorUpgradePR([]*github.PullRequest{
+		pr("chore: 🐝 Upgrade Speakeasy generator - Generate", "speakeasy-generator-upgrade-2", "open", now.Add(-week)),
+		pr("chore: 🐝 Upgrade Speakeasy generator - Generate", "feature/upgrade", "open", now),
+	})
+	if assert.NotNil(t, open) {
+		assert.Equal(t, "speakeasy-generator-upgrade-2", open.GetHead().GetRef())
+	}
+	assert.Equal(t, now.Add(-week), last)
+
+	open, last = generatorUpgradePR(nil)
+	assert.Nil(t, open)
+	assert.True(t, last.IsZero())
+}
@@ -3,8 +3,10 @@ package git
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
@@ -20,9 +22,10 @@ import (
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
-	gitHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/utils/merkletrie"
 	genConfig "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-generation-action/internal/apisurface"
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
 	"github.com/speakeasy-api/sdk-generation-action/internal/cli"
 	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
 	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
@@ -31,25 +34,32 @@ import (
 	"github.com/speakeasy-api/versioning-reports/versioning"
 
 	"github.com/google/go-github/v63/github"
-	"golang.org/x/oauth2"
 )
 
 type Git struct {
 	accessToken string
 	repo        *git.Repository
 	client      *github.Client
+	// host clones, pushes and creates pull requests and releases. client implements the actions only GitHub has, e.g.
+	// suggestions and publishing events, for repos hosted on GitHub.
+	host ci.Host
+
+	// expectedBranch is the branch pushes are expected to target, tracked as branches are cloned and checked out
+	expectedBranch string
 }
 
 func New(accessToken string) *Git {
-	ctx := context.Background()
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: accessToken},
-	)
-	tc := oauth2.NewClient(ctx, ts)
+	host := ci.Current().NewHost(accessToken)
+
+	client := ci.NewGitHubClient(accessToken)
+	if gitHubHost, ok := host.(ci.GitHubHost); ok {
+		client = gitHubHost.Client()
+	}
 
 	return &Git{
 		accessToken: accessToken,
-		client:      github.NewClient(tc),
+		client:      client,
+		host:        host,
 	}
 }
 
@@ -66,28 +76,78 @@ func (g *Git) CloneRepo() error {
 
 	logging.Info("Cloning repo: %s from ref: %s", repoPath, ref)
 
-	workspace := environment.GetWorkspace()
-
 	// Remove the repo if it exists
 	// Flow is useful when testing locally, but we're usually in a fresh image so unnecessary most of the time
-	repoDir := path.Join(workspace, "repo")
+	repoDir := environment.GetRepoDir()
 	if err := os.RemoveAll(repoDir); err != nil {
 		return err
 	}
 
-	r, err := git.PlainClone(path.Join(workspace, "repo"), false, &git.CloneOptions{
+	// A commit SHA can't be cloned directly, so clone the default branch's history and check it out
+	if isCommitSHA(ref) {
+		r, err := g.clone(repoDir, &git.CloneOptions{
+			URL:      repoPath,
+			Progress: os.Stdout,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to clone repo: %w", err)
+		}
+
+		w, err := r.Worktree()
+		if err != nil {
+			return fmt.Errorf("error getting worktree: %w", err)
+		}
+		if err := w.Checkout(&git.CheckoutOptions{Hash: plumbing.NewHash(ref)}); err != nil {
+			return fmt.Errorf("failed to checkout commit %s: %w", ref, err)
+		}
+
+		g.repo = r
+		g.expectedBranch = ""
+
+		return g.initLFSAndSubmodules()
+	}
+
+	refName := plumbing.ReferenceName(ref)
+	if !strings.HasPrefix(ref, "refs/") {
+		refName = plumbing.NewBranchReferenceName(ref)
+	}
+
+	r, err := g.clone(repoDir, &git.CloneOptions{
 		URL:           repoPath,
 		Progress:      os.Stdout,
-		Auth:          getGithubAuth(g.accessToken),
-		ReferenceName: plumbing.ReferenceName(ref),
+		ReferenceName: refName,
 		SingleBranch:  true,
 	})
+	// A bare ref name that isn't a branch may be a tag
+	if err != nil && refName.IsBranch() && !strings.HasPrefix(ref, "refs/") {
+		refName = plumbing.NewTagReferenceName(ref)
+
+		if err := os.RemoveAll(repoDir); err != nil {
+			return err
+		}
+
+		r, err = g.clone(repoDir, &git.CloneOptions{
+			URL:           repoPath,
+			Progress:      os.Stdout,
+			ReferenceName: refName,
+			SingleBranch:  true,
+		})
+	}
 	if err != nil {
 		return fmt.Errorf("failed to clone repo: %w", err)
 	}
 	g.repo = r
+	g.expectedBranch = branchFromRef(refName.String())
 
-	return nil
+	return g.initLFSAndSubmodules()
+}
+
+func isCommitSHA(ref string) bool {
+	if len(ref) != 40 {
+		return false
+	}
+	_, err := hex.DecodeString(ref)
+	return err == nil
 }
 
 func (g *Git) CheckDirDirty(dir string, ignoreChangePatterns map[string]string) (bool, string, error) {
@@ -166,16 +226,11 @@ func (g *Git) CheckDirDirty(dir string, ignoreChangePatterns map[string]string)
 	return IsGitDiffSignificant(diffOutput, ignoreChangePatterns)
 }
 
-func (g *Git) FindExistingPR(branchName string, action environment.Action, sourceGeneration bool) (string, *github.PullRequest, error) {
+func (g *Git) FindExistingPR(branchName string, action environment.Action, sourceGeneration bool) (string, *ci.PullRequest, error) {
 	if g.repo == nil {
 		return "", nil, fmt.Errorf("repo not cloned")
 	}
 
-	prs, _, err := g.client.PullRequests.List(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), nil)
-	if err != nil {
-		return "", nil, fmt.Errorf("error getting pull requests: %w", err)
-	}
-
 	var prTitle string
 	if action == environment.ActionRunWorkflow || action == environment.ActionFinalize {
 		prTitle = getGenPRTitlePrefix()
@@ -190,21 +245,22 @@ func (g *Git) FindExistingPR(branchName string, action environment.Action, sourc
 		prTitle = getDocsPRTitlePrefix()
 	}
 
-	for _, p := range prs {
-		if strings.HasPrefix(p.GetTitle(), prTitle) {
-			logging.Info("Found existing PR %s", *p.Title)
+	pr, err := g.host.FindPR(prTitle)
+	if err != nil {
+		return "", nil, err
+	}
+	if pr == nil {
+		logging.Info("Existing PR not found")
+		return branchName, nil, nil
+	}
 
-			if branchName != "" && p.GetHead().GetRef() != branchName {
-				return "", nil, fmt.Errorf("existing PR has different branch name: %s than expected: %s", p.GetHead().GetRef(), branchName)
-			}
+	logging.Info("Found existing PR %s", pr.Title)
 
-			return p.GetHead().GetRef(), p, nil
-		}
+	if branchName != "" && pr.SourceBranch != branchName {
+		return "", nil, fmt.Errorf("existing PR has different branch name: %s than expected: %s", pr.SourceBranch, branchName)
 	}
 
-	logging.Info("Existing PR not found")
-
-	return branchName, nil, nil
+	return pr.SourceBranch, pr, nil
 }
 
 func (g *Git) FindAndCheckoutBranch(branchName string) (string, error) {
@@ -222,7 +278,7 @@ func (g *Git) FindAndCheckoutBranch(branchName string) (string, error) {
 		return "", fmt.Errorf("error getting remote: %w", err)
 	}
 	if err := r.Fetch(&git.FetchOptions{
-		Auth: getGithubAuth(g.accessToken),
+		Auth: g.auth(),
 		RefSpecs: []config.RefSpec{
 			config.RefSpec(fmt.Sprintf("refs/heads/%s:refs/heads/%s", branchName, branchName)),
 		},
@@ -240,9 +296,31 @@ func (g *Git) FindAndCheckoutBranch(branchName string) (string, error) {
 
 	logging.Info("Found existing branch %s", branchName)
 
+	g.expectedBranch = branchName
+
 	return branchName, nil
 }
 
+// DiscardChanges reverts any changes to the directory (relative to the repo root), removing untracked files
+func (g *Git) DiscardChanges(dir string) error {
+	tracked, err := runGitCommand("ls-files", "--", dir)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(tracked) != "" {
+		if _, err := runGitCommand("checkout", "HEAD", "--", dir); err != nil {
+			return err
+		}
+	}
+
+	if _, err := runGitCommand("clean", "-fd", "--", dir); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (g *Git) Reset(args ...string) error {
 	// We execute this manually because go-git doesn't support all the options we need
 	args = append([]string{"reset"}, args...)
@@ -250,7 +328,7 @@ func (g *Git) Reset(args ...string) error {
 	logging.Info("Running git  %s", strings.Join(args, " "))
 
 	cmd := exec.Command("git", args...)
-	cmd.Dir = filepath.Join(environment.GetWorkspace(), "repo", environment.GetWorkingDirectory())
+	cmd.Dir = filepath.Join(environment.GetRepoDir(), environment.GetWorkingDirectory())
 	cmd.Env = os.Environ()
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -310,6 +388,8 @@ func (g *Git) FindOrCreateBranch(branchName string, action environment.Action) (
 		return "", fmt.Errorf("error checking out branch: %w", err)
 	}
 
+	g.expectedBranch = branchName
+
 	return branchName, nil
 }
 
@@ -333,15 +413,9 @@ func (g *Git) DeleteBranch(branchName string) error {
 
 	logging.Info("Deleting branch %s", branchName)
 
-	r, err := g.repo.Remote("origin")
-	if err != nil {
-		return fmt.Errorf("error getting remote: %w", err)
-	}
-
 	ref := plumbing.NewBranchReferenceName(branchName)
 
-	if err := r.Push(&git.PushOptions{
-		Auth: getGithubAuth(g.accessToken),
+	if err := g.push(&git.PushOptions{
 		RefSpecs: []config.RefSpec{
 			config.RefSpec(fmt.Sprintf(":%s", ref.String())),
 		},
@@ -352,7 +426,42 @@ func (g *Git) DeleteBranch(branchName string) error {
 	return nil
 }
 
-func (g *Git) CommitAndPush(openAPIDocVersion, speakeasyVersion, doc string, action environment.Action, sourcesOnly bool) (string, error) {
+// CommitTrailers are appended to generation commits so the provenance of generated code can be reconstructed from git history
+type CommitTrailers struct {
+	SpeakeasyVersion   string
+	OpenAPIDocVersion  string
+	OpenAPIDocChecksum string
+	GeneratedLanguages []string
+	// SourceCommit is the commit the workflow run was triggered for, identifying re-runs of the run
+	SourceCommit string
+}
+
+func (t *CommitTrailers) String() string {
+	if t == nil {
+		return ""
+	}
+
+	trailers := []string{}
+	for _, trailer := range [][2]string{
+		{"Speakeasy-CLI-Version", t.SpeakeasyVersion},
+		{"OpenAPI-Doc-Version", t.OpenAPIDocVersion},
+		{"OpenAPI-Checksum", t.OpenAPIDocChecksum},
+		{"Generated-Languages", strings.Join(t.GeneratedLanguages, ", ")},
+		{"Source-Commit", t.SourceCommit},
+	} {
+		if trailer[1] != "" {
+			trailers = append(trailers, fmt.Sprintf("%s: %s", trailer[0], trailer[1]))
+		}
+	}
+
+	if len(trailers) == 0 {
+		return ""
+	}
+
+	return "\n\n" + strings.Join(trailers, "\n")
+}
+
+func (g *Git) CommitAndPush(openAPIDocVersion, speakeasyVersion, doc string, action environment.Action, sourcesOnly bool, trailers *CommitTrailers) (string, error) {
 	if g.repo == nil {
 		return "", fmt.Errorf("repo not cloned")
 	}
@@ -369,9 +478,20 @@ func (g *Git) CommitAndPush(openAPIDocVersion, speakeasyVersion, doc string, act
 
 	logging.Info("Commit and pushing changes to git")
 
-	if err := g.Add("."); err != nil {
+	excludes := environment.GetCommitExcludes()
+	artifactDirs := environment.GetArtifactTargetDirs()
+	for _, dir := range artifactDirs {
+		excludes = append(excludes, filepath.ToSlash(dir)+"/*")
+	}
+	if err := g.Add(append([]string{"."}, excludePathspecs(excludes)...)...); err != nil {
 		return "", fmt.Errorf("error adding changes: %w", err)
 	}
+	// Targets delivered as artifacts only commit the management config tracking their freshness
+	if lockFiles := artifactLockFiles(artifactDirs); len(lockFiles) > 0 {
+		if err := g.Add(lockFiles...); err != nil {
+			return "", fmt.Errorf("error adding changes: %w", err)
+		}
+	}
 
 	var commitMessage string
 	if action == environment.ActionRunWorkflow {
@@ -379,24 +499,45 @@ func (g *Git) CommitAndPush(openAPIDocVersion, speakeasyVersion, doc string, act
 		if sourcesOnly {
 			commitMessage = fmt.Sprintf("ci: regenerated with Speakeasy CLI %s", speakeasyVersion)
 		}
+		if directive := environment.GetCommitCIDirective(); directive != "" {
+			commitMessage += " " + directive
+		}
 	} else if action == environment.ActionSuggest {
 		commitMessage = fmt.Sprintf("ci: suggestions for OpenAPI doc %s", doc)
 	}
+	commitMessage += trailers.String()
 	commitHash, err := w.Commit(commitMessage, &git.CommitOptions{
 		Author: &object.Signature{
 			Name:  "speakeasybot",
 			Email: "bot@speakeasyapi.dev",
 			When:  time.Now(),
 		},
-		All: true,
+		// Staging all tracked changes would undo excluding them
+		All: len(excludes) == 0,
 	})
 	if err != nil {
 		return "", fmt.Errorf("error committing changes: %w", err)
 	}
 
-	if err := g.repo.Push(&git.PushOptions{
-		Auth:  getGithubAuth(g.accessToken),
-		Force: true, // This is necessary because at the beginning of the workflow we reset the branch
+	refSpecs, err := g.verifyPushTarget()
+	if err != nil {
+		return "", err
+	}
+
+	if environment.GetCommitMethod() == environment.CommitMethodAPI {
+		branchName, err := g.GetCurrentBranch()
+		if err != nil {
+			return "", err
+		}
+		created, ok, err := g.pushThroughAPI(branchName, commitHash)
+		if err != nil || ok {
+			return created, err
+		}
+	}
+
+	if err := g.push(&git.PushOptions{
+		RefSpecs: refSpecs,
+		Force:    true, // This is necessary because at the beginning of the workflow we reset the branch
 	}); err != nil {
 		return "", pushErr(err)
 	}
@@ -404,37 +545,118 @@ func (g *Git) CommitAndPush(openAPIDocVersion, speakeasyVersion, doc string, act
 	return commitHash.String(), nil
 }
 
-func (g *Git) Add(arg string) error {
+// CommitFilesAndPush commits the changes to the files, relative to the repo, and pushes them to the cloned branch. The
+// push isn't forced so it fails if the branch moved on since it was cloned.
+func (g *Git) CommitFilesAndPush(message string, paths ...string) (string, error) {
+	if g.repo == nil {
+		return "", fmt.Errorf("repo not cloned")
+	}
+
+	// In test mode do not commit and push, just move forward
+	if environment.IsTestMode() {
+		return "", nil
+	}
+
+	if _, err := runGitCommand(append([]string{"add", "-A", "--"}, paths...)...); err != nil {
+		return "", fmt.Errorf("error adding changes: %w", err)
+	}
+
+	w, err := g.repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("error getting worktree: %w", err)
+	}
+
+	commitHash, err := w.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "speakeasybot",
+			Email: "bot@speakeasyapi.dev",
+			When:  time.Now(),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error committing changes: %w", err)
+	}
+
+	refSpecs, err := g.verifyPushTarget()
+	if err != nil {
+		return "", err
+	}
+
+	if err := g.push(&git.PushOptions{RefSpecs: refSpecs}); err != nil {
+		return "", pushErr(err)
+	}
+
+	return commitHash.String(), nil
+}
+
+func (g *Git) Add(pathspecs ...string) error {
 	// We execute this manually because go-git doesn't properly support gitignore
-	cmd := exec.Command("git", "add", arg)
-	cmd.Dir = filepath.Join(environment.GetWorkspace(), "repo", environment.GetWorkingDirectory())
+	cmd := exec.Command("git", append([]string{"add", "--"}, pathspecs...)...)
+	cmd.Dir = filepath.Join(environment.GetRepoDir(), environment.GetWorkingDirectory())
 	cmd.Env = os.Environ()
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("error running `git add %s`: %w %s", arg, err, string(output))
+		return fmt.Errorf("error running `git add %s`: %w %s", strings.Join(pathspecs, " "), err, string(output))
 	}
 
 	return nil
 }
 
+// artifactLockFiles returns the gen.lock files present in the artifact targets' directories
+func artifactLockFiles(dirs []string) []string {
+	lockFiles := []string{}
+	for _, dir := range dirs {
+		lockFile := filepath.Join(dir, ".speakeasy", "gen.lock")
+		if _, err := os.Stat(filepath.Join(environment.GetRepoDir(), environment.GetWorkingDirectory(), lockFile)); err == nil {
+			lockFiles = append(lockFiles, lockFile)
+		}
+	}
+
+	return lockFiles
+}
+
+// excludePathspecs converts glob patterns into pathspecs excluding them from git commands. Patterns without a slash
+// match at any depth, as in .gitignore
+func excludePathspecs(patterns []string) []string {
+	pathspecs := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(pattern, "/")
+		if !strings.Contains(pattern, "/") {
+			pattern = "**/" + pattern
+		}
+		pathspecs = append(pathspecs, fmt.Sprintf(":(exclude,glob)%s", pattern), fmt.Sprintf(":(exclude,glob)%s/**", pattern))
+	}
+
+	return pathspecs
+}
+
 type PRInfo struct {
-	BranchName           string
-	ReleaseInfo          *releases.ReleasesInfo
-	PreviousGenVersion   string
-	PR                   *github.PullRequest
-	SourceGeneration     bool
-	LintingReportURL     string
-	ChangesReportURL     string
-	OpenAPIChangeSummary string
-	VersioningInfo       versionbumps.VersioningInfo
+	BranchName             string
+	ReleaseInfo            *releases.ReleasesInfo
+	PreviousGenVersion     string
+	PR                     *ci.PullRequest
+	SourceGeneration       bool
+	LintingReportURL       string
+	ChangesReportURL       string
+	OpenAPIChangeSummary   string
+	VersioningInfo         versionbumps.VersioningInfo
+	MergeConflicts         []string
+	ModifiedGeneratedFiles []string
+	OrphanedFiles          []string
+	APISurfaceDiffs        map[string]apisurface.Diff
+	// GeneratorUpgrade is set for the generator upgrade PR batching the changes caused only by new Speakeasy CLI versions
+	GeneratorUpgrade bool
+	// PreviousGenerationVersion is the generation version the generator upgrade PR's changelog starts from
+	PreviousGenerationVersion string
 }
 
-func (g *Git) CreateOrUpdatePR(info PRInfo) (*github.PullRequest, error) {
+// maxPRAPIChanges limits the added or removed symbols listed for each language in the PR body, which is size limited
+const maxPRAPIChanges = 50
+
+func (g *Git) CreateOrUpdatePR(info PRInfo) (*ci.PullRequest, error) {
 	var changelog string
 	var err error
 
-	labelTypes := g.UpsertLabelTypes(context.Background())
-
 	var previousGenVersions []string
 
 	if info.PreviousGenVersion != "" {
@@ -444,7 +666,7 @@ func (g *Git) CreateOrUpdatePR(info PRInfo) (*github.PullRequest, error) {
 	// Deprecated -- kept around for old CLI versions. VersioningReport is newer pathway
 	if info.ReleaseInfo != nil && info.VersioningInfo.VersionReport == nil {
 		for language, genInfo := range info.ReleaseInfo.LanguagesGenerated {
-			genPath := path.Join(environment.GetWorkspace(), "repo", genInfo.Path)
+			genPath := path.Join(environment.GetRepoDir(), genInfo.Path)
 
 			var targetVersions map[string]string
 
@@ -505,9 +727,11 @@ func (g *Git) CreateOrUpdatePR(info PRInfo) (*github.PullRequest, error) {
 		title = getDocsPRTitlePrefix()
 	} else if info.SourceGeneration {
 		title = getGenSourcesTitlePrefix()
+	} else if info.GeneratorUpgrade {
+		title = getGeneratorUpgradePRTitlePrefix()
 	}
 
-	suffix, labelBumpType, labels := PRVersionMetadata(info.VersioningInfo.VersionReport, labelTypes)
+	suffix, labelBumpType, labels := PRVersionMetadata(info.VersioningInfo.VersionReport)
 	title += suffix
 
 	body := ""
@@ -529,6 +753,21 @@ func (g *Git) CreateOrUpdatePR(info PRInfo) (*github.PullRequest, error) {
 
 	if info.SourceGeneration {
 		body += "Update of compiled sources"
+	} else if info.GeneratorUpgrade {
+		body += fmt.Sprintf(`# Speakeasy generator upgrade
+The OpenAPI document and generation config are unchanged, the SDKs were regenerated by a new version of the Speakeasy CLI:
+- OpenAPI Doc %s %s
+- Speakeasy CLI %s (%s) https://github.com/speakeasy-api/speakeasy
+`, info.ReleaseInfo.DocVersion, info.ReleaseInfo.DocLocation, info.ReleaseInfo.SpeakeasyVersion, info.ReleaseInfo.GenerationVersion)
+
+		if info.PreviousGenerationVersion != "" {
+			generatorChangelog, err := cli.GetGenerationChangelog(info.ReleaseInfo.GenerationVersion, info.PreviousGenerationVersion)
+			if err != nil {
+				logging.Info("failed to get the generator changelog: %v", err)
+			} else if strings.TrimSpace(generatorChangelog) != "" {
+				body += fmt.Sprintf("\n## Generator changelog\n\n%s\n", stripCodes(generatorChangelog))
+			}
+		}
 	} else {
 		body += fmt.Sprintf(`# SDK update
 Based on:
@@ -537,6 +776,48 @@ Based on:
 `, info.ReleaseInfo.DocVersion, info.ReleaseInfo.DocLocation, info.ReleaseInfo.SpeakeasyVersion, info.ReleaseInfo.GenerationVersion)
 	}
 
+	if len(info.MergeConflicts) > 0 {
+		body += "\n## Merge conflicts\n\nMerging the regenerated versions of these files with their modifications resulted in conflicts, which need to be resolved before merging this PR:\n"
+		for _, file := range info.MergeConflicts {
+			body += fmt.Sprintf("- `%s`\n", file)
+		}
+		body += "\n"
+	}
+
+	if len(info.ModifiedGeneratedFiles) > 0 {
+		body += "\n## Modified generated files\n\nThese generated files were modified since they were last generated and regenerating them overwrote the changes. Changes that should be kept need to be made through the OpenAPI document, overlays or gen.yaml instead:\n"
+		for _, file := range info.ModifiedGeneratedFiles {
+			body += fmt.Sprintf("- `%s`\n", file)
+		}
+		body += "\n"
+	}
+
+	if len(info.OrphanedFiles) > 0 {
+		body += "\n## Deleted orphaned files\n\nThese files were produced by the last generation but are no longer generated, e.g. for operations or schemas removed from the OpenAPI document, so were deleted:\n"
+		for _, file := range info.OrphanedFiles {
+			body += fmt.Sprintf("- `%s`\n", file)
+		}
+		body += "\n"
+	}
+
+	if len(info.APISurfaceDiffs) > 0 {
+		body += "\n## API surface changes\n"
+
+		langs := make([]string, 0, len(info.APISurfaceDiffs))
+		for lang := range info.APISurfaceDiffs {
+			langs = append(langs, lang)
+		}
+		slices.Sort(langs)
+
+		for _, lang := range langs {
+			diff := info.APISurfaceDiffs[lang]
+			body += fmt.Sprintf("\n### %s\n", lang)
+			body += formatAPIChanges("Removed", diff.Removed)
+			body += formatAPIChanges("Added", diff.Added)
+		}
+		body += "\n"
+	}
+
 	if info.VersioningInfo.VersionReport != nil {
 
 		// We keep track of explicit bump types and whether that bump type is manual or automated in the PR body
@@ -576,46 +857,27 @@ Based on:
 		body = body[:maxBodyLength-3] + "..."
 	}
 
-	if info.PR != nil {
-		logging.Info("Updating PR")
-
-		info.PR.Body = github.String(body)
-		info.PR.Title = &title
-		info.PR, _, err = g.client.PullRequests.Edit(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), info.PR.GetNumber(), info.PR)
-		// Set labels MUST always follow updating the PR
-		g.setPRLabels(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), info.PR.GetNumber(), labelTypes, info.PR.Labels, labels)
-		if err != nil {
-			return nil, fmt.Errorf("failed to update PR: %w", err)
-		}
-	} else {
-		logging.Info("Creating PR")
-
-		info.PR, _, err = g.client.PullRequests.Create(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), &github.NewPullRequest{
-			Title:               github.String(title),
-			Body:                github.String(body),
-			Head:                github.String(info.BranchName),
-			Base:                github.String(environment.GetRef()),
-			MaintainerCanModify: github.Bool(true),
-		})
-		if err != nil {
-			messageSuffix := ""
-			if strings.Contains(err.Error(), "GitHub Actions is not permitted to create or approve pull requests") {
-				messageSuffix += "\nNavigate to Settings > Actions > Workflow permissions and ensure that allow GitHub Actions to create and approve pull requests is checked. For more information see https://www.speakeasy.com/docs/advanced-setup/github-setup"
-			}
-			return nil, fmt.Errorf("failed to create PR: %w%s", err, messageSuffix)
-		} else if info.PR != nil && len(labels) > 0 {
-			g.setPRLabels(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), info.PR.GetNumber(), labelTypes, info.PR.Labels, labels)
-		}
+	// Only the bump type labels are managed, labels added by users are left alone
+	managedLabels := map[string]string{}
+	for bumpType, description := range versionbumps.GetBumpTypeLabels() {
+		managedLabels[string(bumpType)] = description
 	}
 
-	url := ""
-	if info.PR.URL != nil {
-		url = *info.PR.HTMLURL
+	pr, err := g.host.CreatePR(ci.NewPullRequest{
+		SourceBranch:  info.BranchName,
+		TargetBranch:  strings.TrimPrefix(environment.GetRef(), "refs/heads/"),
+		Title:         title,
+		Body:          body,
+		Labels:        labels,
+		ManagedLabels: managedLabels,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	logging.Info("PR: %s", url)
+	logging.Info("PR: %s", pr.URL)
 
-	return info.PR, nil
+	return pr, nil
 }
 
 func notEquivalent(desired []*github.Label, actual []*github.Label) bool {
@@ -777,12 +1039,14 @@ func (g *Git) MergeBranch(branchName string) (string, error) {
 	logging.Info("Merging branch %s", branchName)
 
 	// Checkout target branch
+	targetBranch := branchFromRef(environment.GetRef())
 	if err := w.Checkout(&git.CheckoutOptions{
-		Branch: plumbing.ReferenceName(environment.GetRef()),
+		Branch: plumbing.NewBranchReferenceName(targetBranch),
 		Create: false,
 	}); err != nil {
 		return "", fmt.Errorf("error checking out branch: %w", err)
 	}
+	g.expectedBranch = targetBranch
 
 	output, err := runGitCommand("merge", branchName)
 	if err != nil {
@@ -805,8 +1069,13 @@ func (g *Git) MergeBranch(branchName string) (string, error) {
 		return "", fmt.Errorf("error getting head ref: %w", err)
 	}
 
-	if err := g.repo.Push(&git.PushOptions{
-		Auth: getGithubAuth(g.accessToken),
+	refSpecs, err := g.verifyPushTarget()
+	if err != nil {
+		return "", err
+	}
+
+	if err := g.push(&git.PushOptions{
+		RefSpecs: refSpecs,
 	}); err != nil {
 		return "", pushErr(err)
 	}
@@ -990,13 +1259,6 @@ func (g *Git) CreateTag(tag string, hash string) error {
 	return nil
 }
 
-func getGithubAuth(accessToken string) *gitHttp.BasicAuth {
-	return &gitHttp.BasicAuth{
-		Username: "gen",
-		Password: accessToken,
-	}
-}
-
 func getRepo() string {
 	repoPath := os.Getenv("GITHUB_REPOSITORY")
 	parts := strings.Split(repoPath, "/")
@@ -1008,6 +1270,8 @@ const (
 	speakeasyGenSpecsTitle  = "chore: 🐝 Update Specs - "
 	speakeasySuggestPRTitle = "chore: 🐝 Suggest OpenAPI changes - "
 	speakeasyDocsPRTitle    = "chore: 🐝 Update SDK Docs - "
+
+	speakeasyGeneratorUpgradePRTitle = "chore: 🐝 Upgrade Speakeasy generator - "
 )
 
 func getGenPRTitlePrefix() string {
@@ -1030,9 +1294,13 @@ func getSuggestPRTitlePrefix() string {
 	return speakeasySuggestPRTitle + environment.GetWorkflowName()
 }
 
+func getGeneratorUpgradePRTitlePrefix() string {
+	return speakeasyGeneratorUpgradePRTitle + environment.GetWorkflowName()
+}
+
 func runGitCommand(args ...string) (string, error) {
 	cmd := exec.Command("git", args...)
-	cmd.Dir = filepath.Join(environment.GetWorkspace(), "repo")
+	cmd.Dir = environment.GetRepoDir()
 	var outb, errb bytes.Buffer
 	cmd.Stdout = &outb
 	cmd.Stderr = &errb
@@ -1043,6 +1311,49 @@ func runGitCommand(args ...string) (string, error) {
 	return outb.String(), nil
 }
 
+// verifyPushTarget refuses to push unless HEAD is the branch we expect to be on, returning refspecs that limit the
+// push to that branch. When verification is disabled the default refspecs are used.
+func (g *Git) verifyPushTarget() ([]config.RefSpec, error) {
+	if !environment.VerifyPushTarget() {
+		return nil, nil
+	}
+
+	head, err := g.repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("error getting head: %w", err)
+	}
+
+	if !head.Name().IsBranch() {
+		return nil, fmt.Errorf("refusing to push from a detached HEAD at %s", head.Hash().String())
+	}
+
+	if g.expectedBranch == "" {
+		return nil, fmt.Errorf("refusing to push branch %s as the branch to push to is unknown", head.Name().Short())
+	}
+
+	if head.Name().Short() != g.expectedBranch {
+		return nil, fmt.Errorf("refusing to push branch %s, expected to be on branch %s", head.Name().Short(), g.expectedBranch)
+	}
+
+	return []config.RefSpec{
+		config.RefSpec(fmt.Sprintf("%s:%s", head.Name(), head.Name())),
+	}, nil
+}
+
+// branchFromRef returns the branch name of a ref, which may be a full ref name or a bare branch name, or an empty
+// string for refs that aren't branches such as tags
+func branchFromRef(ref string) string {
+	name := plumbing.ReferenceName(ref)
+	if name.IsBranch() {
+		return name.Short()
+	}
+	if strings.HasPrefix(ref, "refs/") {
+		return ""
+	}
+
+	return ref
+}
+
 func pushErr(err error) error {
 	if err != nil {
 		if strings.Contains(err.Error(), "protected branch hook declined") {
@@ -1052,3 +1363,49 @@ func pushErr(err error) error {
 	}
 	return nil
 }
+
+// GetFileContents fetches a file from the repo at the workflow's ref without cloning, returning nil if it doesn't exist
+func (g *Git) GetFileContents(filePath string) ([]byte, error) {
+	return g.GetFileContentsAt(filePath, environment.GetRef())
+}
+
+// GetFileContentsAt fetches a file from the repo at the ref, e.g. a PR's branch, returning nil if it doesn't exist
+func (g *Git) GetFileContentsAt(filePath, ref string) ([]byte, error) {
+	file, _, res, err := g.client.Repositories.GetContents(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), filePath, &github.RepositoryContentGetOptions{
+		Ref: ref,
+	})
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get contents of %s: %w", filePath, err)
+	}
+	if file == nil {
+		return nil, fmt.Errorf("%s is a directory", filePath)
+	}
+
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contents of %s: %w", filePath, err)
+	}
+
+	return []byte(content), nil
+}
+
+// formatAPIChanges lists the added or removed symbols of an SDK's public API for the PR body
+func formatAPIChanges(title string, symbols []string) string {
+	if len(symbols) == 0 {
+		return ""
+	}
+
+	section := fmt.Sprintf("\n%s:\n", title)
+	for i, symbol := range symbols {
+		if i == maxPRAPIChanges {
+			section += fmt.Sprintf("- …and %d more\n", len(symbols)-maxPRAPIChanges)
+			break
+		}
+		section += fmt.Sprintf("- `%s`\n", symbol)
+	}
+
+	return section
+}
@@ -257,3 +257,67 @@ func TestArtifactMatchesRelease(t *testing.T) {
 		})
 	}
 }
+
+func TestCommitTrailers_String(t *testing.T) {
+	var nilTrailers *CommitTrailers
+	require.Equal(t, "", nilTrailers.String())
+
+	trailers := &CommitTrailers{
+		SpeakeasyVersion:   "1.400.0",
+		OpenAPIDocVersion:  "2.1.0",
+		GeneratedLanguages: []string{"go", "typescript"},
+	}
+	require.Equal(t, "\n\nSpeakeasy-CLI-Version: 1.400.0\nOpenAPI-Doc-Version: 2.1.0\nGenerated-Languages: go, typescript", trailers.String())
+}
+
+func TestCommitTrailers_String_SourceCommit(t *testing.T) {
+	trailers := &CommitTrailers{
+		SpeakeasyVersion: "1.400.0",
+		SourceCommit:     "abc123",
+	}
+	require.Equal(t, "\n\nSpeakeasy-CLI-Version: 1.400.0\nSource-Commit: abc123", trailers.String())
+}
+
+func TestGit_VerifyPushTarget(t *testing.T) {
+	repo, _ := newTestRepo(t)
+
+	g := Git{repo: repo, expectedBranch: "master"}
+	refSpecs, err := g.verifyPushTarget()
+	require.NoError(t, err)
+	require.Equal(t, "refs/heads/master:refs/heads/master", refSpecs[0].String())
+
+	g.expectedBranch = "main"
+	_, err = g.verifyPushTarget()
+	require.ErrorContains(t, err, "expected to be on branch main")
+
+	head, err := repo.Head()
+	require.NoError(t, err)
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, wt.Checkout(&git.CheckoutOptions{Hash: head.Hash()}))
+
+	_, err = g.verifyPushTarget()
+	require.ErrorContains(t, err, "detached HEAD")
+}
+
+func TestBranchFromRef(t *testing.T) {
+	require.Equal(t, "main", branchFromRef("refs/heads/main"))
+	require.Equal(t, "release/v1", branchFromRef("release/v1"))
+	require.Equal(t, "", branchFromRef("refs/tags/v1.0.0"))
+}
+
+func TestExcludePathspecs(t *testing.T) {
+	require.Equal(t, []string{
+		":(exclude,glob)**/node_modules",
+		":(exclude,glob)**/node_modules/**",
+		":(exclude,glob)dist/*.js",
+		":(exclude,glob)dist/*.js/**",
+	}, excludePathspecs([]string{"node_modules/", "dist/*.js"}))
+}
+
+func TestParseGitHubFileURL(t *testing.T) {
+	require.Equal(t, &githubFile{Owner: "acme", Repo: "specs", Ref: "main", Path: "api/openapi.yaml"}, parseGitHubFileURL("https://raw.githubusercontent.com/acme/specs/main/api/openapi.yaml"))
+	require.Equal(t, &githubFile{Owner: "acme", Repo: "specs", Ref: "v2", Path: "openapi.json"}, parseGitHubFileURL("https://github.com/acme/specs/blob/v2/openapi.json"))
+	require.Nil(t, parseGitHubFileURL("https://example.com/openapi.yaml"))
+	require.Nil(t, parseGitHubFileURL("./openapi.yaml"))
+}
@@ -0,0 +1,20 @@
+package git
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+// clone clones the repo through the host
+func (g *Git) clone(dir string, opts *git.CloneOptions) (*git.Repository, error) {
+	return g.host.Clone(dir, opts)
+}
+
+// push pushes to the repo's origin through the host
+func (g *Git) push(opts *git.PushOptions) error {
+	return g.host.Push(g.repo, opts)
+}
+
+func (g *Git) auth() transport.AuthMethod {
+	return g.host.Auth()
+}
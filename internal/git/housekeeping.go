@@ -0,0 +1,180 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/hashicorp/go-version"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// generationBranchPrefixes are the prefixes of the branches the action creates for its PRs
+var generationBranchPrefixes = []string{"speakeasy-sdk-regen-", "speakeasy-sdk-docs-regen-", "speakeasy-openapi-suggestion-", generatorUpgradeBranchPrefix}
+
+func isGenerationBranch(branchName string) bool {
+	for _, prefix := range generationBranchPrefixes {
+		if strings.HasPrefix(branchName, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasGenerationTitle returns whether the PR title is the title prefix, optionally followed by the generated version.
+// Titles with more after the prefix, e.g. the target of a PR for a single target, belong to other generations.
+func hasGenerationTitle(title, prTitle string) bool {
+	rest, ok := strings.CutPrefix(title, prTitle)
+	if !ok {
+		return false
+	}
+	if rest == "" {
+		return true
+	}
+
+	v, ok := strings.CutPrefix(rest, " ")
+	if !ok {
+		return false
+	}
+	_, err := version.NewVersion(v)
+
+	return err == nil
+}
+
+// isOwnGenerationPR returns whether the PR is from a generation branch of the repo, rather than a fork
+func isOwnGenerationPR(pr *github.PullRequest) bool {
+	return pr.GetHead().GetRepo().GetFullName() == os.Getenv("GITHUB_REPOSITORY") && isGenerationBranch(pr.GetHead().GetRef())
+}
+
+// CloseSupersededPRs closes the open generation PRs with the same title as the PR from branchName, e.g. left open by
+// concurrent runs, and deletes their branches. It returns the numbers of the closed PRs.
+func (g *Git) CloseSupersededPRs(branchName string, sourceGeneration bool) ([]int, error) {
+	prTitle := getGenPRTitlePrefix()
+	if environment.IsDocsGeneration() {
+		prTitle = getDocsPRTitlePrefix()
+	} else if sourceGeneration {
+		prTitle = getGenSourcesTitlePrefix()
+	}
+
+	prs, err := g.listOpenPRs()
+	if err != nil {
+		return nil, err
+	}
+
+	var current *github.PullRequest
+	superseded := []*github.PullRequest{}
+	for _, pr := range prs {
+		if !isOwnGenerationPR(pr) || !hasGenerationTitle(pr.GetTitle(), prTitle) {
+			continue
+		}
+		if pr.GetHead().GetRef() == branchName {
+			current = pr
+			continue
+		}
+		superseded = append(superseded, pr)
+	}
+	if current == nil {
+		return nil, nil
+	}
+
+	closed := []int{}
+	for _, pr := range superseded {
+		logging.Info("Closing PR #%d superseded by #%d", pr.GetNumber(), current.GetNumber())
+
+		comment := fmt.Sprintf("Superseded by #%d.", current.GetNumber())
+		if _, _, err := g.client.Issues.CreateComment(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), pr.GetNumber(), &github.IssueComment{Body: &comment}); err != nil {
+			return closed, fmt.Errorf("failed to comment on PR #%d: %w", pr.GetNumber(), err)
+		}
+		if _, _, err := g.client.PullRequests.Edit(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), pr.GetNumber(), &github.PullRequest{State: github.String("closed")}); err != nil {
+			return closed, fmt.Errorf("failed to close PR #%d: %w", pr.GetNumber(), err)
+		}
+		closed = append(closed, pr.GetNumber())
+
+		if err := g.DeleteBranch(pr.GetHead().GetRef()); err != nil {
+			return closed, err
+		}
+	}
+
+	return closed, nil
+}
+
+// DeleteStaleBranches deletes the generation branches, other than branchName, without an open PR whose last commit is
+// older than maxAge, e.g. the branches of merged or abandoned PRs. It returns the deleted branches.
+func (g *Git) DeleteStaleBranches(branchName string, maxAge time.Duration) ([]string, error) {
+	prs, err := g.listOpenPRs()
+	if err != nil {
+		return nil, err
+	}
+
+	openBranches := map[string]bool{}
+	for _, pr := range prs {
+		if isOwnGenerationPR(pr) {
+			openBranches[pr.GetHead().GetRef()] = true
+		}
+	}
+
+	branches := []*github.Branch{}
+	opts := &github.BranchListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		page, response, err := g.client.Repositories.ListBranches(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list branches: %w", err)
+		}
+
+		branches = append(branches, page...)
+
+		if response == nil || response.NextPage == 0 {
+			break
+		}
+		opts.Page = response.NextPage
+	}
+
+	deleted := []string{}
+	for _, branch := range branches {
+		name := branch.GetName()
+		if !isGenerationBranch(name) || name == branchName || openBranches[name] || branch.GetProtected() {
+			continue
+		}
+
+		commit, _, err := g.client.Repositories.GetCommit(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), branch.GetCommit().GetSHA(), nil)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to get last commit of branch %s: %w", name, err)
+		}
+		if time.Since(commit.GetCommit().GetCommitter().GetDate().Time) < maxAge {
+			continue
+		}
+
+		if err := g.DeleteBranch(name); err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, name)
+	}
+
+	return deleted, nil
+}
+
+func (g *Git) listOpenPRs() ([]*github.PullRequest, error) {
+	prs := []*github.PullRequest{}
+
+	opts := &github.PullRequestListOptions{State: "open", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		page, response, err := g.client.PullRequests.List(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), opts)
+		if err != nil {
+			return nil, fmt.Errorf("error getting pull requests: %w", err)
+		}
+
+		prs = append(prs, page...)
+
+		if response == nil || response.NextPage == 0 {
+			break
+		}
+		opts.Page = response.NextPage
+	}
+
+	return prs, nil
+}
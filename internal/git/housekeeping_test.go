@@ -0,0 +1,35 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasGenerationTitle(t *testing.T) {
+	prTitle := "chore: 🐝 Update SDK - Generate"
+
+	assert.True(t, hasGenerationTitle("chore: 🐝 Update SDK - Generate", prTitle))
+	assert.True(t, hasGenerationTitle("chore: 🐝 Update SDK - Generate 1.2.3", prTitle))
+	assert.False(t, hasGenerationTitle("chore: 🐝 Update SDK - Generate PYTHON", prTitle))
+	assert.False(t, hasGenerationTitle("chore: 🐝 Update SDK - Generate PYTHON 1.2.3", prTitle))
+	assert.False(t, hasGenerationTitle("chore: 🐝 Update SDK - GenerateDocs", prTitle))
+	assert.False(t, hasGenerationTitle("feat: add pets", prTitle))
+}
+
+func TestIsOwnGenerationPR(t *testing.T) {
+	t.Setenv("GITHUB_REPOSITORY", "speakeasy-api/petstore")
+
+	pr := func(repo, ref string) *github.PullRequest {
+		return &github.PullRequest{Head: &github.PullRequestBranch{
+			Ref:  github.String(ref),
+			Repo: &github.Repository{FullName: github.String(repo)},
+		}}
+	}
+
+	assert.True(t, isOwnGenerationPR(pr("speakeasy-api/petstore", "speakeasy-sdk-regen-1700000000")))
+	assert.True(t, isOwnGenerationPR(pr("speakeasy-api/petstore", "speakeasy-openapi-suggestion-1700000000")))
+	assert.False(t, isOwnGenerationPR(pr("someone/petstore", "speakeasy-sdk-regen-1700000000")))
+	assert.False(t, isOwnGenerationPR(pr("speakeasy-api/petstore", "feature/pets")))
+}
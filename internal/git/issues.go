@@ -0,0 +1,145 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+const (
+	DigestIssueLabel = "speakeasy-digest"
+	// ApprovalIssueLabel labels the issues opened to approve major version bumps
+	ApprovalIssueLabel = "speakeasy-major-bump-approval"
+	// ApprovedLabel is added to an approval issue to approve its major version bump
+	ApprovedLabel = "approved"
+	// FreezeIssueLabel labels the issue queuing the changes detected during a release freeze
+	FreezeIssueLabel = "speakeasy-release-freeze"
+)
+
+// AppendToDigestIssue comments on the open digest issue with the given title, creating the issue if it doesn't exist yet
+func (g *Git) AppendToDigestIssue(title, body string) (string, error) {
+	return g.appendToIssue(DigestIssueLabel, title, "Pending SDK changes detected by Speakeasy that have not been generated yet. Each run that detects changes adds a comment below.", body)
+}
+
+// AppendToFreezeIssue comments on the open release freeze issue with the given title, creating the issue if it doesn't
+// exist yet
+func (g *Git) AppendToFreezeIssue(title, body string) (string, error) {
+	return g.appendToIssue(FreezeIssueLabel, title, "SDK changes detected by Speakeasy during a release freeze. They are generated and released by the first run after the freeze, which closes this issue. Each run during the freeze adds a comment below.", body)
+}
+
+// FindFreezeIssue returns the open release freeze issue with the title, or nil if there's none
+func (g *Git) FindFreezeIssue(title string) (*github.Issue, error) {
+	return g.findIssue(context.Background(), FreezeIssueLabel, title)
+}
+
+func (g *Git) appendToIssue(label, title, description, body string) (string, error) {
+	ctx := context.Background()
+	owner := os.Getenv("GITHUB_REPOSITORY_OWNER")
+
+	issue, err := g.findIssue(ctx, label, title)
+	if err != nil {
+		return "", err
+	}
+
+	if issue == nil {
+		issue, _, err = g.client.Issues.Create(ctx, owner, getRepo(), &github.IssueRequest{
+			Title:  github.String(title),
+			Body:   github.String(description),
+			Labels: &[]string{label},
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to create %s issue: %w", label, err)
+		}
+
+		logging.Info("Created %s issue #%d", label, issue.GetNumber())
+	}
+
+	if _, _, err := g.client.Issues.CreateComment(ctx, owner, getRepo(), issue.GetNumber(), &github.IssueComment{
+		Body: github.String(body),
+	}); err != nil {
+		return "", fmt.Errorf("failed to comment on issue #%d: %w", issue.GetNumber(), err)
+	}
+
+	return issue.GetHTMLURL(), nil
+}
+
+// FindApprovalIssue returns the open issue with the title opened to approve a major version bump, or nil if there's none
+func (g *Git) FindApprovalIssue(title string) (*github.Issue, error) {
+	return g.findIssue(context.Background(), ApprovalIssueLabel, title)
+}
+
+// OpenApprovalIssue opens an issue to approve a major version bump, returning its URL
+func (g *Git) OpenApprovalIssue(title, body string) (string, error) {
+	issue, _, err := g.client.Issues.Create(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), &github.IssueRequest{
+		Title:  github.String(title),
+		Body:   github.String(body),
+		Labels: &[]string{ApprovalIssueLabel},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create approval issue: %w", err)
+	}
+
+	logging.Info("Created approval issue #%d", issue.GetNumber())
+
+	return issue.GetHTMLURL(), nil
+}
+
+// IsApproved returns whether the approval issue is labelled approved
+func IsApproved(issue *github.Issue) bool {
+	for _, label := range issue.Labels {
+		if label.GetName() == ApprovedLabel {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CloseIssue comments on the issue then closes it
+func (g *Git) CloseIssue(number int, comment string) error {
+	ctx := context.Background()
+	owner := os.Getenv("GITHUB_REPOSITORY_OWNER")
+
+	if _, _, err := g.client.Issues.CreateComment(ctx, owner, getRepo(), number, &github.IssueComment{
+		Body: github.String(comment),
+	}); err != nil {
+		return fmt.Errorf("failed to comment on issue #%d: %w", number, err)
+	}
+
+	if _, _, err := g.client.Issues.Edit(ctx, owner, getRepo(), number, &github.IssueRequest{
+		State: github.String("closed"),
+	}); err != nil {
+		return fmt.Errorf("failed to close issue #%d: %w", number, err)
+	}
+
+	return nil
+}
+
+func (g *Git) findIssue(ctx context.Context, label, title string) (*github.Issue, error) {
+	opts := &github.IssueListByRepoOptions{
+		State:       "open",
+		Labels:      []string{label},
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		issues, res, err := g.client.Issues.ListByRepo(ctx, os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s issues: %w", label, err)
+		}
+
+		for _, issue := range issues {
+			if issue.GetTitle() == title && !issue.IsPullRequest() {
+				return issue, nil
+			}
+		}
+
+		if res.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = res.NextPage
+	}
+}
@@ -1,116 +1,20 @@
 package git
 
 import (
-	"context"
-	"os"
 	"strings"
 
-	"github.com/google/go-github/v63/github"
-	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
 	"github.com/speakeasy-api/sdk-generation-action/internal/versionbumps"
 	"github.com/speakeasy-api/versioning-reports/versioning"
 )
 
-func (g *Git) UpsertLabelTypes(ctx context.Context) map[string]github.Label {
-	desiredLabels := map[string]github.Label{}
-	addGitHubLabel := func(name, description string) {
-		desiredLabels[name] = github.Label{
-			Name:        &name,
-			Description: &description,
-		}
-	}
-	for bumpType, description := range versionbumps.GetBumpTypeLabels() {
-		addGitHubLabel(string(bumpType), description)
-	}
-
-	actualLabels := make(map[string]github.Label)
-	allLabels, _, err := g.client.Issues.ListLabels(ctx, os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), nil)
-	if err != nil {
-		return actualLabels
-	}
-	for _, label := range allLabels {
-		actualLabels[*label.Name] = *label
-	}
-
-	for _, label := range desiredLabels {
-		foundLabel, ok := actualLabels[*label.Name]
-		if ok {
-			if *foundLabel.Description != *label.Description {
-				_, _, err = g.client.Issues.EditLabel(ctx, os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), *label.Name, &github.Label{
-					Name:        label.Name,
-					Description: label.Description,
-				})
-				if err != nil {
-					return actualLabels
-				}
-			}
-		} else {
-			_, _, err = g.client.Issues.CreateLabel(ctx, os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), &label)
-			if err != nil {
-				return actualLabels
-			}
-		}
-		actualLabels[*label.Name] = label
-	}
-	return actualLabels
-}
-
-func (g *Git) setPRLabels(background context.Context, owner string, repo string, issueNumber int, labelTypes map[string]github.Label, actualLabels, desiredLabels []*github.Label) {
-	shouldRemove := []string{}
-	shouldAdd := []string{}
-	for _, label := range actualLabels {
-		foundInDesired := false
-		for _, desired := range desiredLabels {
-			if label.GetName() == desired.GetName() {
-				foundInDesired = true
-				break
-			}
-			if _, ok := labelTypes[label.GetName()]; !ok {
-				foundInDesired = true
-				continue
-			}
-			break
-		}
-
-		// We shouldn't delete labels that aren't managed by us
-		if _, ok := versionbumps.GetBumpTypeLabels()[versioning.BumpType(label.GetName())]; ok && !foundInDesired {
-			shouldRemove = append(shouldRemove, label.GetName())
-		}
-	}
-	for _, desired := range desiredLabels {
-		foundInActual := false
-		for _, label := range actualLabels {
-			if label.GetName() == desired.GetName() {
-				foundInActual = true
-				break
-			}
-		}
-		if !foundInActual {
-			shouldAdd = append(shouldAdd, desired.GetName())
-		}
-	}
-	if len(shouldAdd) > 0 {
-		_, _, err := g.client.Issues.AddLabelsToIssue(background, owner, repo, issueNumber, shouldAdd)
-		if err != nil {
-			logging.Info("failed to add labels %v: %s", shouldAdd, err.Error())
-		}
-	}
-	if len(shouldRemove) > 0 {
-		for _, label := range shouldRemove {
-			_, err := g.client.Issues.RemoveLabelForIssue(background, owner, repo, issueNumber, label)
-			if err != nil {
-				logging.Info("failed to remove labels %s: %s", label, err.Error())
-			}
-		}
-	}
-}
-
-func PRVersionMetadata(m *versioning.MergedVersionReport, labelTypes map[string]github.Label) (string, *versioning.BumpType, []*github.Label) {
+// PRVersionMetadata returns the version suffix of the PR title, along with the bump type label of the PR when all the
+// SDKs are bumped alike
+func PRVersionMetadata(m *versioning.MergedVersionReport) (string, *versioning.BumpType, []string) {
 	var labelBumpTypeAdded *versioning.BumpType
 	if m == nil {
-		return "", labelBumpTypeAdded, []*github.Label{}
+		return "", labelBumpTypeAdded, []string{}
 	}
-	labels := []*github.Label{}
+	labels := []string{}
 	skipBumpType := false
 	skipVersionNumber := false
 	singleBumpType := ""
@@ -134,8 +38,8 @@ func PRVersionMetadata(m *versioning.MergedVersionReport, labelTypes map[string]
 		builder = append(builder, singleNewVersion)
 	}
 	if !skipBumpType {
-		if matched, ok := labelTypes[singleBumpType]; ok {
-			labels = append(labels, &matched)
+		if _, ok := versionbumps.GetBumpTypeLabels()[versioning.BumpType(singleBumpType)]; ok {
+			labels = append(labels, singleBumpType)
 			bumpType := versioning.BumpType(singleBumpType)
 			labelBumpTypeAdded = &bumpType
 		}
@@ -0,0 +1,191 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// mergeBaseDir holds the unmodified generated versions of user extendable files, relative to a target's directory, to
+// use as the base of the next three-way merge
+const mergeBaseDir = ".speakeasy/merge-base"
+
+// MergeUserExtendedFiles three-way merges the user extendable files the generator overwrote in the target directory
+// (relative to the repo root), between the previously generated version, the user's modified version and the newly
+// generated version. Conflicting files are left with conflict markers and returned.
+func (g *Git) MergeUserExtendedFiles(dir string) ([]string, error) {
+	pathspecs := userExtendablePathspecs(dir)
+	if len(pathspecs) == 0 {
+		return nil, nil
+	}
+
+	modified, err := runGitCommand(append([]string{"ls-files", "--modified", "--"}, pathspecs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modified user extendable files: %w", err)
+	}
+
+	conflicts := []string{}
+
+	for _, file := range strings.Split(strings.TrimSpace(modified), "\n") {
+		if file == "" {
+			continue
+		}
+
+		conflicted, err := mergeUserExtendedFile(dir, file)
+		if err != nil {
+			return nil, err
+		}
+		if conflicted {
+			conflicts = append(conflicts, file)
+		}
+	}
+
+	return conflicts, nil
+}
+
+// ListUserExtendableFiles returns the tracked user extendable files in the target directory (relative to the repo root)
+func (g *Git) ListUserExtendableFiles(dir string) ([]string, error) {
+	pathspecs := userExtendablePathspecs(dir)
+	if len(pathspecs) == 0 {
+		return nil, nil
+	}
+
+	files, err := runGitCommand(append([]string{"ls-files", "--"}, pathspecs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user extendable files: %w", err)
+	}
+
+	return strings.Fields(files), nil
+}
+
+func userExtendablePathspecs(dir string) []string {
+	patterns := environment.GetUserExtendableFiles()
+
+	pathspecs := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		pathspecs = append(pathspecs, ":(glob)"+path.Join(dir, pattern))
+	}
+
+	return pathspecs
+}
+
+func mergeUserExtendedFile(dir, file string) (bool, error) {
+	repoDir := environment.GetRepoDir()
+
+	relPath, err := filepath.Rel(dir, file)
+	if err != nil {
+		return false, err
+	}
+	baseFile := path.Join(dir, mergeBaseDir, filepath.ToSlash(relPath))
+
+	generated, err := os.ReadFile(filepath.Join(repoDir, file))
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	current, err := runGitCommand("show", "HEAD:"+file)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s at HEAD: %w", file, err)
+	}
+
+	base, err := getMergeBase(baseFile, file)
+	if err != nil {
+		return false, err
+	}
+
+	merged := []byte(current)
+	conflicted := false
+
+	if base == nil {
+		logging.Info("No previously generated version of %s found, keeping the current version", file)
+	} else {
+		merged, conflicted, err = mergeFile([]byte(current), base, generated)
+		if err != nil {
+			return false, fmt.Errorf("failed to merge %s: %w", file, err)
+		}
+		if conflicted {
+			logging.Info("Merging %s resulted in conflicts", file)
+		} else {
+			logging.Info("Merged user modifications to %s", file)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(repoDir, file), merged, 0o644); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", file, err)
+	}
+
+	// Keep the newly generated version as the base for the next merge
+	if err := os.MkdirAll(filepath.Dir(filepath.Join(repoDir, baseFile)), 0o755); err != nil {
+		return false, fmt.Errorf("failed to create merge base directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, baseFile), generated, 0o644); err != nil {
+		return false, fmt.Errorf("failed to write merge base for %s: %w", file, err)
+	}
+
+	return conflicted, nil
+}
+
+// getMergeBase returns the previously generated version of the file, falling back to its version in the last generation
+// commit for files generated before merge bases were kept
+func getMergeBase(baseFile, file string) ([]byte, error) {
+	if base, err := os.ReadFile(filepath.Join(environment.GetRepoDir(), baseFile)); err == nil {
+		return base, nil
+	}
+
+	commit, err := runGitCommand("log", "-1", "--format=%H", "--author=speakeasybot", "--", file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find last generation of %s: %w", file, err)
+	}
+	if commit = strings.TrimSpace(commit); commit == "" {
+		return nil, nil
+	}
+
+	base, err := runGitCommand("show", commit+":"+file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s at %s: %w", file, commit, err)
+	}
+
+	return []byte(base), nil
+}
+
+// mergeFile runs git merge-file on the three versions, returning the merged content and whether it has conflicts
+func mergeFile(current, base, generated []byte) ([]byte, bool, error) {
+	tmpDir, err := os.MkdirTemp("", "speakeasy-merge")
+	if err != nil {
+		return nil, false, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for name, content := range map[string][]byte{"current": current, "base": base, "generated": generated} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), content, 0o644); err != nil {
+			return nil, false, err
+		}
+	}
+
+	cmd := exec.Command("git", "merge-file", "-p",
+		"-L", "current", "-L", "previous generation", "-L", "new generation",
+		"current", "base", "generated")
+	cmd.Dir = tmpDir
+	var outb, errb bytes.Buffer
+	cmd.Stdout = &outb
+	cmd.Stderr = &errb
+
+	// merge-file exits with the number of conflicts
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128 {
+			return outb.Bytes(), true, nil
+		}
+		return nil, false, fmt.Errorf("%w - %s", err, errb.String())
+	}
+
+	return outb.Bytes(), false, nil
+}
@@ -0,0 +1,21 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeFile(t *testing.T) {
+	base := []byte("a\nb\nc\n")
+
+	merged, conflicted, err := mergeFile([]byte("a\nb\nc\nuser\n"), base, []byte("generated\na\nb\nc\n"))
+	require.NoError(t, err)
+	require.False(t, conflicted)
+	require.Equal(t, "generated\na\nb\nc\nuser\n", string(merged))
+
+	merged, conflicted, err = mergeFile([]byte("a\nuser\nc\n"), base, []byte("a\ngenerated\nc\n"))
+	require.NoError(t, err)
+	require.True(t, conflicted)
+	require.Contains(t, string(merged), "<<<<<<< current")
+}
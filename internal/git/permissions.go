@@ -0,0 +1,94 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+type Permission string
+
+const (
+	PermissionContentsWrite     Permission = "contents: write"
+	PermissionPullRequestsWrite Permission = "pull-requests: write"
+)
+
+// CheckTokenPermissions verifies, as far as GitHub exposes it for the token type in use, that the access token has the
+// given permissions on the repository. Fine-grained personal access tokens and installation tokens (e.g. GITHUB_TOKEN)
+// don't expose their permissions, so pull-requests: write is probed and contents: write is only checked against the
+// user's permissions, if any.
+func (g *Git) CheckTokenPermissions(required []Permission) error {
+	repo, res, err := g.client.Repositories.Get(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo())
+	if err != nil {
+		if res != nil && (res.StatusCode == 401 || res.StatusCode == 404) {
+			return fmt.Errorf("the provided github_access_token cannot access repository %s, ensure it is valid and has access to the repository", os.Getenv("GITHUB_REPOSITORY"))
+		}
+		return fmt.Errorf("failed to check github_access_token permissions: %w", err)
+	}
+
+	var scopes []string
+	if res != nil && res.Header.Get("X-OAuth-Scopes") != "" {
+		for _, scope := range strings.Split(res.Header.Get("X-OAuth-Scopes"), ",") {
+			scopes = append(scopes, strings.TrimSpace(scope))
+		}
+	}
+
+	denied := []Permission{}
+	if len(scopes) == 0 && slices.Contains(required, PermissionPullRequestsWrite) && !g.canWritePullRequests() {
+		denied = append(denied, PermissionPullRequestsWrite)
+	}
+
+	return missingPermissionsErr(required, repo.Permissions, scopes, repo.GetPrivate(), denied)
+}
+
+// canWritePullRequests probes pull-requests: write by editing a pull request that doesn't exist, which GitHub refuses
+// with a 403 before looking the pull request up when the token lacks the permission. Other failures are inconclusive.
+func (g *Git) canWritePullRequests() bool {
+	_, res, err := g.client.PullRequests.Edit(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), 0, &github.PullRequest{})
+	if err != nil && res != nil && res.StatusCode == http.StatusForbidden {
+		logging.Debug("github_access_token can't edit pull requests: %v", err)
+		return false
+	}
+
+	return true
+}
+
+// missingPermissionsErr lists the required permissions the token's scopes or the user's repository permissions lack,
+// along with those probing showed were denied
+func missingPermissionsErr(required []Permission, repoPermissions map[string]bool, scopes []string, private bool, denied []Permission) error {
+	missing := []string{}
+
+	for _, permission := range required {
+		if slices.Contains(denied, permission) {
+			missing = append(missing, string(permission))
+			continue
+		}
+
+		// Classic personal access tokens report their scopes, the repo scope grants every permission we need
+		if len(scopes) > 0 {
+			if !slices.Contains(scopes, "repo") && (private || !slices.Contains(scopes, "public_repo")) {
+				missing = append(missing, fmt.Sprintf("%s (token is missing the `repo` scope)", permission))
+			}
+			continue
+		}
+
+		// User tokens report the user's permissions on the repository, installation tokens report none
+		if permission == PermissionContentsWrite && repoPermissions != nil {
+			if push, ok := repoPermissions["push"]; ok && !push {
+				missing = append(missing, string(permission))
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("the provided github_access_token is missing required permissions: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package git
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingPermissionsErr(t *testing.T) {
+	required := []Permission{PermissionContentsWrite, PermissionPullRequestsWrite}
+
+	// Installation tokens expose neither scopes nor permissions
+	require.NoError(t, missingPermissionsErr(required, nil, nil, true, nil))
+
+	require.NoError(t, missingPermissionsErr(required, map[string]bool{"push": true, "pull": true}, nil, true, nil))
+	require.EqualError(t, missingPermissionsErr(required, map[string]bool{"push": false, "pull": true}, nil, true, nil),
+		"the provided github_access_token is missing required permissions: contents: write")
+
+	require.NoError(t, missingPermissionsErr(required, nil, []string{"repo", "workflow"}, true, nil))
+	require.NoError(t, missingPermissionsErr(required, nil, []string{"public_repo"}, false, nil))
+	require.Error(t, missingPermissionsErr(required, nil, []string{"public_repo"}, true, nil))
+	require.Error(t, missingPermissionsErr(required, nil, []string{"read:org"}, false, nil))
+
+	// Fine-grained personal access tokens and installation tokens are probed for pull-requests: write
+	require.EqualError(t, missingPermissionsErr(required, map[string]bool{"push": true, "pull": true}, nil, true, []Permission{PermissionPullRequestsWrite}),
+		"the provided github_access_token is missing required permissions: pull-requests: write")
+}
+
+func TestGit_CheckTokenPermissions(t *testing.T) {
+	tests := []struct {
+		name        string
+		probeStatus int
+		wantErr     string
+	}{
+		{name: "pull request edits allowed", probeStatus: http.StatusNotFound},
+		{name: "pull request edits denied", probeStatus: http.StatusForbidden, wantErr: "the provided github_access_token is missing required permissions: pull-requests: write"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/repos/acme/sdks":
+					_ = json.NewEncoder(w).Encode(map[string]any{"private": true})
+				case "/repos/acme/sdks/pulls/0":
+					assert.Equal(t, http.MethodPatch, r.Method)
+					w.WriteHeader(tt.probeStatus)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			t.Setenv("GITHUB_REPOSITORY", "acme/sdks")
+			t.Setenv("GITHUB_REPOSITORY_OWNER", "acme")
+
+			client := github.NewClient(nil)
+			baseURL, err := url.Parse(server.URL + "/")
+			require.NoError(t, err)
+			client.BaseURL = baseURL
+			g := Git{client: client}
+
+			err = g.CheckTokenPermissions([]Permission{PermissionContentsWrite, PermissionPullRequestsWrite})
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,14 +3,22 @@ package git
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
+	"maps"
+	"net/http"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/google/go-github/v63/github"
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
+	"github.com/speakeasy-api/sdk-generation-action/internal/cli"
 	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
 	"github.com/speakeasy-api/sdk-generation-action/internal/telemetry"
 	"github.com/speakeasy-api/sdk-generation-action/internal/utils"
 	"github.com/speakeasy-api/sdk-generation-action/pkg/releases"
@@ -19,26 +27,140 @@ import (
 //go:embed goreleaser.yml
 var tfGoReleaserConfig string
 
-const PublishingCompletedString = "Publishing Completed"
+const PublishingCompletedString = ci.PublishingCompleted
 
-func (g *Git) SetReleaseToPublished(version, directory string) error {
-	if g.repo == nil {
-		return fmt.Errorf("repo not cloned")
-	}
+// PublishingPendingString marks the releases drafted by release_after_publish until publishing to the registry succeeds
+const PublishingPendingString = "Publishing Pending"
+
+// publishEventLanguages are the languages whose publishing jobs report their outcome through the publish-event action,
+// which publishes the releases drafted by release_after_publish
+var publishEventLanguages = []string{"csharp", "java", "php", "python", "ruby", "typescript"}
+
+// ReleasedAfterPublish returns whether release_after_publish defers the release of the language until its publishing
+// job reports success through the publish-event action, e.g. Go is released by its tag alone so it's never deferred.
+// Only GitHub drafts releases, so releases are created immediately on other platforms.
+func ReleasedAfterPublish(lang string, outputs map[string]string) bool {
+	return environment.ReleaseAfterPublish() && ci.IsGitHub() && outputs[fmt.Sprintf("publish_%s", lang)] == "true" && slices.Contains(publishEventLanguages, lang)
+}
+
+// ReleaseTag returns the git tag used for releases of the SDK in the given directory
+func ReleaseTag(version, directory string) string {
 	tag := "v" + version
 	if directory != "" && directory != "." && directory != "./" {
 		tag = fmt.Sprintf("%s/%s", directory, tag)
 	}
 
-	release, _, err := g.client.Repositories.GetReleaseByTag(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), tag)
+	return tag
+}
+
+// UPMBranch is the branch Unity Package Manager releases of C# SDKs are published to, with the SDK at its root
+const UPMBranch = "upm"
+
+// PublishUPMRelease commits the SDK in the given directory to the root of the UPM branch, which Unity requires to install
+// packages from git, and tags it upm/v<version>. The tag is returned.
+func (g *Git) PublishUPMRelease(version, directory string) (string, error) {
+	treePath := path.Clean(directory)
+	if treePath == "." {
+		treePath = ""
+	}
+
+	tree, err := runGitCommand("rev-parse", "HEAD:"+treePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get tree of %s: %w", directory, err)
+	}
+
+	args := []string{"-c", "user.name=speakeasybot", "-c", "user.email=bot@speakeasyapi.dev", "commit-tree", strings.TrimSpace(tree), "-m", fmt.Sprintf("chore: release v%s", version)}
+
+	// Build on the existing UPM branch so its history is preserved
+	if _, err := runGitCommand(g.authArgs("fetch", "origin", fmt.Sprintf("refs/heads/%s:refs/remotes/origin/%s", UPMBranch, UPMBranch))...); err == nil {
+		parent, err := runGitCommand("rev-parse", "refs/remotes/origin/"+UPMBranch)
+		if err != nil {
+			return "", fmt.Errorf("failed to get head of %s branch: %w", UPMBranch, err)
+		}
+		args = append(args, "-p", strings.TrimSpace(parent))
+	}
+
+	commit, err := runGitCommand(args...)
 	if err != nil {
-		return fmt.Errorf("failed to get release for tag %s: %w", tag, err)
+		return "", fmt.Errorf("failed to create %s commit: %w", UPMBranch, err)
+	}
+	commit = strings.TrimSpace(commit)
+
+	tag := fmt.Sprintf("%s/v%s", UPMBranch, version)
+
+	if _, err := runGitCommand(g.authArgs("push", "origin", fmt.Sprintf("%s:refs/heads/%s", commit, UPMBranch), fmt.Sprintf("%s:refs/tags/%s", commit, tag))...); err != nil {
+		return "", pushErr(err)
+	}
+
+	return tag, nil
+}
+
+// FindRelease returns the published or draft release of the version of the SDK in the given directory, nil if there's
+// none
+func (g *Git) FindRelease(version, directory string) (*github.RepositoryRelease, error) {
+	return g.findReleaseByTag(ReleaseTag(version, directory))
+}
+
+// SetReleaseToDraft unpublishes the release of the SDK in the given directory, returning it to a draft. A release
+// that's already a draft is left as is.
+func (g *Git) SetReleaseToDraft(version, directory string) error {
+	tag := ReleaseTag(version, directory)
+
+	release, err := g.releaseByTag(tag)
+	if err != nil {
+		return err
+	}
+	if release.GetDraft() {
+		fmt.Printf("the release with tag %s is already a draft\n", tag)
+		return nil
+	}
+
+	release.Draft = github.Bool(true)
+
+	if _, _, err = g.client.Repositories.EditRelease(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), release.GetID(), release); err != nil {
+		return fmt.Errorf("failed to set release for tag %s to draft: %w", tag, err)
+	}
+
+	return nil
+}
+
+// AppendToReleaseBody adds a line to the body of the release of the SDK in the given directory
+func (g *Git) AppendToReleaseBody(version, directory, line string) error {
+	tag := ReleaseTag(version, directory)
+
+	release, err := g.releaseByTag(tag)
+	if err != nil {
+		return err
+	}
+
+	release.Body = github.String(release.GetBody() + "\n" + line)
+
+	if _, _, err = g.client.Repositories.EditRelease(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), release.GetID(), release); err != nil {
+		return fmt.Errorf("failed to add to release body for tag %s: %w", tag, err)
+	}
+
+	return nil
+}
+
+func (g *Git) SetReleaseToPublished(version, directory string) error {
+	if g.repo == nil {
+		return fmt.Errorf("repo not cloned")
+	}
+	tag := ReleaseTag(version, directory)
+
+	release, err := g.releaseByTag(tag)
+	if err != nil {
+		return err
 	}
 
 	if release != nil && release.ID != nil {
-		if release.Body != nil && !strings.Contains(*release.Body, PublishingCompletedString) {
-			body := *release.Body + "\n\n" + PublishingCompletedString
-			release.Body = &body
+		// Releases drafted by release_after_publish are released now their publishing succeeded
+		if release.GetDraft() && strings.Contains(release.GetBody(), PublishingPendingString) {
+			fmt.Printf("publishing the release with tag %s drafted until publishing succeeded\n", tag)
+			release.Draft = github.Bool(false)
+		}
+		if release.Body != nil {
+			release.Body = github.String(publishedReleaseBody(release.GetBody()))
 		}
 
 		if _, _, err = g.client.Repositories.EditRelease(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), *release.ID, release); err != nil {
@@ -49,25 +171,119 @@ func (g *Git) SetReleaseToPublished(version, directory string) error {
 	return nil
 }
 
+// publishedReleaseBody marks the release body's publishing as completed, replacing the pending publishing of drafted
+// releases
+func publishedReleaseBody(body string) string {
+	if strings.Contains(body, PublishingPendingString) {
+		body = strings.TrimSpace(strings.ReplaceAll(body, PublishingPendingString, ""))
+	}
+	if !strings.Contains(body, PublishingCompletedString) {
+		body += "\n\n" + PublishingCompletedString
+	}
+
+	return body
+}
+
+// releaseByTag returns the release of the tag. GitHub doesn't find draft releases by their tag, so they're looked up
+// among the repo's releases when there's no published one.
+func (g *Git) releaseByTag(tag string) (*github.RepositoryRelease, error) {
+	release, err := g.findReleaseByTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	if release == nil {
+		return nil, fmt.Errorf("failed to get release for tag %s: not found", tag)
+	}
+
+	return release, nil
+}
+
+// findReleaseByTag returns the published or draft release of the tag, nil if there's none
+func (g *Git) findReleaseByTag(tag string) (*github.RepositoryRelease, error) {
+	release, res, err := g.client.Repositories.GetReleaseByTag(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), tag)
+	if err == nil {
+		return release, nil
+	}
+	if res == nil || res.StatusCode != http.StatusNotFound {
+		return nil, fmt.Errorf("failed to get release for tag %s: %w", tag, err)
+	}
+
+	return ci.FindGitHubDraftRelease(g.client, tag)
+}
+
+// promoteCanaryRelease promotes the pre-release of the tag when releasing to the stable channel. It returns false when
+// the tag has no pre-release to promote.
+func (g *Git) promoteCanaryRelease(tag string) (bool, error) {
+	if environment.GetReleaseChannel() != environment.ReleaseChannelStable {
+		return false, nil
+	}
+
+	promoted, err := g.host.PromoteRelease(tag)
+	if promoted {
+		fmt.Printf("promoted the canary release with tag %s to the stable channel\n", tag)
+	}
+
+	return promoted, err
+}
+
 func (g *Git) CreateRelease(releaseInfo releases.ReleasesInfo, outputs map[string]string) error {
 	if g.repo == nil {
 		return fmt.Errorf("repo not cloned")
 	}
 
-	fmt.Println("Creating release")
-
 	headRef, err := g.repo.Head()
 	if err != nil {
 		return fmt.Errorf("failed to get head ref: %w", err)
 	}
 
-	commitHash := headRef.Hash().String()
+	return g.CreateReleaseAt(releaseInfo, outputs, headRef.Hash().String())
+}
+
+// CreateReleaseAt creates the releases at the commit rather than HEAD. Terraform releases are built by goreleaser from
+// the checked out code so must be created at HEAD.
+func (g *Git) CreateReleaseAt(releaseInfo releases.ReleasesInfo, outputs map[string]string, commitHash string) error {
+	if g.repo == nil {
+		return fmt.Errorf("repo not cloned")
+	}
+
+	fmt.Println("Creating release")
+
+	tags, err := g.RemoteTags()
+	if err != nil {
+		return err
+	}
 
+	releaseInfo.Languages = maps.Clone(releaseInfo.Languages)
 	for lang, info := range releaseInfo.Languages {
-		tag := "v" + info.Version
-		if info.Path != "" && info.Path != "." && info.Path != "./" {
-			tag = fmt.Sprintf("%s/%s", info.Path, tag)
+		// A canary release is promoted whatever commit its tag is at, as HEAD may have moved on since it was released
+		promoted, err := g.promoteCanaryRelease(ReleaseTag(info.Version, info.Path))
+		if err != nil {
+			return err
+		}
+		if promoted {
+			delete(releaseInfo.Languages, lang)
+			continue
+		}
+
+		ok, err := g.resolveTagConflict(info.Version, info.Path, commitHash, tags)
+		if err != nil {
+			return err
 		}
+		if !ok {
+			delete(releaseInfo.Languages, lang)
+			if _, ok := outputs[fmt.Sprintf("publish_%s", lang)]; ok {
+				outputs[fmt.Sprintf("publish_%s", lang)] = "false"
+			}
+		}
+	}
+
+	body, err := g.releaseBody(releaseInfo)
+	if err != nil {
+		return err
+	}
+
+	for lang, info := range releaseInfo.Languages {
+		tag := ReleaseTag(info.Version, info.Path)
 
 		if lang == "terraform" {
 			// Terraform is a special case -- we use go releaser externally to turn this tag into a release.
@@ -75,13 +291,14 @@ func (g *Git) CreateRelease(releaseInfo releases.ReleasesInfo, outputs map[strin
 			if err != nil {
 				return fmt.Errorf("failed to create tag: %w", err)
 			}
-			// Copy our standard terraform config into /tmp/.goreleaser.yml
-			err = os.WriteFile("/tmp/.goreleaser.yml", []byte(tfGoReleaserConfig), 0644)
+			// Copy our standard terraform config into the temp dir
+			goreleaserConfigPath := filepath.Join(os.TempDir(), ".goreleaser.yml")
+			err = os.WriteFile(goreleaserConfigPath, []byte(tfGoReleaserConfig), 0644)
 			if err != nil {
 				return fmt.Errorf("failed to write goreleaser config: %w", err)
 			}
-			cmd := exec.Command("goreleaser", "release", "--clean", "--config", "/tmp/.goreleaser.yml")
-			cmd.Dir = filepath.Join(environment.GetWorkspace(), "repo")
+			cmd := exec.Command("goreleaser", "release", "--clean", "--config", goreleaserConfigPath)
+			cmd.Dir = environment.GetRepoDir()
 			cmd.Env = append(os.Environ(),
 				"GORELEASER_PREVIOUS_TAG="+info.PreviousVersion,
 				"GORELEASER_CURRENT_TAG="+tag,
@@ -94,42 +311,173 @@ func (g *Git) CreateRelease(releaseInfo releases.ReleasesInfo, outputs map[strin
 				return fmt.Errorf("failed to run goreleaser: %w", err)
 			}
 		} else {
-			tagName := github.String(tag)
-			_, _, err = g.client.Repositories.CreateRelease(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), &github.RepositoryRelease{
-				TagName:         tagName,
-				TargetCommitish: github.String(commitHash),
-				Name:            github.String(fmt.Sprintf("%s - %s - %s", lang, tag, environment.GetInvokeTime().Format("2006-01-02 15:04:05"))),
-				Body:            github.String(fmt.Sprintf(`# Generated by Speakeasy CLI%s`, releaseInfo)),
-			})
+			release := ci.Release{
+				Tag:                tag,
+				Commit:             commitHash,
+				Name:               fmt.Sprintf("%s - %s - %s", lang, tag, environment.GetInvokeTime().Format("2006-01-02 15:04:05")),
+				Notes:              body,
+				Prerelease:         environment.GetReleaseChannel() == environment.ReleaseChannelCanary,
+				DiscussionCategory: environment.GetReleaseDiscussionCategory(),
+			}
+			// Releases are only drafted when a publishing job will publish them
+			if ReleasedAfterPublish(lang, outputs) {
+				fmt.Printf("drafting the release with tag %s until publishing succeeds\n", tag)
+				release.Draft = true
+				release.Notes = body + "\n\n" + PublishingPendingString
+			}
 
-			if err != nil {
-				if release, _, err := g.client.Repositories.GetReleaseByTag(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), *tagName); err == nil && release != nil {
-					if release.Body != nil && strings.Contains(*release.Body, PublishingCompletedString) {
-						fmt.Println(fmt.Sprintf("a github release with tag %s has already been published ... skipping publishing", *tagName))
-						fmt.Println(fmt.Sprintf("to publish this version again please check with your package managed delete the github tag and release"))
-						if _, ok := outputs[fmt.Sprintf("publish_%s", lang)]; ok {
-							outputs[fmt.Sprintf("publish_%s", lang)] = "false"
-						}
+			created, err := g.host.CreateRelease(release)
+			if errors.Is(err, ci.ErrReleaseExists) {
+				switch {
+				case created != nil && created.Draft:
+					fmt.Printf("a draft release with tag %s is already waiting for publishing ... skipping\n", tag)
+				case created != nil && strings.Contains(created.Notes, PublishingCompletedString):
+					fmt.Printf("a release with tag %s has already been published ... skipping publishing\n", tag)
+					fmt.Println("to publish this version again please check with your package manager, then delete the tag and release")
+					if _, ok := outputs[fmt.Sprintf("publish_%s", lang)]; ok {
+						outputs[fmt.Sprintf("publish_%s", lang)] = "false"
 					}
-					// TODO: Consider deleting and recreating the release if we are moving forward with publishing
-					return nil
+				default:
+					fmt.Printf("a release with tag %s already exists ... skipping\n", tag)
 				}
+				continue
+			}
+			if err != nil {
 				// If the release fails, trigger a failed publishing CLI event
 				if _, publishEventErr := telemetry.TriggerPublishingEvent(info.Path, "failed", utils.GetRegistryName(lang)); publishEventErr != nil {
 					fmt.Printf("failed to write publishing event: %v\n", publishEventErr)
 				}
 
-				return fmt.Errorf("failed to create release for tag %s: %w", *tagName, err)
-			} else {
-				// Go has no publishing job, so we publish a CLI event on github release here
-				if lang == "go" {
-					if _, publishEventErr := telemetry.TriggerPublishingEvent(info.Path, "success", utils.GetRegistryName(lang)); publishEventErr != nil {
-						fmt.Printf("failed to write publishing event: %v\n", publishEventErr)
-					}
+				return err
+			}
+
+			// Go has no publishing job, so we publish a CLI event on release here
+			if lang == "go" {
+				if _, publishEventErr := telemetry.TriggerPublishingEvent(info.Path, "success", utils.GetRegistryName(lang)); publishEventErr != nil {
+					fmt.Printf("failed to write publishing event: %v\n", publishEventErr)
 				}
 			}
+
+			if environment.TrackDeployments() {
+				g.trackReleaseDeployment(lang, tag, created.URL)
+			}
 		}
 	}
 
 	return nil
 }
+
+// releaseBody renders the release notes, using the release_notes_template file when one is configured
+func (g *Git) releaseBody(releaseInfo releases.ReleasesInfo) (string, error) {
+	notes := releaseInfo.Format(environment.GetReleaseNotesFormat())
+
+	templatePath := environment.GetReleaseNotesTemplate()
+	if templatePath == "" {
+		return fmt.Sprintf(`# Generated by Speakeasy CLI%s`, notes), nil
+	}
+
+	specChanges, err := releases.GetSpecChanges()
+	if err != nil {
+		return "", err
+	}
+
+	return releases.RenderTemplate(templatePath, releases.TemplateData{
+		ReleasesInfo: releaseInfo,
+		Notes:        strings.TrimSpace(notes),
+		Changelog:    g.generatorChangelogSinceLatestRelease(releaseInfo.GenerationVersion),
+		SpecChanges:  specChanges,
+	})
+}
+
+// generatorChangelogSinceLatestRelease returns the generator changes since the generation version of the repo's latest
+// release. The changelog is supplementary so is empty if it can't be determined.
+func (g *Git) generatorChangelogSinceLatestRelease(genVersion string) string {
+	latestRelease, _, err := g.client.Repositories.GetLatestRelease(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo())
+	if err != nil {
+		logging.Debug("failed to get latest release for changelog: %v", err)
+		return ""
+	}
+
+	body := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(latestRelease.GetBody()), PublishingCompletedString))
+	previous, err := releases.ParseReleases(body)
+	if err != nil || previous.GenerationVersion == "" || previous.GenerationVersion == genVersion {
+		return ""
+	}
+
+	changelog, err := cli.GetGenerationChangelog(genVersion, previous.GenerationVersion)
+	if err != nil {
+		logging.Info("failed to get generator changelog: %s", err.Error())
+		return ""
+	}
+
+	return strings.TrimSpace(changelog)
+}
+
+func (g *Git) trackReleaseDeployment(lang, tag, releaseURL string) {
+	environmentName := utils.GetRegistryName(lang)
+
+	if err := g.CreateDeployment(environmentName, tag, ""); err != nil {
+		fmt.Printf("failed to create deployment: %v\n", err)
+		return
+	}
+
+	// Go is published by the release itself, other languages are marked as deployed by the publish-event action
+	if lang == "go" {
+		if err := g.SetDeploymentState(environmentName, tag, DeploymentStateSuccess, releaseURL); err != nil {
+			fmt.Printf("failed to update deployment: %v\n", err)
+		}
+	}
+}
+
+func (g *Git) GetReleasesBetween(fromTag, toTag string) ([]*github.RepositoryRelease, error) {
+	allReleases := []*github.RepositoryRelease{}
+
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		page, response, err := g.client.Repositories.ListReleases(context.Background(), os.Getenv("GITHUB_REPOSITORY_OWNER"), getRepo(), opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list releases: %w", err)
+		}
+
+		allReleases = append(allReleases, page...)
+
+		if response == nil || response.NextPage == 0 {
+			break
+		}
+		opts.Page = response.NextPage
+	}
+
+	return ReleasesBetween(allReleases, fromTag, toTag)
+}
+
+// ReleasesBetween returns the releases created after fromTag up to and including toTag, oldest first.
+// If toTag is empty the latest release is used.
+func ReleasesBetween(allReleases []*github.RepositoryRelease, fromTag, toTag string) ([]*github.RepositoryRelease, error) {
+	sorted := slices.Clone(allReleases)
+	slices.SortStableFunc(sorted, func(a, b *github.RepositoryRelease) int {
+		return a.GetCreatedAt().Compare(b.GetCreatedAt().Time)
+	})
+
+	fromIndex := slices.IndexFunc(sorted, func(r *github.RepositoryRelease) bool {
+		return r.GetTagName() == fromTag
+	})
+	if fromIndex == -1 {
+		return nil, fmt.Errorf("release with tag %s not found", fromTag)
+	}
+
+	toIndex := len(sorted) - 1
+	if toTag != "" {
+		toIndex = slices.IndexFunc(sorted, func(r *github.RepositoryRelease) bool {
+			return r.GetTagName() == toTag
+		})
+		if toIndex == -1 {
+			return nil, fmt.Errorf("release with tag %s not found", toTag)
+		}
+	}
+
+	if toIndex <= fromIndex {
+		return nil, fmt.Errorf("release %s must be newer than release %s", sorted[toIndex].GetTagName(), fromTag)
+	}
+
+	return sorted[fromIndex+1 : toIndex+1], nil
+}
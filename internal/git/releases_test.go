@@ -0,0 +1,105 @@
+package git
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v63/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRelease(tag string, created time.Time) *github.RepositoryRelease {
+	return &github.RepositoryRelease{
+		TagName:   github.String(tag),
+		CreatedAt: &github.Timestamp{Time: created},
+	}
+}
+
+func TestReleasesBetween(t *testing.T) {
+	base := time.Unix(0, 0)
+	all := []*github.RepositoryRelease{
+		newTestRelease("v1.2.0", base.Add(3*time.Hour)),
+		newTestRelease("v1.0.0", base.Add(1*time.Hour)),
+		newTestRelease("v1.3.0", base.Add(4*time.Hour)),
+		newTestRelease("v1.1.0", base.Add(2*time.Hour)),
+	}
+
+	tags := func(releases []*github.RepositoryRelease) []string {
+		out := []string{}
+		for _, r := range releases {
+			out = append(out, r.GetTagName())
+		}
+		return out
+	}
+
+	got, err := ReleasesBetween(all, "v1.0.0", "v1.2.0")
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1.1.0", "v1.2.0"}, tags(got))
+
+	got, err = ReleasesBetween(all, "v1.1.0", "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1.2.0", "v1.3.0"}, tags(got))
+
+	_, err = ReleasesBetween(all, "v0.9.0", "")
+	require.Error(t, err)
+
+	_, err = ReleasesBetween(all, "v1.2.0", "v1.1.0")
+	require.Error(t, err)
+}
+
+func TestPublishedReleaseBody(t *testing.T) {
+	require.Equal(t, "# Notes\n\n"+PublishingCompletedString, publishedReleaseBody("# Notes"))
+	require.Equal(t, "# Notes\n\n"+PublishingCompletedString, publishedReleaseBody("# Notes\n\n"+PublishingPendingString))
+	require.Equal(t, "# Notes\n\n"+PublishingCompletedString, publishedReleaseBody("# Notes\n\n"+PublishingCompletedString))
+}
+
+func TestGit_SetReleaseToDraft(t *testing.T) {
+	edited := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/repos/acme/sdks/releases/tags/v1.2.3":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": 1, "tag_name": "v1.2.3"})
+		case r.URL.Path == "/repos/acme/sdks/releases/tags/v1.2.4":
+			// GitHub doesn't find draft releases by their tag
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path == "/repos/acme/sdks/releases" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"id": 2, "tag_name": "v1.2.4", "draft": true}})
+		case r.Method == http.MethodPatch:
+			var release github.RepositoryRelease
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&release))
+			assert.True(t, release.GetDraft())
+			edited = append(edited, r.URL.Path)
+			_ = json.NewEncoder(w).Encode(release)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_REPOSITORY", "acme/sdks")
+	t.Setenv("GITHUB_REPOSITORY_OWNER", "acme")
+
+	client := github.NewClient(nil)
+	baseURL, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+	client.BaseURL = baseURL
+	g := Git{client: client}
+
+	require.NoError(t, g.SetReleaseToDraft("1.2.3", "."))
+	// A release that's already a draft is left as is
+	require.NoError(t, g.SetReleaseToDraft("1.2.4", "."))
+	assert.Equal(t, []string{"/repos/acme/sdks/releases/1"}, edited)
+
+	release, err := g.FindRelease("1.2.4", ".")
+	require.NoError(t, err)
+	assert.True(t, release.GetDraft())
+
+	release, err = g.FindRelease("1.2.5", ".")
+	require.NoError(t, err)
+	assert.Nil(t, release)
+}
@@ -0,0 +1,163 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// HeadTrailer returns the value of the trailer of the HEAD commit's message, empty if it doesn't have it
+func (g *Git) HeadTrailer(key string) (string, error) {
+	if g.repo == nil {
+		return "", fmt.Errorf("repo not cloned")
+	}
+
+	head, err := g.repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to get head ref: %w", err)
+	}
+
+	commit, err := g.repo.CommitObject(head.Hash())
+	if err != nil {
+		return "", fmt.Errorf("failed to get head commit: %w", err)
+	}
+
+	return commitTrailer(commit.Message, key), nil
+}
+
+// BranchTrailer returns the value of the trailer of the message of the remote branch's head commit, empty if it doesn't
+// have it
+func (g *Git) BranchTrailer(branchName, key string) (string, error) {
+	if g.repo == nil {
+		return "", fmt.Errorf("repo not cloned")
+	}
+
+	remote, err := g.repo.Remote("origin")
+	if err != nil {
+		return "", fmt.Errorf("error getting remote: %w", err)
+	}
+	remoteRef := plumbing.NewRemoteReferenceName("origin", branchName)
+	if err := remote.Fetch(&git.FetchOptions{
+		Auth:     g.auth(),
+		RefSpecs: []config.RefSpec{config.RefSpec(fmt.Sprintf("+refs/heads/%s:%s", branchName, remoteRef))},
+	}); err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return "", fmt.Errorf("error fetching branch %s: %w", branchName, err)
+	}
+
+	ref, err := g.repo.Reference(remoteRef, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to get ref of branch %s: %w", branchName, err)
+	}
+
+	commit, err := g.repo.CommitObject(ref.Hash())
+	if err != nil {
+		return "", fmt.Errorf("failed to get head commit of branch %s: %w", branchName, err)
+	}
+
+	return commitTrailer(commit.Message, key), nil
+}
+
+// commitTrailer returns the value of the trailer in the last paragraph of the commit message
+func commitTrailer(message, key string) string {
+	paragraphs := strings.Split(strings.TrimSpace(message), "\n\n")
+	if len(paragraphs) < 2 {
+		return ""
+	}
+
+	for _, line := range strings.Split(paragraphs[len(paragraphs)-1], "\n") {
+		if k, v, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(k), key) {
+			return strings.TrimSpace(v)
+		}
+	}
+
+	return ""
+}
+
+// RemoteTags returns the tags of the repo's origin, e.g. "v1.2.0" or "go/v1.2.0", mapped to the commits they point at
+func (g *Git) RemoteTags() (map[string]string, error) {
+	if g.repo == nil {
+		return nil, fmt.Errorf("repo not cloned")
+	}
+
+	remote, err := g.repo.Remote("origin")
+	if err != nil {
+		return nil, fmt.Errorf("error getting remote: %w", err)
+	}
+
+	refs, err := remote.List(&git.ListOptions{Auth: g.auth(), PeelingOption: git.AppendPeeled})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote refs: %w", err)
+	}
+
+	tags := map[string]string{}
+	for _, ref := range refs {
+		if !ref.Name().IsTag() {
+			continue
+		}
+		// Annotated tags are listed twice, the peeled ref is the commit rather than the tag object
+		name, peeled := strings.CutSuffix(ref.Name().Short(), "^{}")
+		if _, ok := tags[name]; !ok || peeled {
+			tags[name] = ref.Hash().String()
+		}
+	}
+
+	return tags, nil
+}
+
+// LastCommitChanging returns the hash of the latest commit in the history of HEAD that changed the file at the path,
+// relative to the repo
+func (g *Git) LastCommitChanging(filePath string) (string, error) {
+	if g.repo == nil {
+		return "", fmt.Errorf("repo not cloned")
+	}
+
+	commits, err := g.repo.Log(&git.LogOptions{FileName: &filePath})
+	if err != nil {
+		return "", fmt.Errorf("failed to get history of %s: %w", filePath, err)
+	}
+	defer commits.Close()
+
+	commit, err := commits.Next()
+	if errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("no commit changed %s", filePath)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get history of %s: %w", filePath, err)
+	}
+
+	return commit.Hash.String(), nil
+}
+
+// CommitTime returns when the commit was committed
+func (g *Git) CommitTime(hash string) (time.Time, error) {
+	if g.repo == nil {
+		return time.Time{}, fmt.Errorf("repo not cloned")
+	}
+
+	commit, err := g.repo.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get commit %s: %w", hash, err)
+	}
+
+	return commit.Committer.When, nil
+}
+
+// HeadCommit returns the hash of the HEAD commit
+func (g *Git) HeadCommit() (string, error) {
+	if g.repo == nil {
+		return "", fmt.Errorf("repo not cloned")
+	}
+
+	head, err := g.repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to get head ref: %w", err)
+	}
+
+	return head.Hash().String(), nil
+}
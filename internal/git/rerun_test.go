@@ -0,0 +1,117 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitTrailer(t *testing.T) {
+	message := "ci: regenerated with Speakeasy CLI 1.400.0\n\nSpeakeasy-CLI-Version: 1.400.0\nSource-Commit: abc123\n"
+
+	assert.Equal(t, "abc123", commitTrailer(message, "Source-Commit"))
+	assert.Equal(t, "", commitTrailer(message, "OpenAPI-Doc-Version"))
+	assert.Equal(t, "", commitTrailer("Source-Commit: abc123", "Source-Commit"))
+}
+
+func TestGit_RemoteTags(t *testing.T) {
+	originDir := filepath.Join(t.TempDir(), "origin")
+	origin, err := git.PlainInit(originDir, false)
+	require.NoError(t, err)
+	wt, err := origin.Worktree()
+	require.NoError(t, err)
+	hash, err := wt.Commit("ci: regenerated\n\nSource-Commit: abc123", &git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author:            &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Unix(0, 0)},
+	})
+	require.NoError(t, err)
+	_, err = origin.CreateTag("go/v1.2.0", hash, nil)
+	require.NoError(t, err)
+	_, err = origin.CreateTag("v1.2.0", hash, &git.CreateTagOptions{
+		Message: "v1.2.0",
+		Tagger:  &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Unix(0, 0)},
+	})
+	require.NoError(t, err)
+
+	repo, err := git.PlainClone(filepath.Join(t.TempDir(), "repo"), false, &git.CloneOptions{URL: originDir})
+	require.NoError(t, err)
+	g := Git{repo: repo, host: originHost{}}
+
+	tags, err := g.RemoteTags()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"go/v1.2.0": hash.String(), "v1.2.0": hash.String()}, tags)
+
+	sourceCommit, err := g.HeadTrailer("Source-Commit")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", sourceCommit)
+}
+
+func TestGit_BranchTrailer(t *testing.T) {
+	originDir := filepath.Join(t.TempDir(), "origin")
+	origin, err := git.PlainInit(originDir, false)
+	require.NoError(t, err)
+	wt, err := origin.Worktree()
+	require.NoError(t, err)
+	author := &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Unix(0, 0)}
+	_, err = wt.Commit("chore: initial commit", &git.CommitOptions{AllowEmptyCommits: true, Author: author})
+	require.NoError(t, err)
+
+	repo, err := git.PlainClone(filepath.Join(t.TempDir(), "repo"), false, &git.CloneOptions{URL: originDir, SingleBranch: true})
+	require.NoError(t, err)
+	g := Git{repo: repo, host: originHost{}}
+
+	require.NoError(t, wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("speakeasy-sdk-regen-1"), Create: true}))
+	_, err = wt.Commit("ci: regenerated\n\nSource-Commit: abc123", &git.CommitOptions{AllowEmptyCommits: true, Author: author})
+	require.NoError(t, err)
+
+	sourceCommit, err := g.BranchTrailer("speakeasy-sdk-regen-1", "Source-Commit")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", sourceCommit)
+
+	sourceCommit, err = g.HeadTrailer("Source-Commit")
+	require.NoError(t, err)
+	assert.Equal(t, "", sourceCommit)
+}
+
+func TestGit_LastCommitChanging(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+
+	commit := func(file, message string) string {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(message), 0o644))
+		_, err := wt.Add(file)
+		require.NoError(t, err)
+		hash, err := wt.Commit(message, &git.CommitOptions{
+			Author: &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Unix(0, 0)},
+		})
+		require.NoError(t, err)
+		return hash.String()
+	}
+
+	commit("RELEASES.md", "ci: regenerated v1.0.0")
+	generation := commit("RELEASES.md", "ci: regenerated v1.1.0")
+	commit("README.md", "docs: update readme")
+
+	g := Git{repo: repo}
+
+	hash, err := g.LastCommitChanging("RELEASES.md")
+	require.NoError(t, err)
+	assert.Equal(t, generation, hash)
+
+	committed, err := g.CommitTime(hash)
+	require.NoError(t, err)
+	assert.True(t, committed.Equal(time.Unix(0, 0)))
+
+	_, err = g.LastCommitChanging("go/RELEASES.md")
+	assert.ErrorContains(t, err, "no commit changed go/RELEASES.md")
+}
@@ -0,0 +1,61 @@
+package git
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// initLFSAndSubmodules fetches LFS objects and submodules for repos that use them, as go-git's clone leaves LFS
+// pointers in place of the files and doesn't initialize submodules
+func (g *Git) initLFSAndSubmodules() error {
+	repoDir := environment.GetRepoDir()
+
+	if environment.InitSubmodules() && fileExists(filepath.Join(repoDir, ".gitmodules")) {
+		logging.Info("Initializing submodules")
+
+		if _, err := runGitCommand(g.authArgs("submodule", "update", "--init", "--recursive")...); err != nil {
+			return fmt.Errorf("failed to initialize submodules: %w", err)
+		}
+	}
+
+	if environment.InitLFS() && usesLFS(repoDir) {
+		logging.Info("Fetching Git LFS objects")
+
+		if _, err := runGitCommand("lfs", "install", "--local"); err != nil {
+			return fmt.Errorf("failed to install git lfs: %w", err)
+		}
+		if _, err := runGitCommand(g.authArgs("lfs", "pull")...); err != nil {
+			return fmt.Errorf("failed to pull git lfs objects: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// authArgs prefixes a git command with an auth header for the access token, as the credentials used to clone
+// aren't persisted for the git CLI
+func (g *Git) authArgs(args ...string) []string {
+	credentials := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + g.accessToken))
+
+	return append([]string{"-c", fmt.Sprintf("http.extraheader=AUTHORIZATION: basic %s", credentials)}, args...)
+}
+
+func usesLFS(repoDir string) bool {
+	data, err := os.ReadFile(filepath.Join(repoDir, ".gitattributes"))
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(string(data), "filter=lfs")
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
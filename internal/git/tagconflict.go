@@ -0,0 +1,61 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// resolveTagConflict applies the tag_conflict strategy when the release tag of the SDK in the directory already exists
+// on the origin at a commit other than the released one. A tag at the released commit is left to the release's creation
+// to handle, as it's a re-run of the same release. It returns false to skip releasing the SDK.
+func (g *Git) resolveTagConflict(releaseVersion, directory, commitHash string, tags map[string]string) (bool, error) {
+	tag := ReleaseTag(releaseVersion, directory)
+	tagCommit := tags[tag]
+	if tagCommit == "" || tagCommit == commitHash {
+		return true, nil
+	}
+
+	switch environment.GetTagConflictStrategy() {
+	case environment.TagConflictSkip:
+		logging.Info("Release tag %s already exists at commit %s, skipping the release", tag, tagCommit)
+		return false, nil
+	case environment.TagConflictBumpPatch:
+		// The SDK was committed at the version so can't be released under another, conflicts are resolved before
+		// generation
+		return false, fmt.Errorf("release tag %s already exists at commit %s: it was created after the SDK was generated, regenerate the SDK to release it under a free patch version", tag, tagCommit)
+	case environment.TagConflictOverwrite:
+		logging.Info("Release tag %s already exists at commit %s, overwriting it", tag, tagCommit)
+		if err := g.deleteRelease(tag); err != nil {
+			return false, err
+		}
+		return true, nil
+	default:
+		return false, fmt.Errorf("release tag %s already exists at commit %s: set tag_conflict to skip or overwrite to release anyway", tag, tagCommit)
+	}
+}
+
+// deleteRelease deletes the tag from the origin and the local repo, along with its release on the host
+func (g *Git) deleteRelease(tag string) error {
+	if err := g.host.DeleteRelease(tag); err != nil {
+		return err
+	}
+
+	if err := g.push(&git.PushOptions{
+		RefSpecs: []config.RefSpec{
+			config.RefSpec(":refs/tags/" + tag),
+		},
+	}); err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("failed to delete tag %s: %w", tag, err)
+	}
+
+	if err := g.repo.DeleteTag(tag); err != nil && !errors.Is(err, git.ErrTagNotFound) {
+		return fmt.Errorf("failed to delete local tag %s: %w", tag, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package git
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// originHost fetches from and pushes to a local origin, the tests don't use the rest of the host
+type originHost struct {
+	ci.Host
+}
+
+func (originHost) Auth() transport.AuthMethod {
+	return nil
+}
+
+func (originHost) Push(repo *git.Repository, opts *git.PushOptions) error {
+	return repo.Push(opts)
+}
+
+// DeleteRelease has no release to delete, the tag is the release
+func (originHost) DeleteRelease(tag string) error {
+	return nil
+}
+
+func TestGit_resolveTagConflict(t *testing.T) {
+	tags := map[string]string{"go/v1.2.0": "abc", "go/v1.2.1": "def", "v1.2.0": "123"}
+
+	tests := []struct {
+		name        string
+		strategy    string
+		version     string
+		directory   string
+		wantRelease bool
+		wantErr     string
+	}{
+		{name: "free tag", version: "1.3.0", directory: "go", wantRelease: true},
+		{name: "tag at the released commit", version: "1.2.0", directory: ".", wantRelease: true},
+		{name: "fails by default", version: "1.2.0", directory: "go", wantErr: "release tag go/v1.2.0 already exists at commit abc"},
+		{name: "skip", strategy: "skip", version: "1.2.0", directory: "go"},
+		{name: "bump patch after generation", strategy: "bump-patch", version: "1.2.0", directory: "go", wantErr: "it was created after the SDK was generated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("INPUT_TAG_CONFLICT", tt.strategy)
+
+			g := Git{}
+			gotRelease, err := g.resolveTagConflict(tt.version, tt.directory, "123", tags)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRelease, gotRelease)
+		})
+	}
+}
+
+func TestGit_resolveTagConflict_Overwrite(t *testing.T) {
+	t.Setenv("INPUT_TAG_CONFLICT", "overwrite")
+
+	originDir := filepath.Join(t.TempDir(), "origin")
+	origin, err := git.PlainInit(originDir, false)
+	require.NoError(t, err)
+	wt, err := origin.Worktree()
+	require.NoError(t, err)
+	hash, err := wt.Commit("ci: regenerated", &git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author:            &object.Signature{Name: "Test User", Email: "test@example.com", When: time.Unix(0, 0)},
+	})
+	require.NoError(t, err)
+	_, err = origin.CreateTag("v1.2.0", hash, nil)
+	require.NoError(t, err)
+
+	repo, err := git.PlainClone(filepath.Join(t.TempDir(), "repo"), false, &git.CloneOptions{URL: originDir})
+	require.NoError(t, err)
+	g := Git{repo: repo, host: originHost{}}
+
+	ok, err := g.resolveTagConflict("1.2.0", ".", "def", map[string]string{"v1.2.0": hash.String()})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = origin.Tag("v1.2.0")
+	assert.ErrorIs(t, err, git.ErrTagNotFound)
+	_, err = repo.Tag("v1.2.0")
+	assert.ErrorIs(t, err, git.ErrTagNotFound)
+}
@@ -0,0 +1,98 @@
+// Package lockfiles re-resolves the dependencies pinned by the lockfiles of generated SDKs
+package lockfiles
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// lockfile is a package manager's lockfile along with the command re-resolving the dependencies it pins
+type lockfile struct {
+	name string
+	args []string
+}
+
+var (
+	npmLockfile      = lockfile{name: "package-lock.json", args: []string{"npm", "update", "--package-lock-only", "--ignore-scripts", "--no-audit", "--no-fund"}}
+	pnpmLockfile     = lockfile{name: "pnpm-lock.yaml", args: []string{"pnpm", "update", "--lockfile-only", "--ignore-scripts"}}
+	uvLockfile       = lockfile{name: "uv.lock", args: []string{"uv", "lock", "--upgrade"}}
+	poetryLockfile   = lockfile{name: "poetry.lock", args: []string{"poetry", "lock"}}
+	bundleLockfile   = lockfile{name: "Gemfile.lock", args: []string{"bundle", "lock", "--update"}}
+	composerLockfile = lockfile{name: "composer.lock", args: []string{"composer", "update", "--no-install", "--no-scripts", "--no-interaction"}}
+)
+
+// Refresh re-resolves the dependencies of the SDK generated into dir to the latest versions its manifest allows and
+// pins them in its lockfile, creating the lockfile if the SDK doesn't have one yet. It returns the lockfile refreshed,
+// or an empty string if the language's package manager isn't supported or available on the runner.
+func Refresh(lang, dir string) (string, error) {
+	lf, ok := detect(lang, dir)
+	if !ok {
+		return "", nil
+	}
+
+	if _, err := exec.LookPath(lf.args[0]); err != nil {
+		logging.Info("%s not found, skipping refreshing %s", lf.args[0], lf.name)
+		return "", nil
+	}
+
+	logging.Info("Refreshing %s in %s", lf.name, dir)
+
+	cmd := exec.Command(lf.args[0], lf.args[1:]...)
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to refresh %s: error running `%s`: %w\n%s", lf.name, strings.Join(lf.args, " "), err, string(output))
+	}
+
+	return lf.name, nil
+}
+
+// detect returns the lockfile of the SDK's package manager, the lockfile it already has if it supports several
+func detect(lang, dir string) (lockfile, bool) {
+	var candidates []lockfile
+	switch lang {
+	case "typescript":
+		candidates = []lockfile{pnpmLockfile, npmLockfile}
+	case "python":
+		candidates = []lockfile{uvLockfile, poetryLockfile}
+	case "ruby":
+		candidates = []lockfile{bundleLockfile}
+	case "php":
+		candidates = []lockfile{composerLockfile}
+	default:
+		return lockfile{}, false
+	}
+
+	for _, candidate := range candidates {
+		if exists(filepath.Join(dir, candidate.name)) {
+			return candidate, true
+		}
+	}
+
+	if lang == "python" && !usesPoetry(dir) {
+		return uvLockfile, true
+	}
+
+	// The last candidate is the package manager the language's SDKs are generated for
+	return candidates[len(candidates)-1], true
+}
+
+// usesPoetry returns whether the Python SDK's pyproject.toml configures its project with Poetry
+func usesPoetry(dir string) bool {
+	data, err := os.ReadFile(filepath.Join(dir, "pyproject.toml"))
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(string(data), "[tool.poetry]")
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
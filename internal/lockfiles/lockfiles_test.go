@@ -0,0 +1,40 @@
+package lockfiles
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name  string
+		lang  string
+		files map[string]string
+		want  string
+	}{
+		{name: "npm by default", lang: "typescript", files: map[string]string{"package.json": "{}"}, want: "package-lock.json"},
+		{name: "existing pnpm lockfile", lang: "typescript", files: map[string]string{"pnpm-lock.yaml": ""}, want: "pnpm-lock.yaml"},
+		{name: "existing uv lockfile", lang: "python", files: map[string]string{"uv.lock": ""}, want: "uv.lock"},
+		{name: "poetry project", lang: "python", files: map[string]string{"pyproject.toml": "[tool.poetry]\nname = \"petstore\"\n"}, want: "poetry.lock"},
+		{name: "uv by default", lang: "python", files: map[string]string{"pyproject.toml": "[project]\nname = \"petstore\"\n"}, want: "uv.lock"},
+		{name: "ruby", lang: "ruby", want: "Gemfile.lock"},
+		{name: "php", lang: "php", want: "composer.lock"},
+		{name: "unsupported language", lang: "go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+			}
+
+			lf, ok := detect(tt.lang, dir)
+			assert.Equal(t, tt.want != "", ok)
+			assert.Equal(t, tt.want, lf.name)
+		})
+	}
+}
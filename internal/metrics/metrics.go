@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/tracing"
+)
+
+var (
+	mu        sync.Mutex
+	durations = map[string]time.Duration{}
+)
+
+// Time starts timing the named phase and a span covering it, calling the returned func records its duration
+func Time(phase string) func() {
+	start := time.Now()
+	endSpan := tracing.Start(phase)
+
+	return func() {
+		endSpan()
+		Record(phase, time.Since(start))
+	}
+}
+
+func Record(phase string, d time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	durations[phase] = d
+}
+
+func Durations() map[string]time.Duration {
+	mu.Lock()
+	defer mu.Unlock()
+
+	copied := make(map[string]time.Duration, len(durations))
+	for phase, d := range durations {
+		copied[phase] = d
+	}
+
+	return copied
+}
+
+// DurationsJSON returns the recorded durations in milliseconds keyed by phase
+func DurationsJSON() (string, error) {
+	ms := map[string]int64{}
+	for phase, d := range Durations() {
+		ms[phase] = d.Milliseconds()
+	}
+
+	data, err := json.Marshal(ms)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal durations: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// PushStatsD sends the recorded durations as StatsD timers to the given host:port, tagged DogStatsD style
+func PushStatsD(address string, tags map[string]string) error {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to statsd at %s: %w", address, err)
+	}
+	defer conn.Close()
+
+	tagStr := formatTags(tags)
+
+	for phase, d := range Durations() {
+		if _, err := fmt.Fprintf(conn, "speakeasy.sdk_generation.%s.duration:%d|ms%s", phase, d.Milliseconds(), tagStr); err != nil {
+			return fmt.Errorf("failed to send metric %s to statsd: %w", phase, err)
+		}
+	}
+
+	return nil
+}
+
+func formatTags(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, k+":"+v)
+	}
+	sort.Strings(pairs)
+
+	return "|#" + strings.Join(pairs, ",")
+}
@@ -0,0 +1,112 @@
+// Package pipeline runs an action as an ordered list of named steps, with hook points before and after each step where
+// the shell commands of the step_hooks input insert custom behavior, e.g. extra validation or custom publishing
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+	"github.com/speakeasy-api/sdk-generation-action/internal/tracing"
+)
+
+// ErrStop is returned by a step to end the pipeline early without failing it, e.g. when there's nothing to generate
+var ErrStop = errors.New("pipeline stopped")
+
+// Step is a named step of the pipeline
+type Step struct {
+	Name string
+	Run  func() error
+}
+
+// hook is custom behavior run around each step of the pipeline
+type hook interface {
+	// Before runs before the step, failing the pipeline if it returns an error
+	Before(step string) error
+	// After runs after the step with the error it failed with, failing the pipeline if it returns an error
+	After(step string, stepErr error) error
+}
+
+// Run runs the steps in order until one fails or stops the pipeline, running the shell hooks around each of them
+func Run(steps []Step, shellHooks map[string]string) error {
+	if err := checkShellHooks(steps, shellHooks); err != nil {
+		return err
+	}
+
+	stepHooks := []hook{}
+	if len(shellHooks) > 0 {
+		stepHooks = append(stepHooks, shellHook(shellHooks))
+	}
+
+	return run(steps, stepHooks)
+}
+
+func run(steps []Step, stepHooks []hook) error {
+	for _, step := range steps {
+		err := runStep(step, stepHooks)
+		if errors.Is(err, ErrStop) {
+			logging.Debug("Pipeline stopped by step %s", step.Name)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func runStep(step Step, stepHooks []hook) error {
+	endSpan := tracing.Start("step " + step.Name)
+	defer endSpan()
+
+	for _, h := range stepHooks {
+		if err := h.Before(step.Name); err != nil {
+			return fmt.Errorf("hook before step %s failed: %w", step.Name, err)
+		}
+	}
+
+	stepErr := step.Run()
+
+	// Hooks after a step that stopped the pipeline see it as successful
+	hookStepErr := stepErr
+	if errors.Is(stepErr, ErrStop) {
+		hookStepErr = nil
+	}
+	for _, h := range stepHooks {
+		if err := h.After(step.Name, hookStepErr); err != nil {
+			if stepErr != nil && !errors.Is(stepErr, ErrStop) {
+				logging.Info("Hook after step %s failed: %v", step.Name, err)
+				continue
+			}
+			return fmt.Errorf("hook after step %s failed: %w", step.Name, err)
+		}
+	}
+
+	return stepErr
+}
+
+// checkShellHooks fails on shell hooks for steps the pipeline doesn't have, which would otherwise never run
+func checkShellHooks(steps []Step, shellHooks map[string]string) error {
+	names := make([]string, len(steps))
+	known := map[string]bool{}
+	for i, step := range steps {
+		names[i] = step.Name
+		known[step.Name] = true
+	}
+
+	unknown := []string{}
+	for point := range shellHooks {
+		if _, step, _ := strings.Cut(point, ":"); !known[step] {
+			unknown = append(unknown, point)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+
+	return fmt.Errorf("step_hooks has hook points for unknown steps: %s, the steps are %s", strings.Join(unknown, ", "), strings.Join(names, ", "))
+}
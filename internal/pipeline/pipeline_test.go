@@ -0,0 +1,90 @@
+package pipeline
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingHook struct {
+	calls     *[]string
+	failAfter string
+}
+
+func (h recordingHook) Before(step string) error {
+	*h.calls = append(*h.calls, "before:"+step)
+	return nil
+}
+
+func (h recordingHook) After(step string, stepErr error) error {
+	call := "after:" + step
+	if stepErr != nil {
+		call += " (" + stepErr.Error() + ")"
+	}
+	*h.calls = append(*h.calls, call)
+
+	if step == h.failAfter {
+		return errors.New("rejected")
+	}
+	return nil
+}
+
+func TestRun(t *testing.T) {
+	calls := []string{}
+	hooks := []hook{recordingHook{calls: &calls}}
+
+	step := func(name string, err error) Step {
+		return Step{Name: name, Run: func() error {
+			calls = append(calls, name)
+			return err
+		}}
+	}
+
+	require.NoError(t, run([]Step{step("clone", nil), step("generate", ErrStop), step("commit", nil)}, hooks))
+	assert.Equal(t, []string{"before:clone", "clone", "after:clone", "before:generate", "generate", "after:generate"}, calls)
+
+	calls = []string{}
+	err := run([]Step{step("clone", errors.New("clone failed")), step("generate", nil)}, hooks)
+	assert.EqualError(t, err, "clone failed")
+	assert.Equal(t, []string{"before:clone", "clone", "after:clone (clone failed)"}, calls)
+}
+
+func TestRun_HookFails(t *testing.T) {
+	calls := []string{}
+
+	err := run([]Step{
+		{Name: "generate", Run: func() error { return nil }},
+		{Name: "commit", Run: func() error { t.Fatal("commit ran after a failed hook"); return nil }},
+	}, []hook{recordingHook{calls: &calls, failAfter: "generate"}})
+	assert.EqualError(t, err, "hook after step generate failed: rejected")
+}
+
+func TestRun_ShellHooks(t *testing.T) {
+	workspace := t.TempDir()
+	t.Setenv("GITHUB_WORKSPACE", workspace)
+
+	err := Run([]Step{
+		{Name: "generate", Run: func() error { return nil }},
+		{Name: "commit", Run: func() error { t.Fatal("commit ran after a failed hook"); return nil }},
+	}, map[string]string{
+		"before:generate": `echo "$SPEAKEASY_STEP" > hook.txt`,
+		"before:commit":   "exit 1",
+	})
+	assert.EqualError(t, err, `hook before step commit failed: failed to run "exit 1": exit status 1`)
+
+	data, err := os.ReadFile(filepath.Join(workspace, "hook.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "generate\n", string(data))
+}
+
+func TestRun_UnknownShellHookStep(t *testing.T) {
+	err := Run([]Step{{Name: "generate", Run: func() error {
+		t.Fatal("generate ran with an invalid hook")
+		return nil
+	}}}, map[string]string{"after:publish": "./publish.sh"})
+	assert.EqualError(t, err, "step_hooks has hook points for unknown steps: after:publish, the steps are generate")
+}
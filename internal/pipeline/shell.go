@@ -0,0 +1,50 @@
+package pipeline
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// shellHook runs the shell commands configured for the hook points, keyed to "before:<step>" or "after:<step>"
+type shellHook map[string]string
+
+func (h shellHook) Before(step string) error {
+	return h.run("before:"+step, step, nil)
+}
+
+func (h shellHook) After(step string, stepErr error) error {
+	return h.run("after:"+step, step, stepErr)
+}
+
+// run runs the command in the workspace with the step and the repo's directory in its environment, and the error of
+// the step for hooks after it
+func (h shellHook) run(point, step string, stepErr error) error {
+	command, ok := h[point]
+	if !ok {
+		return nil
+	}
+
+	logging.Info("Running %s hook: %s", point, command)
+
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Dir = environment.GetWorkspace()
+	cmd.Env = append(os.Environ(),
+		"SPEAKEASY_STEP="+step,
+		"SPEAKEASY_REPO_DIR="+environment.GetRepoDir(),
+	)
+	if stepErr != nil {
+		cmd.Env = append(cmd.Env, "SPEAKEASY_STEP_ERROR="+stepErr.Error())
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run %q: %w", command, err)
+	}
+
+	return nil
+}
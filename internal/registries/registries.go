@@ -0,0 +1,165 @@
+package registries
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// registryURLs are the base URLs of the registries' APIs, overridden in tests
+var registryURLs = map[string]string{
+	"npm":       "https://registry.npmjs.org",
+	"pypi":      "https://pypi.org",
+	"nuget":     "https://api.nuget.org",
+	"rubygems":  "https://rubygems.org",
+	"packagist": "https://repo.packagist.org",
+}
+
+// Preflight checks the version of the package about to be released isn't already published to the language's registry,
+// and when owners are given that an existing package is maintained by at least one of them. Languages whose registries
+// aren't supported are skipped.
+func Preflight(lang, packageName, version string, owners []string) error {
+	var published bool
+	var maintainers []string
+	var err error
+
+	switch lang {
+	case "typescript":
+		published, maintainers, err = checkNPM(packageName, version)
+	case "python":
+		published, err = checkPyPI(packageName, version)
+	case "csharp":
+		published, err = checkNuGet(packageName, version)
+	case "ruby":
+		published, maintainers, err = checkRubyGems(packageName, version)
+	case "php":
+		published, err = checkPackagist(packageName, version)
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check %s package %s in its registry: %w", lang, packageName, err)
+	}
+
+	if published {
+		return fmt.Errorf("version %s of %s package %s is already published, bump the version before releasing", version, lang, packageName)
+	}
+
+	if len(owners) > 0 && maintainers != nil && !slices.ContainsFunc(maintainers, func(m string) bool {
+		return slices.ContainsFunc(owners, func(o string) bool { return strings.EqualFold(m, o) })
+	}) {
+		return fmt.Errorf("%s package %s is maintained by %s, none of which are the expected owners %s", lang, packageName, strings.Join(maintainers, ", "), strings.Join(owners, ", "))
+	}
+
+	return nil
+}
+
+// checkNPM returns whether the version is published along with the package's maintainers, nil if it doesn't exist yet
+func checkNPM(packageName, version string) (bool, []string, error) {
+	var packument struct {
+		Versions    map[string]json.RawMessage `json:"versions"`
+		Maintainers []struct {
+			Name string `json:"name"`
+		} `json:"maintainers"`
+	}
+	found, err := getJSON(registryURLs["npm"]+"/"+url.PathEscape(packageName), &packument)
+	if err != nil || !found {
+		return false, nil, err
+	}
+
+	maintainers := []string{}
+	for _, m := range packument.Maintainers {
+		maintainers = append(maintainers, m.Name)
+	}
+
+	_, published := packument.Versions[version]
+	return published, maintainers, nil
+}
+
+func checkPyPI(packageName, version string) (bool, error) {
+	return getJSON(fmt.Sprintf("%s/pypi/%s/%s/json", registryURLs["pypi"], url.PathEscape(packageName), url.PathEscape(version)), nil)
+}
+
+func checkNuGet(packageName, version string) (bool, error) {
+	var index struct {
+		Versions []string `json:"versions"`
+	}
+	found, err := getJSON(fmt.Sprintf("%s/v3-flatcontainer/%s/index.json", registryURLs["nuget"], url.PathEscape(strings.ToLower(packageName))), &index)
+	if err != nil || !found {
+		return false, err
+	}
+
+	return slices.ContainsFunc(index.Versions, func(v string) bool { return strings.EqualFold(v, version) }), nil
+}
+
+func checkRubyGems(packageName, version string) (bool, []string, error) {
+	var owners []struct {
+		Handle string `json:"handle"`
+	}
+	found, err := getJSON(fmt.Sprintf("%s/api/v1/gems/%s/owners.json", registryURLs["rubygems"], url.PathEscape(packageName)), &owners)
+	if err != nil || !found {
+		return false, nil, err
+	}
+
+	maintainers := []string{}
+	for _, o := range owners {
+		maintainers = append(maintainers, o.Handle)
+	}
+
+	published, err := getJSON(fmt.Sprintf("%s/api/v2/rubygems/%s/versions/%s.json", registryURLs["rubygems"], url.PathEscape(packageName), url.PathEscape(version)), nil)
+	if err != nil {
+		return false, nil, err
+	}
+
+	return published, maintainers, nil
+}
+
+type packagistVersion struct {
+	Version string `json:"version"`
+}
+
+func checkPackagist(packageName, version string) (bool, error) {
+	var metadata struct {
+		Packages map[string][]packagistVersion `json:"packages"`
+	}
+	found, err := getJSON(fmt.Sprintf("%s/p2/%s.json", registryURLs["packagist"], packageName), &metadata)
+	if err != nil || !found {
+		return false, err
+	}
+
+	return slices.ContainsFunc(metadata.Packages[packageName], func(v packagistVersion) bool {
+		return strings.TrimPrefix(v.Version, "v") == version
+	}), nil
+}
+
+// getJSON fetches and decodes the URL into v if non-nil, returning false if it doesn't exist
+func getJSON(u string, v any) (bool, error) {
+	res, err := http.Get(u)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		_, _ = io.Copy(io.Discard, res.Body)
+		return false, nil
+	}
+	if res.StatusCode/100 != 2 {
+		return false, fmt.Errorf("request to %s failed: %s", u, res.Status)
+	}
+
+	if v == nil {
+		_, _ = io.Copy(io.Discard, res.Body)
+		return true, nil
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return false, fmt.Errorf("failed to decode response from %s: %w", u, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,29 @@
+package registries
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreflight_NPM(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/@acme%2Fsdk" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"versions": {"1.0.0": {}}, "maintainers": [{"name": "acme-bot"}]}`))
+	}))
+	defer server.Close()
+
+	original := registryURLs["npm"]
+	registryURLs["npm"] = server.URL
+	defer func() { registryURLs["npm"] = original }()
+
+	require.NoError(t, Preflight("typescript", "@acme/sdk", "1.1.0", []string{"acme-bot"}))
+	require.NoError(t, Preflight("typescript", "@acme/new-sdk", "0.1.0", []string{"acme-bot"}))
+	require.ErrorContains(t, Preflight("typescript", "@acme/sdk", "1.0.0", nil), "already published")
+	require.ErrorContains(t, Preflight("typescript", "@acme/sdk", "1.1.0", []string{"someone-else"}), "none of which are the expected owners")
+}
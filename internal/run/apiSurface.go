@@ -0,0 +1,46 @@
+package run
+
+import (
+	"github.com/speakeasy-api/sdk-generation-action/internal/apisurface"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// extractAPISurface extracts the public API of the target's SDK, or nil if it can't be. Failing to doesn't fail
+// generation as the changes to it are only reported.
+func extractAPISurface(lang, outputDir string) apisurface.Surface {
+	if !apisurface.Supported(lang) {
+		return nil
+	}
+
+	surface, err := apisurface.Extract(lang, outputDir)
+	if err != nil {
+		logging.Info("Failed to extract the public API of the %s SDK in %s: %v", lang, outputDir, err)
+		return nil
+	}
+
+	return surface
+}
+
+// addAPISurfaceDiff compares the public API of the target's SDK before and after regenerating, adding any changes to
+// those of its language
+func addAPISurfaceDiff(diffs map[string]apisurface.Diff, lang, outputDir string, previous apisurface.Surface) {
+	// Every symbol of a newly generated SDK is new, which isn't worth reporting
+	if len(previous) == 0 {
+		return
+	}
+
+	current := extractAPISurface(lang, outputDir)
+	if current == nil {
+		return
+	}
+
+	diff := apisurface.Compare(previous, current)
+	if diff.IsEmpty() {
+		return
+	}
+
+	langDiff := diffs[lang]
+	langDiff.Added = append(langDiff.Added, diff.Added...)
+	langDiff.Removed = append(langDiff.Removed, diff.Removed...)
+	diffs[lang] = langDiff
+}
@@ -0,0 +1,72 @@
+package run
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// nonSDKTargets are the target types that generate something other than an SDK from the spec
+var nonSDKTargets = []string{"docs", "postman"}
+
+// artifactTargets returns the non-SDK targets delivered as workflow artifacts rather than committed, which must exist
+// in the workflow and be generated into a directory of their own
+func artifactTargets(wf *workflow.Workflow) (map[string]bool, error) {
+	targetIDs := environment.GetArtifactTargets()
+	if len(targetIDs) == 0 {
+		return nil, nil
+	}
+
+	artifacts := map[string]bool{}
+	for _, targetID := range targetIDs {
+		target, ok := wf.Targets[targetID]
+		if !ok {
+			return nil, fmt.Errorf("artifact target %s not found in the workflow", targetID)
+		}
+		if !slices.Contains(nonSDKTargets, target.Target) {
+			return nil, fmt.Errorf("artifact target %s must be one of the %v targets, SDKs are committed to be published", targetID, nonSDKTargets)
+		}
+		if targetOutputDir(target) == "." {
+			return nil, fmt.Errorf("artifact target %s must be generated into a directory of its own", targetID)
+		}
+
+		artifacts[targetID] = true
+	}
+
+	return artifacts, nil
+}
+
+// artifactTargetDirs returns the output directories of the artifact targets, relative to the working directory
+func artifactTargetDirs(wf *workflow.Workflow, artifacts map[string]bool) []string {
+	dirs := []string{}
+	for targetID := range artifacts {
+		dirs = append(dirs, targetOutputDir(wf.Targets[targetID]))
+	}
+	sort.Strings(dirs)
+
+	return dirs
+}
+
+// artifactTargetChanged returns whether an artifact target's output changed, along with what changed. Their output
+// isn't committed, so its freshness is tracked by the management config in the target's committed gen.lock instead.
+func artifactTargetChanged(previous, current config.Management) (bool, string) {
+	for _, field := range []struct {
+		name              string
+		previous, current string
+	}{
+		{"doc checksum", previous.DocChecksum, current.DocChecksum},
+		{"doc version", previous.DocVersion, current.DocVersion},
+		{"config checksum", previous.ConfigChecksum, current.ConfigChecksum},
+		{"generation version", previous.GenerationVersion, current.GenerationVersion},
+	} {
+		if field.previous != field.current {
+			return true, fmt.Sprintf("%s changed from %q to %q", field.name, field.previous, field.current)
+		}
+	}
+
+	return false, ""
+}
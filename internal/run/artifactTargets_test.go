@@ -0,0 +1,47 @@
+package run
+
+import (
+	"testing"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactTargets(t *testing.T) {
+	docsDir, postmanDir := "docs", "postman/"
+	wf := &workflow.Workflow{Targets: map[string]workflow.Target{
+		"go-sdk":  {Target: "go"},
+		"docs":    {Target: "docs", Output: &docsDir},
+		"postman": {Target: "postman", Output: &postmanDir},
+		"inline":  {Target: "postman"},
+	}}
+
+	t.Setenv("INPUT_ARTIFACT_TARGETS", "docs, postman")
+	artifacts, err := artifactTargets(wf)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"docs": true, "postman": true}, artifacts)
+	assert.Equal(t, []string{"docs", "postman"}, artifactTargetDirs(wf, artifacts))
+
+	t.Setenv("INPUT_ARTIFACT_TARGETS", "go-sdk")
+	_, err = artifactTargets(wf)
+	assert.ErrorContains(t, err, "SDKs are committed to be published")
+
+	t.Setenv("INPUT_ARTIFACT_TARGETS", "inline")
+	_, err = artifactTargets(wf)
+	assert.ErrorContains(t, err, "must be generated into a directory of its own")
+}
+
+func TestArtifactTargetChanged(t *testing.T) {
+	previous := config.Management{DocChecksum: "abc", DocVersion: "1.0.0", GenerationVersion: "2.400.0"}
+
+	changed, _ := artifactTargetChanged(previous, previous)
+	assert.False(t, changed)
+
+	current := previous
+	current.DocChecksum = "def"
+	changed, msg := artifactTargetChanged(previous, current)
+	assert.True(t, changed)
+	assert.Equal(t, `doc checksum changed from "abc" to "def"`, msg)
+}
@@ -0,0 +1,76 @@
+package run
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/go-version"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/versionbumps"
+	"github.com/speakeasy-api/versioning-reports/versioning"
+)
+
+// checkVersionBumpPolicy enforces pre_1_0_semver, max_automatic_version_bump and min_spec_change_version_bump against
+// the bump the CLI determined automatically for each generated target, returning the versions to regenerate the targets
+// whose bump doesn't satisfy the policy at
+func checkVersionBumpPolicy(previousManagementInfos, currentManagementInfos map[string]config.Management) (map[string]string, error) {
+	maxBump := versioning.BumpType(environment.GetMaxAutomaticVersionBump())
+	minBump := versioning.BumpType(environment.GetMinSpecChangeVersionBump())
+
+	versions := map[string]string{}
+	for _, targetID := range slices.Sorted(maps.Keys(currentManagementInfos)) {
+		previous, current := previousManagementInfos[targetID], currentManagementInfos[targetID]
+		applied, ok := releaseBump(previous.ReleaseVersion, current.ReleaseVersion)
+		if !ok {
+			continue
+		}
+
+		bump := applied
+		if environment.PreOneSemver() && belowOne(previous.ReleaseVersion) {
+			bump = versionbumps.ShiftPreOneBump(bump)
+		}
+
+		if maxBump != "" && versionbumps.ExceedsBump(bump, maxBump) {
+			return nil, fmt.Errorf("the changes to %s require a %s version bump which exceeds max_automatic_version_bump %s, set the version_bump input to apply it", targetID, bump, maxBump)
+		}
+
+		if minBump != "" && versionbumps.ExceedsBump(minBump, bump) && specChanged(previous, current) {
+			bump = minBump
+		}
+
+		if bump == applied {
+			continue
+		}
+
+		v, err := bumpVersion(previous.ReleaseVersion, bump)
+		if err != nil {
+			return nil, err
+		}
+		versions[targetID] = v
+	}
+
+	return versions, nil
+}
+
+// specChanged returns whether the target was generated from a newer OpenAPI doc than it was previously. Targets
+// previously generated without the compared doc version or checksum are skipped, as there's nothing to compare.
+func specChanged(previous, current config.Management) bool {
+	comparison := environment.GetDocVersionComparison()
+	if comparison == environment.DocVersionChecksum && previous.DocChecksum == "" {
+		return false
+	}
+	if comparison != environment.DocVersionChecksum && previous.DocVersion == "" {
+		return false
+	}
+
+	return versionbumps.DocVersionAdvanced(comparison, previous.DocVersion, current.DocVersion, previous.DocChecksum, current.DocChecksum)
+}
+
+// belowOne returns whether the target was previously released at a version below 1.0.0
+func belowOne(previousVersion string) bool {
+	v, err := version.NewVersion(previousVersion)
+	return err == nil && v.Segments()[0] < 1
+}
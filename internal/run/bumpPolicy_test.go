@@ -0,0 +1,56 @@
+package run
+
+import (
+	"testing"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckVersionBumpPolicy(t *testing.T) {
+	previous := map[string]config.Management{
+		"go":         {ReleaseVersion: "1.2.0", DocVersion: "1.0.0"},
+		"typescript": {ReleaseVersion: "2.4.1", DocVersion: "1.0.0"},
+	}
+
+	t.Setenv("INPUT_MIN_SPEC_CHANGE_VERSION_BUMP", "minor")
+	versions, err := checkVersionBumpPolicy(previous, map[string]config.Management{
+		"go":         {ReleaseVersion: "1.2.1", DocVersion: "1.1.0"},
+		"typescript": {ReleaseVersion: "2.4.2", DocVersion: "1.0.0"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"go": "1.3.0"}, versions)
+
+	t.Setenv("INPUT_MAX_AUTOMATIC_VERSION_BUMP", "minor")
+	_, err = checkVersionBumpPolicy(previous, map[string]config.Management{
+		"go":         {ReleaseVersion: "1.2.1", DocVersion: "1.0.0"},
+		"typescript": {ReleaseVersion: "3.0.0", DocVersion: "1.0.0"},
+	})
+	assert.ErrorContains(t, err, "the changes to typescript require a major version bump which exceeds max_automatic_version_bump minor")
+}
+
+func TestCheckVersionBumpPolicy_PreOneSemver(t *testing.T) {
+	t.Setenv("INPUT_PRE_1_0_SEMVER", "true")
+
+	versions, err := checkVersionBumpPolicy(map[string]config.Management{
+		"go":         {ReleaseVersion: "0.4.2"},
+		"typescript": {ReleaseVersion: "1.4.2"},
+	}, map[string]config.Management{
+		"go":         {ReleaseVersion: "1.0.0"},
+		"typescript": {ReleaseVersion: "2.0.0"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"go": "0.5.0"}, versions)
+}
+
+func TestSpecChanged(t *testing.T) {
+	previous := config.Management{DocChecksum: "abc"}
+	current := config.Management{DocVersion: "1.0.0", DocChecksum: "def"}
+
+	assert.False(t, specChanged(previous, current))
+
+	t.Setenv("INPUT_OPENAPI_DOC_VERSION_COMPARISON", "checksum")
+	assert.True(t, specChanged(previous, current))
+	assert.False(t, specChanged(config.Management{DocVersion: "1.0.0"}, current))
+}
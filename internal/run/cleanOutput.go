@@ -0,0 +1,177 @@
+package run
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// alwaysProtected are the paths of a target's output directory clean_output never deletes, as gitignore patterns
+var alwaysProtected = []string{".speakeasy/", ".git", ".github/", ".genignore", ".gitignore", ".gitattributes"}
+
+// cleanableFiles returns the files of the target's output directory, relative to it, that are deleted before
+// generation under the policy so files no longer generated from the spec are deleted with it. Workflow paths are the
+// output directories of other targets and local sources, relative to the working directory, which are protected too.
+func cleanableFiles(policy environment.CleanOutputPolicy, outputDir string, lockFile *config.LockFile, workflowPaths []string) ([]string, error) {
+	if policy == environment.CleanOutputOff {
+		return nil, nil
+	}
+
+	if policy == environment.CleanOutputGenerated {
+		return previouslyGeneratedFiles(outputDir, lockFile, workflowPaths)
+	}
+
+	protected, err := protectedPaths(outputDir, workflowPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	err = filepath.WalkDir(outputDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		file, err := filepath.Rel(outputDir, path)
+		if err != nil || file == "." {
+			return err
+		}
+
+		if isProtected(protected, file, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			files = append(files, file)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files of %s: %w", outputDir, err)
+	}
+
+	return files, nil
+}
+
+// previouslyGeneratedFiles returns the files the last generation of the target recorded as generated in its gen.lock
+// that still exist and aren't protected, relative to its output directory
+func previouslyGeneratedFiles(outputDir string, lockFile *config.LockFile, workflowPaths []string) ([]string, error) {
+	if lockFile == nil || len(lockFile.GeneratedFiles) == 0 {
+		return nil, nil
+	}
+
+	protected, err := protectedPaths(outputDir, workflowPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for _, file := range lockFile.GeneratedFiles {
+		file = filepath.Clean(file)
+		if strings.HasPrefix(file, "..") || isProtected(protected, file, false) {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(outputDir, file)); err == nil {
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+func isProtected(protected gitignore.Matcher, file string, isDir bool) bool {
+	return protected.Match(strings.Split(filepath.ToSlash(file), "/"), isDir)
+}
+
+// protectedPaths returns a matcher of the paths of the target's output directory never deleted by clean_output or by
+// pruning orphaned files
+func protectedPaths(outputDir string, workflowPaths []string) (gitignore.Matcher, error) {
+	patterns := append([]string{}, alwaysProtected...)
+	patterns = append(patterns, environment.GetUserExtendableFiles()...)
+	patterns = append(patterns, environment.GetCleanOutputProtected()...)
+
+	// Files ignored by .genignore aren't generated, so are the user's
+	data, err := os.ReadFile(filepath.Join(outputDir, ".genignore"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read .genignore: %w", err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			patterns = append(patterns, line)
+		}
+	}
+
+	workingDir := filepath.Join(environment.GetRepoDir(), environment.GetWorkingDirectory())
+	for _, path := range workflowPaths {
+		rel, err := filepath.Rel(outputDir, filepath.Join(workingDir, path))
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		patterns = append(patterns, "/"+filepath.ToSlash(rel))
+	}
+
+	parsed := make([]gitignore.Pattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		parsed = append(parsed, gitignore.ParsePattern(pattern, nil))
+	}
+
+	return gitignore.NewMatcher(parsed), nil
+}
+
+// workflowPaths returns the output directories of the workflow's targets other than targetID and the local documents
+// of its sources, relative to the working directory
+func workflowPaths(wf *workflow.Workflow, targetID string) []string {
+	paths := []string{}
+	for id, target := range wf.Targets {
+		if id != targetID {
+			paths = append(paths, targetOutputDir(target))
+		}
+	}
+
+	documents := []workflow.Document{}
+	for _, source := range wf.Sources {
+		documents = append(documents, source.Inputs...)
+		for _, overlay := range source.Overlays {
+			if overlay.Document != nil {
+				documents = append(documents, *overlay.Document)
+			}
+		}
+		if source.Output != nil {
+			paths = append(paths, *source.Output)
+		}
+	}
+	for _, document := range documents {
+		location := document.Location.Resolve()
+		if !strings.Contains(location, "://") {
+			paths = append(paths, location)
+		}
+	}
+
+	return paths
+}
+
+// cleanOutputDirs deletes the cleanable files of each target's output directory
+func cleanOutputDirs(outputDirs map[string]string, cleanFiles map[string][]string) error {
+	for targetID, files := range cleanFiles {
+		for _, file := range files {
+			if err := os.Remove(filepath.Join(outputDirs[targetID], file)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("failed to clean %s of target %s: %w", file, targetID, err)
+			}
+		}
+		if len(files) > 0 {
+			fmt.Printf("Cleaned %d files of target %s before generation\n", len(files), targetID)
+		}
+	}
+
+	return nil
+}
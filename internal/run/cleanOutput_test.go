@@ -0,0 +1,53 @@
+package run
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanableFiles(t *testing.T) {
+	repoDir := t.TempDir()
+	t.Setenv("INPUT_REPO_DIR", repoDir)
+	t.Setenv("INPUT_WORKING_DIRECTORY", "")
+	t.Setenv("INPUT_USER_EXTENDABLE_FILES", "src/hooks/*.ts")
+	t.Setenv("INPUT_CLEAN_OUTPUT_PROTECTED", "LICENSE")
+
+	for file, content := range map[string]string{
+		".speakeasy/gen.yaml":   "",
+		".github/workflows/ci":  "",
+		".genignore":            "src/custom/\n",
+		"LICENSE":               "",
+		"openapi.yaml":          "",
+		"src/sdk.ts":            "",
+		"src/models/removed.ts": "",
+		"src/hooks/hooks.ts":    "",
+		"src/custom/helper.ts":  "",
+		"sdks/python/setup.py":  "",
+	} {
+		path := filepath.Join(repoDir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	workflowPaths := []string{"sdks/python", "openapi.yaml"}
+
+	files, err := cleanableFiles(environment.CleanOutputAll, repoDir, nil, workflowPaths)
+	require.NoError(t, err)
+	sort.Strings(files)
+	assert.Equal(t, []string{filepath.Join("src", "models", "removed.ts"), filepath.Join("src", "sdk.ts")}, files)
+
+	lockFile := &config.LockFile{GeneratedFiles: []string{"src/sdk.ts", "src/hooks/hooks.ts", "src/deleted.ts", "../outside.ts"}}
+	files, err = cleanableFiles(environment.CleanOutputGenerated, repoDir, lockFile, workflowPaths)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("src", "sdk.ts")}, files)
+
+	files, err = cleanableFiles(environment.CleanOutputOff, repoDir, lockFile, workflowPaths)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
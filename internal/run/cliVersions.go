@@ -0,0 +1,54 @@
+package run
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/cli"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// hasLanguageCLIVersions returns whether any of the targets' languages pin the CLI version they're generated with
+func hasLanguageCLIVersions(targetLangs map[string]string) bool {
+	for _, lang := range targetLangs {
+		if languageCLIVersion(lang) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func languageCLIVersion(lang string) string {
+	override := environment.GetLanguageOverrides()[lang]
+	if override.SpeakeasyVersion == nil {
+		return ""
+	}
+
+	return *override.SpeakeasyVersion
+}
+
+// useLanguageCLIVersion sets the CLI version pinned for the language, which the CLI downloads to execute `run` with,
+// returning a func restoring the version used for other languages
+func useLanguageCLIVersion(lang string) (func() error, error) {
+	pinned := languageCLIVersion(lang)
+	if pinned == "" {
+		return func() error { return nil }, nil
+	}
+
+	previous := environment.GetCLIVersionToUse()
+
+	version := ""
+	if pinned != "latest" {
+		version = cli.GetVersion(pinned)
+	}
+
+	fmt.Printf("Generating %s targets with Speakeasy CLI %s\n", lang, strings.TrimPrefix(cli.GetVersion(pinned), "v"))
+	if err := environment.SetCLIVersionToUse(version); err != nil {
+		return nil, fmt.Errorf("failed to set the %s speakeasy version: %w", lang, err)
+	}
+
+	return func() error {
+		return environment.SetCLIVersionToUse(previous)
+	}, nil
+}
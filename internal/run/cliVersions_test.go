@@ -0,0 +1,28 @@
+package run
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseLanguageCLIVersion(t *testing.T) {
+	t.Setenv("INPUT_LANGUAGE_OVERRIDES", `{"python": {"speakeasy_version": "1.300.0"}}`)
+	t.Setenv("PINNED_VERSION", "v1.400.0")
+
+	assert.True(t, hasLanguageCLIVersions(map[string]string{"sdk": "python", "other": "go"}))
+	assert.False(t, hasLanguageCLIVersions(map[string]string{"other": "go"}))
+
+	restore, err := useLanguageCLIVersion("python")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.300.0", os.Getenv("PINNED_VERSION"))
+	require.NoError(t, restore())
+	assert.Equal(t, "v1.400.0", os.Getenv("PINNED_VERSION"))
+
+	restore, err = useLanguageCLIVersion("go")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.400.0", os.Getenv("PINNED_VERSION"))
+	require.NoError(t, restore())
+}
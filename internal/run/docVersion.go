@@ -0,0 +1,43 @@
+package run
+
+import (
+	"fmt"
+	"path"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// overrideDocVersion records openapi_doc_version_override as the OpenAPI doc version in the gen.lock of each generated
+// target, in place of the placeholder info.version the CLI recorded, so the version bump policies, change detection and
+// release notes use it
+func overrideDocVersion(targetDirs map[string]string, failedTargets map[string]error) error {
+	docVersion := environment.GetOpenAPIDocVersionOverride()
+	if docVersion == "" {
+		return nil
+	}
+
+	for targetID, dir := range targetDirs {
+		if _, failed := failedTargets[targetID]; failed {
+			continue
+		}
+
+		outputDir := path.Join(environment.GetRepoDir(), dir)
+		cfg, err := config.Load(outputDir)
+		if err != nil {
+			return err
+		}
+		if cfg.LockFile == nil || cfg.LockFile.Management.DocVersion == docVersion {
+			continue
+		}
+
+		fmt.Printf("Recording OpenAPI doc version %s for target %s in place of %s\n", docVersion, targetID, cfg.LockFile.Management.DocVersion)
+
+		cfg.LockFile.Management.DocVersion = docVersion
+		if err := config.SaveLockFile(outputDir, cfg.LockFile); err != nil {
+			return fmt.Errorf("failed to save OpenAPI doc version of target %s: %w", targetID, err)
+		}
+	}
+
+	return nil
+}
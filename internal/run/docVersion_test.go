@@ -0,0 +1,37 @@
+package run
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverrideDocVersion(t *testing.T) {
+	repoDir := t.TempDir()
+	t.Setenv("INPUT_REPO_DIR", repoDir)
+	t.Setenv("INPUT_OPENAPI_DOC_VERSION_OVERRIDE", "2.4.0")
+
+	targetDirs := map[string]string{"go": "go", "python": "python"}
+	for _, dir := range targetDirs {
+		require.NoError(t, os.MkdirAll(filepath.Join(repoDir, dir, ".speakeasy"), 0o755))
+		cfg, err := config.Load(filepath.Join(repoDir, dir))
+		require.NoError(t, err)
+		cfg.LockFile.Management.DocVersion = "0.0.0"
+		require.NoError(t, config.SaveLockFile(filepath.Join(repoDir, dir), cfg.LockFile))
+	}
+
+	require.NoError(t, overrideDocVersion(targetDirs, map[string]error{"python": errors.New("failed")}))
+
+	cfg, err := config.Load(filepath.Join(repoDir, "go"))
+	require.NoError(t, err)
+	assert.Equal(t, "2.4.0", cfg.LockFile.Management.DocVersion)
+
+	cfg, err = config.Load(filepath.Join(repoDir, "python"))
+	require.NoError(t, err)
+	assert.Equal(t, "0.0.0", cfg.LockFile.Management.DocVersion)
+}
@@ -0,0 +1,91 @@
+package run
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// environmentTarget describes an SDK flavor generated from the spec source of an environment in spec_environments, for
+// publishing each flavor from a job matrix
+type environmentTarget struct {
+	Environment string `json:"environment"`
+	Target      string `json:"target"`
+	Lang        string `json:"lang"`
+	Dir         string `json:"dir"`
+	Version     string `json:"version"`
+	Regenerated bool   `json:"regenerated"`
+	Published   bool   `json:"published"`
+	NPMDistTag  string `json:"npm_dist_tag,omitempty"`
+}
+
+// specEnvironmentTargets returns the environment of each target generated from the spec source of an environment in
+// spec_environments, whose sources must exist in the workflow
+func specEnvironmentTargets(wf *workflow.Workflow) (map[string]string, error) {
+	environments := environment.GetSpecEnvironments()
+	if len(environments) == 0 {
+		return nil, nil
+	}
+
+	targetEnvironments := map[string]string{}
+	for name, env := range environments {
+		if _, ok := wf.Sources[env.Source]; !ok {
+			return nil, fmt.Errorf("source %s of environment %s not found in the workflow", env.Source, name)
+		}
+
+		for targetID, target := range wf.Targets {
+			if target.Source == env.Source {
+				targetEnvironments[targetID] = name
+			}
+		}
+	}
+
+	return targetEnvironments, nil
+}
+
+// newEnvironmentTarget describes the target generated for the environment, defaulting the npm dist-tag of TypeScript
+// flavors to that of the release channel
+func newEnvironmentTarget(name, targetID, lang, dir, version string, regenerated, published bool) environmentTarget {
+	target := environmentTarget{
+		Environment: name,
+		Target:      targetID,
+		Lang:        lang,
+		Dir:         dir,
+		Version:     version,
+		Regenerated: regenerated,
+		Published:   published,
+	}
+
+	if lang == "typescript" {
+		target.NPMDistTag = environment.GetSpecEnvironments()[name].NPMDistTag
+		if target.NPMDistTag == "" {
+			target.NPMDistTag = environment.GetReleaseChannel().NPMDistTag()
+		}
+	}
+
+	return target
+}
+
+// addEnvironmentTargetsOutput sets the environment_targets output to a JSON array of the environments' targets, sorted
+// by environment and target
+func addEnvironmentTargetsOutput(outputs map[string]string, targets []environmentTarget) error {
+	slices.SortFunc(targets, func(a, b environmentTarget) int {
+		if c := strings.Compare(a.Environment, b.Environment); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Target, b.Target)
+	})
+
+	data, err := json.Marshal(targets)
+	if err != nil {
+		return fmt.Errorf("failed to marshal environment targets: %w", err)
+	}
+
+	outputs["environment_targets"] = string(data)
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecEnvironmentTargets(t *testing.T) {
+	wf := &workflow.Workflow{
+		Sources: map[string]workflow.Source{
+			"api":         {},
+			"api-staging": {},
+		},
+		Targets: map[string]workflow.Target{
+			"typescript":         {Target: "typescript", Source: "api"},
+			"python":             {Target: "python", Source: "api"},
+			"typescript-staging": {Target: "typescript", Source: "api-staging"},
+		},
+	}
+
+	t.Setenv("INPUT_SPEC_ENVIRONMENTS", `{"production": {"source": "api"}, "staging": {"source": "api-staging", "npm_dist_tag": "staging"}}`)
+	targetEnvironments, err := specEnvironmentTargets(wf)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"typescript": "production", "python": "production", "typescript-staging": "staging"}, targetEnvironments)
+
+	t.Setenv("INPUT_SPEC_ENVIRONMENTS", `{"staging": {"source": "api-stage"}}`)
+	_, err = specEnvironmentTargets(wf)
+	assert.ErrorContains(t, err, "source api-stage of environment staging not found")
+}
+
+func TestAddEnvironmentTargetsOutput(t *testing.T) {
+	t.Setenv("INPUT_PROMOTE", "")
+	t.Setenv("INPUT_RELEASE_CHANNEL", "")
+	t.Setenv("INPUT_SPEC_ENVIRONMENTS", `{"production": {"source": "api"}, "staging": {"source": "api-staging", "npm_dist_tag": "staging"}}`)
+
+	outputs := map[string]string{}
+	err := addEnvironmentTargetsOutput(outputs, []environmentTarget{
+		newEnvironmentTarget("staging", "typescript-staging", "typescript", "staging/typescript", "0.3.0", true, true),
+		newEnvironmentTarget("production", "typescript", "typescript", "typescript", "1.2.0", false, true),
+		newEnvironmentTarget("production", "python", "python", "python", "1.2.0", true, false),
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"environment": "production", "target": "python", "lang": "python", "dir": "python", "version": "1.2.0", "regenerated": true, "published": false},
+		{"environment": "production", "target": "typescript", "lang": "typescript", "dir": "typescript", "version": "1.2.0", "regenerated": false, "published": true, "npm_dist_tag": "latest"},
+		{"environment": "staging", "target": "typescript-staging", "lang": "typescript", "dir": "staging/typescript", "version": "0.3.0", "regenerated": true, "published": true, "npm_dist_tag": "staging"}
+	]`, outputs["environment_targets"])
+}
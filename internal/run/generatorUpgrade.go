@@ -0,0 +1,19 @@
+package run
+
+import (
+	config "github.com/speakeasy-api/sdk-gen-config"
+)
+
+// generatorOnlyChange returns whether a target's regeneration was driven only by a new Speakeasy CLI or generator
+// version, rather than changes to the OpenAPI doc or gen.yaml
+func generatorOnlyChange(previous, current config.Management) bool {
+	if previous.GenerationVersion == "" && previous.SpeakeasyVersion == "" {
+		// Targets generated for the first time
+		return false
+	}
+	if previous.DocChecksum != current.DocChecksum || previous.DocVersion != current.DocVersion || previous.ConfigChecksum != current.ConfigChecksum {
+		return false
+	}
+
+	return previous.GenerationVersion != current.GenerationVersion || previous.SpeakeasyVersion != current.SpeakeasyVersion
+}
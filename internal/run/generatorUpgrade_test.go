@@ -0,0 +1,30 @@
+package run
+
+import (
+	"testing"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratorOnlyChange(t *testing.T) {
+	previous := config.Management{DocChecksum: "abc", DocVersion: "1.0.0", ConfigChecksum: "def", SpeakeasyVersion: "1.400.0", GenerationVersion: "2.450.0"}
+
+	tests := []struct {
+		name    string
+		current config.Management
+		want    bool
+	}{
+		{name: "generator upgrade", current: config.Management{DocChecksum: "abc", DocVersion: "1.0.0", ConfigChecksum: "def", SpeakeasyVersion: "1.410.0", GenerationVersion: "2.460.0"}, want: true},
+		{name: "spec change", current: config.Management{DocChecksum: "xyz", DocVersion: "1.0.0", ConfigChecksum: "def", SpeakeasyVersion: "1.410.0", GenerationVersion: "2.460.0"}},
+		{name: "config change", current: config.Management{DocChecksum: "abc", DocVersion: "1.0.0", ConfigChecksum: "ghi", SpeakeasyVersion: "1.410.0", GenerationVersion: "2.460.0"}},
+		{name: "same versions", current: previous},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, generatorOnlyChange(previous, tt.current))
+		})
+	}
+
+	assert.False(t, generatorOnlyChange(config.Management{}, previous), "first generation")
+}
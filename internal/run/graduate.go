@@ -0,0 +1,50 @@
+package run
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-version"
+	config "github.com/speakeasy-api/sdk-gen-config"
+)
+
+// graduationVersion returns the stable version to graduate the targets to, stripping the pre-release suffix from their
+// current version or graduating SDKs below 1.0.0 to 1.0.0. Every target must graduate to the same version as a single
+// version is set for the run.
+func graduationVersion(targetDirs map[string]string, previousManagementInfos map[string]config.Management) (string, error) {
+	targetIDs := make([]string, 0, len(targetDirs))
+	for targetID := range targetDirs {
+		targetIDs = append(targetIDs, targetID)
+	}
+	sort.Strings(targetIDs)
+
+	graduated := ""
+	for _, targetID := range targetIDs {
+		current := previousManagementInfos[targetID].ReleaseVersion
+		v, err := version.NewSemver(current)
+		if err != nil {
+			return "", fmt.Errorf("failed to graduate %s: its current version %q is not a semantic version, set graduate_version", targetID, current)
+		}
+
+		var targetVersion string
+		switch {
+		case v.Prerelease() != "":
+			targetVersion = v.Core().String()
+		case v.Segments()[0] == 0:
+			targetVersion = "1.0.0"
+		default:
+			return "", fmt.Errorf("failed to graduate %s: its current version %s is already stable, set graduate_version to release a specific version", targetID, current)
+		}
+
+		if graduated != "" && graduated != targetVersion {
+			return "", fmt.Errorf("targets would graduate to different versions (%s and %s), set graduate_version to graduate them to the same version", graduated, targetVersion)
+		}
+		graduated = targetVersion
+	}
+
+	if graduated == "" {
+		return "", fmt.Errorf("no targets to graduate")
+	}
+
+	return graduated, nil
+}
@@ -0,0 +1,43 @@
+package run
+
+import (
+	"testing"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraduationVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions map[string]string
+		want     string
+		wantErr  bool
+	}{
+		{name: "strips pre-release suffix", versions: map[string]string{"python": "2.0.0-beta.3"}, want: "2.0.0"},
+		{name: "graduates 0.x to 1.0.0", versions: map[string]string{"go": "0.14.2"}, want: "1.0.0"},
+		{name: "targets agree", versions: map[string]string{"go": "1.0.0-rc.1", "typescript": "0.9.0"}, want: "1.0.0"},
+		{name: "targets disagree", versions: map[string]string{"go": "2.0.0-rc.1", "typescript": "0.9.0"}, wantErr: true},
+		{name: "already stable", versions: map[string]string{"go": "1.2.3"}, wantErr: true},
+		{name: "never generated", versions: map[string]string{"go": ""}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targetDirs := map[string]string{}
+			infos := map[string]config.Management{}
+			for targetID, v := range tt.versions {
+				targetDirs[targetID] = targetID
+				infos[targetID] = config.Management{ReleaseVersion: v}
+			}
+
+			got, err := graduationVersion(targetDirs, infos)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package run
+
+import (
+	"fmt"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// applyInitialVersion writes the language's initial_version override, if any, into the target's gen.yaml when it
+// hasn't been generated before, so its first release starts from that version
+func applyInitialVersion(lang, outputDir string, cfg *config.Config) error {
+	override := environment.GetLanguageOverrides()[lang]
+	if override.InitialVersion == nil || cfg.Config == nil {
+		return nil
+	}
+
+	if cfg.LockFile != nil && cfg.LockFile.Management.ReleaseVersion != "" {
+		return nil
+	}
+
+	if cfg.Config.Languages == nil {
+		cfg.Config.Languages = map[string]config.LanguageConfig{}
+	}
+	langCfg := cfg.Config.Languages[lang]
+	langCfg.Version = *override.InitialVersion
+	cfg.Config.Languages[lang] = langCfg
+
+	fmt.Printf("Starting %s SDK in %s at version %s\n", lang, outputDir, *override.InitialVersion)
+
+	if err := config.SaveConfig(outputDir, cfg.Config); err != nil {
+		return fmt.Errorf("failed to save %s initial version: %w", lang, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package run
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCLIInstallationURL(t *testing.T) {
+	t.Setenv("INPUT_LANGUAGE_OVERRIDES", `{"python": {"published": true}, "typescript": {"published": false}}`)
+
+	assert.Equal(t, "", cliInstallationURL("python", "https://github.com/acme/sdks.git#subdirectory=python"))
+	assert.Equal(t, "https://gitpkg.now.sh/acme/sdks/typescript", cliInstallationURL("typescript", "https://gitpkg.now.sh/acme/sdks/typescript"))
+	assert.Equal(t, "https://github.com/acme/sdks/go", cliInstallationURL("go", "https://github.com/acme/sdks/go"))
+}
+
+func TestCLIInstallationURL_RunArgs(t *testing.T) {
+	baseDir := t.TempDir()
+	argsFile := filepath.Join(baseDir, "args")
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "bin"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "bin", "speakeasy"), []byte("#!/bin/sh\nprintf '%s\\n' \"$@\" > "+argsFile+"\n"), 0o755))
+
+	t.Setenv("INPUT_BASE_DIR", baseDir)
+	t.Setenv("INPUT_REPO_DIR", t.TempDir())
+	t.Setenv("INPUT_LANGUAGE_OVERRIDES", `{"python": {"published": true}}`)
+
+	installationURLs := map[string]string{}
+	for targetID, url := range map[string]string{
+		"python-sdk":     "https://github.com/acme/sdks.git#subdirectory=python",
+		"typescript-sdk": "https://gitpkg.now.sh/acme/sdks/typescript",
+	} {
+		lang, _, _ := strings.Cut(targetID, "-")
+		if url := cliInstallationURL(lang, url); url != "" {
+			installationURLs[targetID] = url
+		}
+	}
+
+	_, err := cli.Run(false, "all", installationURLs, "", map[string]string{}, nil, "")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	args := strings.Split(strings.TrimSpace(string(data)), "\n")
+	i := slices.Index(args, "--installationURLs")
+	require.NotEqual(t, -1, i)
+
+	urls := map[string]string{}
+	require.NoError(t, json.Unmarshal([]byte(args[i+1]), &urls))
+	assert.Equal(t, map[string]string{"typescript-sdk": "https://gitpkg.now.sh/acme/sdks/typescript"}, urls)
+}
@@ -0,0 +1,34 @@
+package run
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// namespacedTargets returns the targets generated from separate specs as namespaced sub-packages of a single SDK,
+// which must exist in the workflow and share a language
+func namespacedTargets(wf *workflow.Workflow) ([]string, error) {
+	targetIDs := environment.GetNamespacedTargets()
+	if len(targetIDs) == 0 {
+		return nil, nil
+	}
+
+	lang := ""
+	for _, targetID := range targetIDs {
+		target, ok := wf.Targets[targetID]
+		if !ok {
+			return nil, fmt.Errorf("namespaced target %s not found in the workflow", targetID)
+		}
+		if lang != "" && target.Target != lang {
+			return nil, fmt.Errorf("namespaced targets must share a language, found %s and %s", lang, target.Target)
+		}
+		lang = target.Target
+	}
+
+	sort.Strings(targetIDs)
+
+	return targetIDs, nil
+}
@@ -0,0 +1,30 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespacedTargets(t *testing.T) {
+	wf := &workflow.Workflow{Targets: map[string]workflow.Target{
+		"identity": {Target: "go"},
+		"billing":  {Target: "go"},
+		"cli":      {Target: "typescript"},
+	}}
+
+	t.Setenv("INPUT_NAMESPACED_TARGETS", "identity, billing")
+	targetIDs, err := namespacedTargets(wf)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"billing", "identity"}, targetIDs)
+
+	t.Setenv("INPUT_NAMESPACED_TARGETS", "billing,cli")
+	_, err = namespacedTargets(wf)
+	assert.ErrorContains(t, err, "must share a language")
+
+	t.Setenv("INPUT_NAMESPACED_TARGETS", "billing,payments")
+	_, err = namespacedTargets(wf)
+	assert.ErrorContains(t, err, "payments not found")
+}
@@ -0,0 +1,44 @@
+package run
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+)
+
+// pruneOrphanedFiles deletes the files the target's last generation produced, as listed before generation by
+// previouslyGeneratedFiles, that its new generation recorded in lockFile no longer produces, e.g. the models of a
+// schema removed from the spec. The deleted files are returned relative to the repo.
+func pruneOrphanedFiles(dir, outputDir string, previous []string, lockFile *config.LockFile) ([]string, error) {
+	// CLI versions that don't record the generated files would orphan every file
+	if lockFile == nil || len(lockFile.GeneratedFiles) == 0 {
+		return nil, nil
+	}
+
+	generated := make([]string, 0, len(lockFile.GeneratedFiles))
+	for _, file := range lockFile.GeneratedFiles {
+		generated = append(generated, filepath.Clean(file))
+	}
+
+	orphaned := []string{}
+	for _, file := range previous {
+		if slices.Contains(generated, file) {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(outputDir, file)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to delete orphaned file %s: %w", file, err)
+		}
+		orphaned = append(orphaned, path.Join(filepath.ToSlash(dir), filepath.ToSlash(file)))
+	}
+
+	sort.Strings(orphaned)
+
+	return orphaned, nil
+}
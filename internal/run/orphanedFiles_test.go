@@ -0,0 +1,33 @@
+package run
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneOrphanedFiles(t *testing.T) {
+	outputDir := t.TempDir()
+	for _, file := range []string{"src/sdk.ts", "src/models/pet.ts", "src/models/removed.ts"} {
+		path := filepath.Join(outputDir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, nil, 0o644))
+	}
+	previous := []string{filepath.Join("src", "sdk.ts"), filepath.Join("src", "models", "pet.ts"), filepath.Join("src", "models", "removed.ts")}
+
+	// Nothing is orphaned by CLI versions that don't record the generated files
+	orphaned, err := pruneOrphanedFiles("sdks/ts", outputDir, previous, &config.LockFile{})
+	require.NoError(t, err)
+	assert.Empty(t, orphaned)
+	assert.FileExists(t, filepath.Join(outputDir, "src", "models", "removed.ts"))
+
+	orphaned, err = pruneOrphanedFiles("sdks/ts", outputDir, previous, &config.LockFile{GeneratedFiles: []string{"src/sdk.ts", "./src/models/pet.ts", "src/models/new.ts"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sdks/ts/src/models/removed.ts"}, orphaned)
+	assert.NoFileExists(t, filepath.Join(outputDir, "src", "models", "removed.ts"))
+	assert.FileExists(t, filepath.Join(outputDir, "src", "models", "pet.ts"))
+}
@@ -0,0 +1,194 @@
+package run
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"golang.org/x/mod/modfile"
+)
+
+// The keys of the package metrics recorded in the management section of gen.lock
+const (
+	packageSizeKey     = "packageSize"
+	dependencyCountKey = "dependencyCount"
+)
+
+type packageMetrics struct {
+	// Size is the total size in bytes of the generated files
+	Size int64
+	// Dependencies is the number of direct dependencies of the package, or -1 if unknown for the language
+	Dependencies int
+}
+
+// measurePackage measures the size of the target's generated files and counts the dependencies declared in its
+// package manifest
+func measurePackage(lang, outputDir string, generatedFiles []string) (packageMetrics, error) {
+	metrics := packageMetrics{}
+
+	for _, file := range generatedFiles {
+		info, err := os.Stat(filepath.Join(outputDir, file))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return metrics, err
+		}
+		metrics.Size += info.Size()
+	}
+
+	dependencies, err := countDependencies(lang, outputDir)
+	if err != nil {
+		return metrics, fmt.Errorf("failed to count %s dependencies: %w", lang, err)
+	}
+	metrics.Dependencies = dependencies
+
+	return metrics, nil
+}
+
+func countDependencies(lang, outputDir string) (int, error) {
+	var manifest string
+	switch lang {
+	case "typescript":
+		manifest = "package.json"
+	case "go":
+		manifest = "go.mod"
+	case "python":
+		manifest = "pyproject.toml"
+	case "php":
+		manifest = "composer.json"
+	default:
+		return -1, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, manifest))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return -1, nil
+		}
+		return -1, err
+	}
+
+	switch lang {
+	case "typescript":
+		var pkg struct {
+			Dependencies     map[string]string `json:"dependencies"`
+			PeerDependencies map[string]string `json:"peerDependencies"`
+		}
+		if err := json.Unmarshal(data, &pkg); err != nil {
+			return -1, err
+		}
+		return len(pkg.Dependencies) + len(pkg.PeerDependencies), nil
+	case "go":
+		mod, err := modfile.ParseLax(manifest, data, nil)
+		if err != nil {
+			return -1, err
+		}
+		count := 0
+		for _, req := range mod.Require {
+			if !req.Indirect {
+				count++
+			}
+		}
+		return count, nil
+	case "python":
+		return countPyprojectDependencies(string(data)), nil
+	default:
+		var pkg struct {
+			Require map[string]string `json:"require"`
+		}
+		if err := json.Unmarshal(data, &pkg); err != nil {
+			return -1, err
+		}
+		count := 0
+		for name := range pkg.Require {
+			// The PHP version and extensions aren't packages
+			if name != "php" && !strings.HasPrefix(name, "ext-") {
+				count++
+			}
+		}
+		return count, nil
+	}
+}
+
+var (
+	pyprojectDependenciesRegex = regexp.MustCompile(`(?ms)^dependencies\s*=\s*\[(.*?)\]`)
+	pyprojectRequirementRegex  = regexp.MustCompile(`["']([^"']+)["']`)
+	poetryDependenciesRegex    = regexp.MustCompile(`(?ms)^\[tool\.poetry\.dependencies\]\s*\n(.*?)(?:^\[|\z)`)
+)
+
+// countPyprojectDependencies counts the PEP 621 project dependencies, or the poetry dependencies other than python
+func countPyprojectDependencies(pyproject string) int {
+	if match := pyprojectDependenciesRegex.FindStringSubmatch(pyproject); match != nil {
+		return len(pyprojectRequirementRegex.FindAllString(match[1], -1))
+	}
+
+	if match := poetryDependenciesRegex.FindStringSubmatch(pyproject); match != nil {
+		count := 0
+		for _, line := range strings.Split(match[1], "\n") {
+			name, _, ok := strings.Cut(line, "=")
+			if name = strings.TrimSpace(name); ok && name != "" && name != "python" && !strings.HasPrefix(name, "#") {
+				count++
+			}
+		}
+		return count
+	}
+
+	return 0
+}
+
+// checkPackageGrowth warns when regenerating grew the target's package size or dependency count by more than the
+// warning threshold since it was last generated, failing if max_package_growth is set and exceeded
+func checkPackageGrowth(targetID string, previous, current config.Management) error {
+	threshold := environment.GetMaxPackageGrowth()
+	fail := threshold > 0
+	if !fail {
+		threshold = environment.DefaultPackageGrowthWarning
+	}
+
+	for _, metric := range []struct{ key, name string }{{packageSizeKey, "size"}, {dependencyCountKey, "dependency count"}} {
+		before, ok := managementInt(previous, metric.key)
+		if !ok || before <= 0 {
+			continue
+		}
+		after, ok := managementInt(current, metric.key)
+		if !ok || after < 0 {
+			continue
+		}
+
+		growth := (after - before) * 100 / before
+		if growth <= int64(threshold) {
+			continue
+		}
+
+		msg := fmt.Sprintf("the package %s of target %s grew by %d%% (%d -> %d) since it was last generated", metric.name, targetID, growth, before, after)
+		if fail {
+			return fmt.Errorf("%s, exceeding max_package_growth of %d%%", msg, threshold)
+		}
+		ci.Warning("package growth", "Regenerating "+msg)
+	}
+
+	return nil
+}
+
+func managementInt(m config.Management, key string) (int64, bool) {
+	switch v := m.AdditionalProperties[key].(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint64:
+		return int64(v), true
+	case float64:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,44 @@
+package run
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeasurePackage(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"dependencies": {"zod": "^3.0.0"}, "peerDependencies": {"react": "^18"}, "devDependencies": {"typescript": "^5"}}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.ts"), []byte("export {};\n"), 0o644))
+
+	metrics, err := measurePackage("typescript", dir, []string{"index.ts", "removed.ts"})
+	require.NoError(t, err)
+	assert.Equal(t, packageMetrics{Size: 11, Dependencies: 2}, metrics)
+
+	metrics, err = measurePackage("ruby", dir, nil)
+	require.NoError(t, err)
+	assert.Equal(t, -1, metrics.Dependencies)
+}
+
+func TestCountPyprojectDependencies(t *testing.T) {
+	assert.Equal(t, 2, countPyprojectDependencies("[project]\nname = \"sdk\"\ndependencies = [\n  \"httpx >=0.27.0\",\n  \"pydantic ~=2.9\",\n]\n"))
+	assert.Equal(t, 2, countPyprojectDependencies("[tool.poetry.dependencies]\npython = \"^3.8\"\nhttpx = \"^0.27.0\"\npydantic = \"~2.9\"\n\n[tool.poetry.group.dev.dependencies]\nmypy = \"*\"\n"))
+}
+
+func TestCheckPackageGrowth(t *testing.T) {
+	previous := config.Management{AdditionalProperties: map[string]any{packageSizeKey: 1000, dependencyCountKey: 4}}
+	current := config.Management{AdditionalProperties: map[string]any{packageSizeKey: int64(2100), dependencyCountKey: 4}}
+
+	t.Setenv("INPUT_MAX_PACKAGE_GROWTH", "")
+	assert.NoError(t, checkPackageGrowth("sdk", previous, current))
+
+	t.Setenv("INPUT_MAX_PACKAGE_GROWTH", "50")
+	assert.EqualError(t, checkPackageGrowth("sdk", previous, current), "the package size of target sdk grew by 110% (1000 -> 2100) since it was last generated, exceeding max_package_growth of 50%")
+
+	t.Setenv("INPUT_MAX_PACKAGE_GROWTH", "200")
+	assert.NoError(t, checkPackageGrowth("sdk", previous, current))
+}
@@ -0,0 +1,74 @@
+package run
+
+import (
+	"fmt"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/utils"
+)
+
+// overridePackageName writes the language's package name override, if any, into the target's gen.yaml before generation
+func overridePackageName(lang, outputDir string, cfg *config.Config) error {
+	override := environment.GetLanguageOverrides()[lang]
+	if override.PackageName == nil || cfg.Config == nil {
+		return nil
+	}
+
+	langCfg, ok := cfg.Config.Languages[lang]
+	if !ok {
+		return fmt.Errorf("%s config not found in %s to override the package name of", lang, outputDir)
+	}
+	if langCfg.Cfg == nil {
+		langCfg.Cfg = map[string]any{}
+	}
+
+	utils.SetPackageName(lang, &langCfg, *override.PackageName)
+	cfg.Config.Languages[lang] = langCfg
+
+	if err := config.SaveConfig(outputDir, cfg.Config); err != nil {
+		return fmt.Errorf("failed to save %s package name override: %w", lang, err)
+	}
+
+	return nil
+}
+
+// configuredPackageName returns the package name in the target's gen.yaml, or an empty string if it isn't configured yet
+func configuredPackageName(lang string, cfg *config.Config) string {
+	if cfg.Config == nil {
+		return ""
+	}
+
+	langCfg, ok := cfg.Config.Languages[lang]
+	if !ok {
+		return ""
+	}
+
+	key := "packageName"
+	if lang == "java" {
+		key = "artifactID"
+	}
+	if langCfg.Cfg[key] == nil {
+		return ""
+	}
+
+	return utils.GetPackageName(lang, &langCfg)
+}
+
+// checkPackageNameCollisions fails if targets would publish packages the registry considers to have the same name.
+// This includes TypeScript flavors of different spec environments, as npm versions are global to a package whatever
+// dist-tag they're published under.
+func checkPackageNameCollisions(packageNames map[string]map[string]string) error {
+	for lang, names := range packageNames {
+		seen := map[string]string{}
+		for targetID, name := range names {
+			normalized := utils.NormalizePackageName(lang, name)
+			if other, ok := seen[normalized]; ok {
+				return fmt.Errorf("targets %s and %s would both publish the %s package %s", other, targetID, lang, name)
+			}
+			seen[normalized] = targetID
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPackageNameCollisions(t *testing.T) {
+	assert.NoError(t, checkPackageNameCollisions(map[string]map[string]string{
+		"typescript": {"sdk": "@acme/sdk", "sdk-staging": "@acme/sdk-staging"},
+		"python":     {"sdk": "acme-sdk"},
+	}))
+
+	// Flavors of different environments would publish the same npm versions
+	err := checkPackageNameCollisions(map[string]map[string]string{
+		"typescript": {"sdk": "@acme/sdk", "sdk-staging": "@acme/sdk"},
+	})
+	assert.ErrorContains(t, err, "would both publish the typescript package @acme/sdk")
+}
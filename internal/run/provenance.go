@@ -0,0 +1,145 @@
+package run
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/speakeasy-api/sdk-generation-action/internal/configuration"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// generatedFileChecksumsKey is the key of the manifest of generated files and their checksums in gen.lock
+const generatedFileChecksumsKey = "generatedFileChecksums"
+
+// recordProvenance records how the target was generated in its gen.lock alongside the CLI and generator versions and
+// doc checksum the CLI records: the checksum of the config it was generated with, so changes to the config alone are
+// detected as requiring regeneration, the checksum of each generated file, so changes made to them outside of
+// generation can be detected, and the size and dependency count of the package, so its growth can be tracked
+func recordProvenance(wf *workflow.Workflow, targetID, outputDir string, lockFile *config.LockFile) error {
+	if lockFile == nil {
+		return nil
+	}
+
+	checksum, err := configuration.GenerationConfigChecksum(readRepoFile, wf, targetID)
+	if err != nil {
+		return err
+	}
+
+	fileChecksums, err := generatedFileChecksums(outputDir, lockFile.GeneratedFiles)
+	if err != nil {
+		return err
+	}
+
+	if lockFile.Management.AdditionalProperties == nil {
+		lockFile.Management.AdditionalProperties = map[string]any{}
+	}
+	lockFile.Management.AdditionalProperties[configuration.GenerationConfigChecksumKey] = checksum
+
+	metrics, err := measurePackage(wf.Targets[targetID].Target, outputDir, lockFile.GeneratedFiles)
+	if err != nil {
+		return err
+	}
+	lockFile.Management.AdditionalProperties[packageSizeKey] = metrics.Size
+	if metrics.Dependencies >= 0 {
+		lockFile.Management.AdditionalProperties[dependencyCountKey] = metrics.Dependencies
+	}
+
+	if lockFile.AdditionalProperties == nil {
+		lockFile.AdditionalProperties = map[string]any{}
+	}
+	if len(fileChecksums) > 0 {
+		lockFile.AdditionalProperties[generatedFileChecksumsKey] = fileChecksums
+	} else {
+		delete(lockFile.AdditionalProperties, generatedFileChecksumsKey)
+	}
+
+	if err := config.SaveLockFile(outputDir, lockFile); err != nil {
+		return fmt.Errorf("failed to record generation provenance for %s: %w", targetID, err)
+	}
+
+	return nil
+}
+
+// generatedFileChecksums hashes the generated files, relative to the output directory, that exist
+func generatedFileChecksums(outputDir string, files []string) (map[string]string, error) {
+	checksums := map[string]string{}
+
+	for _, file := range files {
+		checksum, err := fileChecksum(filepath.Join(outputDir, file))
+		if err != nil {
+			return nil, err
+		}
+		if checksum != "" {
+			checksums[file] = checksum
+		}
+	}
+
+	return checksums, nil
+}
+
+// fileChecksum returns the sha256 of the file's contents, or an empty string if it doesn't exist
+func fileChecksum(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// modifiedGeneratedFiles returns the generated files in the target directory (relative to the repo root) whose contents
+// no longer match the checksums recorded when they were generated, including those since deleted. User extendable
+// files are expected to be modified so aren't included.
+func modifiedGeneratedFiles(dir, outputDir string, lockFile *config.LockFile, userExtendable []string) ([]string, error) {
+	if lockFile == nil {
+		return nil, nil
+	}
+
+	manifest, ok := lockFile.AdditionalProperties[generatedFileChecksumsKey].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	modified := []string{}
+
+	for file, recorded := range manifest {
+		repoFile := path.Join(filepath.ToSlash(dir), file)
+		if slices.Contains(userExtendable, repoFile) {
+			continue
+		}
+
+		checksum, err := fileChecksum(filepath.Join(outputDir, file))
+		if err != nil {
+			return nil, err
+		}
+		if checksum != recorded {
+			modified = append(modified, repoFile)
+		}
+	}
+
+	sort.Strings(modified)
+
+	return modified, nil
+}
+
+// readRepoFile reads a file relative to the repo root, returning nil if it doesn't exist
+func readRepoFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(environment.GetRepoDir(), path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
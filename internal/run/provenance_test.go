@@ -0,0 +1,50 @@
+package run
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedFileChecksums(t *testing.T) {
+	dir := t.TempDir()
+	contents := []byte("package sdk\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "src"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src", "sdk.go"), contents, 0o644))
+
+	checksums, err := generatedFileChecksums(dir, []string{"src/sdk.go", "removed.go"})
+	require.NoError(t, err)
+
+	sum := sha256.Sum256(contents)
+	assert.Equal(t, map[string]string{"src/sdk.go": hex.EncodeToString(sum[:])}, checksums)
+}
+
+func TestModifiedGeneratedFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unchanged.go"), []byte("unchanged"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "modified.go"), []byte("modified"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "hooks.go"), []byte("extended"), 0o644))
+
+	checksums, err := generatedFileChecksums(dir, []string{"unchanged.go", "modified.go", "deleted.go", "hooks.go"})
+	require.NoError(t, err)
+	manifest := map[string]any{}
+	for file, checksum := range checksums {
+		manifest[file] = checksum
+	}
+	manifest["deleted.go"] = "0000"
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "modified.go"), []byte("modified out of band"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "hooks.go"), []byte("extended by user"), 0o644))
+
+	lockFile := &config.LockFile{AdditionalProperties: map[string]any{generatedFileChecksumsKey: manifest}}
+
+	modified, err := modifiedGeneratedFiles("sdk", dir, lockFile, []string{"sdk/hooks.go"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sdk/deleted.go", "sdk/modified.go"}, modified)
+}
@@ -2,15 +2,23 @@ package run
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"path"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
-	"github.com/google/go-github/v63/github"
+	"github.com/speakeasy-api/sdk-generation-action/internal/apisurface"
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
+	"github.com/speakeasy-api/sdk-generation-action/internal/lockfiles"
+	"github.com/speakeasy-api/sdk-generation-action/internal/registries"
 	"github.com/speakeasy-api/sdk-generation-action/internal/utils"
+	"github.com/speakeasy-api/sdk-generation-action/internal/verify"
 	"github.com/speakeasy-api/sdk-generation-action/internal/versionbumps"
+	"github.com/speakeasy-api/sdk-generation-action/internal/workspaces"
 	"github.com/speakeasy-api/versioning-reports/versioning"
 
 	"github.com/speakeasy-api/sdk-gen-config/workflow"
@@ -18,6 +26,7 @@ import (
 	config "github.com/speakeasy-api/sdk-gen-config"
 	"github.com/speakeasy-api/sdk-generation-action/internal/cli"
 	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/metrics"
 )
 
 type LanguageGenInfo struct {
@@ -25,11 +34,20 @@ type LanguageGenInfo struct {
 	Version     string
 }
 
+// ServerStubGenInfo describes a regenerated server stub target, which is versioned separately from the SDKs
+type ServerStubGenInfo struct {
+	Path    string `json:"directory"`
+	Version string `json:"version"`
+}
+
 type GenerationInfo struct {
-	SpeakeasyVersion  string
-	GenerationVersion string
-	OpenAPIDocVersion string
-	Languages         map[string]LanguageGenInfo
+	SpeakeasyVersion   string
+	GenerationVersion  string
+	OpenAPIDocVersion  string
+	OpenAPIDocChecksum string
+	Languages          map[string]LanguageGenInfo
+	// ServerStubs are the regenerated server stub targets, by target ID
+	ServerStubs map[string]ServerStubGenInfo
 }
 
 type RunResult struct {
@@ -39,14 +57,35 @@ type RunResult struct {
 	ChangesReportURL     string
 	VersioningReport     *versioning.MergedVersionReport
 	VersioningInfo       versionbumps.VersioningInfo
+	// FailedTargets are the targets that failed to generate when on_language_failure isn't fail
+	FailedTargets map[string]error
+	// MergeConflicts are the user extendable files left with conflict markers after merging in the regenerated versions
+	MergeConflicts []string
+	// ModifiedGeneratedFiles are the generated files that were modified outside of generation, which regenerating restored
+	ModifiedGeneratedFiles []string
+	// OrphanedFiles are the files generated by the last generation that are no longer generated, which were deleted
+	OrphanedFiles []string
+	// GenerationWarnings are the parts of the OpenAPI doc the generator skipped or doesn't support
+	GenerationWarnings []cli.GenerationWarning
+	// ValidationIssues are the issues found validating the OpenAPI doc
+	ValidationIssues []cli.ValidationIssue
+	// APISurfaceDiffs are the changes to the public API of the regenerated SDKs, by language
+	APISurfaceDiffs map[string]apisurface.Diff
+	// GeneratorOnly is set when every regenerated target was regenerated only because of a new Speakeasy CLI or
+	// generator version, with PreviousGenerationVersion the generator version they were last generated with
+	GeneratorOnly             bool
+	PreviousGenerationVersion string
 }
 
 type Git interface {
 	CheckDirDirty(dir string, ignoreMap map[string]string) (bool, string, error)
+	DiscardChanges(dir string) error
+	MergeUserExtendedFiles(dir string) ([]string, error)
+	ListUserExtendableFiles(dir string) ([]string, error)
+	RemoteTags() (map[string]string, error)
 }
 
-func Run(g Git, pr *github.PullRequest, wf *workflow.Workflow) (*RunResult, map[string]string, error) {
-	workspace := environment.GetWorkspace()
+func Run(g Git, pr *ci.PullRequest, wf *workflow.Workflow) (*RunResult, map[string]string, error) {
 	outputs := map[string]string{}
 
 	executeSpeakeasyVersion, err := cli.GetSpeakeasyVersion()
@@ -62,6 +101,10 @@ func Run(g Git, pr *github.PullRequest, wf *workflow.Workflow) (*RunResult, map[
 
 	langGenerated := map[string]bool{}
 
+	// The doc version and checksum of the last regenerated target, targets generally share a source
+	docVersion := ""
+	docChecksum := ""
+
 	globalPreviousGenVersion := ""
 
 	langConfigs := map[string]*config.LanguageConfig{}
@@ -70,9 +113,18 @@ func Run(g Git, pr *github.PullRequest, wf *workflow.Workflow) (*RunResult, map[
 	repoURL := getRepoURL()
 	repoSubdirectories := map[string]string{}
 	previousManagementInfos := map[string]config.Management{}
+	modifiedFiles := []string{}
+	previousSurfaces := map[string]apisurface.Surface{}
+	apiSurfaceDiffs := map[string]apisurface.Diff{}
 
 	var manualVersioningBump *versioning.BumpType
-	if versionBump := versionbumps.GetLabelBasedVersionBump(pr); versionBump != "" && versionBump != versioning.BumpNone {
+	if versionBump := versioning.BumpType(environment.GetVersionBump()); versionBump != "" {
+		fmt.Println("Using version bump from inputs: ", versionBump)
+		manualVersioningBump = &versionBump
+	} else if versionBump := getTriggeringPRVersionBump(); versionBump != versioning.BumpNone {
+		fmt.Println("Using version bump from the triggering pull request's labels: ", versionBump)
+		manualVersioningBump = &versionBump
+	} else if versionBump := versionbumps.GetLabelBasedVersionBump(pr); versionBump != "" && versionBump != versioning.BumpNone {
 		fmt.Println("Using label based version bump: ", versionBump)
 		manualVersioningBump = &versionBump
 	}
@@ -84,10 +136,28 @@ func Run(g Git, pr *github.PullRequest, wf *workflow.Workflow) (*RunResult, map[
 		}
 
 		dir = filepath.Join(environment.GetWorkingDirectory(), dir)
-		return dir, path.Join(workspace, "repo", dir)
+		return dir, path.Join(environment.GetRepoDir(), dir)
+	}
+
+	serverStubs, err := serverStubTargets(wf)
+	if err != nil {
+		return nil, outputs, err
+	}
+	artifacts, err := artifactTargets(wf)
+	if err != nil {
+		return nil, outputs, err
+	}
+	if err := environment.SetArtifactTargetDirs(artifactTargetDirs(wf, artifacts)); err != nil {
+		return nil, outputs, fmt.Errorf("failed to set artifact target directories: %w", err)
 	}
 
 	includesTerraform := false
+	targetDirs := map[string]string{}
+	targetLangs := map[string]string{}
+	packageNames := map[string]map[string]string{}
+	outputDirs := map[string]string{}
+	cleanFiles := map[string][]string{}
+	previousGeneratedFiles := map[string][]string{}
 
 	// Load initial configs
 	for targetID, target := range wf.Targets {
@@ -97,6 +167,8 @@ func Run(g Git, pr *github.PullRequest, wf *workflow.Workflow) (*RunResult, map[
 
 		lang := target.Target
 		dir, outputDir := getDirAndOutputDir(target)
+		targetDirs[targetID] = dir
+		targetLangs[targetID] = lang
 
 		// Load the config so we can get the current version information
 		loadedCfg, err := config.Load(outputDir)
@@ -105,19 +177,69 @@ func Run(g Git, pr *github.PullRequest, wf *workflow.Workflow) (*RunResult, map[
 		}
 		previousManagementInfos[targetID] = loadedCfg.LockFile.Management
 
+		userExtendable, err := g.ListUserExtendableFiles(dir)
+		if err != nil {
+			return nil, outputs, err
+		}
+		modified, err := modifiedGeneratedFiles(dir, outputDir, loadedCfg.LockFile, userExtendable)
+		if err != nil {
+			return nil, outputs, err
+		}
+		for _, file := range modified {
+			ci.FileWarning(file, "modified generated file", file+" was modified since it was generated, regenerating will overwrite the changes")
+		}
+		modifiedFiles = append(modifiedFiles, modified...)
+
+		previousSurfaces[targetID] = extractAPISurface(lang, outputDir)
+
+		// The files are listed before generation changes them and deleted before each generation
+		outputDirs[targetID] = outputDir
+		paths := workflowPaths(wf, targetID)
+		cleanFiles[targetID], err = cleanableFiles(environment.GetCleanOutput(), outputDir, loadedCfg.LockFile, paths)
+		if err != nil {
+			return nil, outputs, err
+		}
+		if environment.PruneOrphanedFiles() {
+			previousGeneratedFiles[targetID], err = previouslyGeneratedFiles(outputDir, loadedCfg.LockFile, paths)
+			if err != nil {
+				return nil, outputs, err
+			}
+		}
+
+		if err := overridePackageName(lang, outputDir, loadedCfg); err != nil {
+			return nil, outputs, err
+		}
+		if err := applyInitialVersion(lang, outputDir, loadedCfg); err != nil {
+			return nil, outputs, err
+		}
+		if name := configuredPackageName(lang, loadedCfg); name != "" && !serverStubs[targetID] {
+			if packageNames[lang] == nil {
+				packageNames[lang] = map[string]string{}
+			}
+			packageNames[lang][targetID] = name
+		}
+
 		globalPreviousGenVersion, err = getPreviousGenVersion(loadedCfg.LockFile, lang, globalPreviousGenVersion)
 		if err != nil {
 			return nil, outputs, err
 		}
 
-		fmt.Printf("Generating %s SDK in %s", lang, outputDir)
+		if serverStubs[targetID] {
+			// Server stubs aren't published so have no installation instructions
+			fmt.Printf("Generating %s server stubs in %s", lang, outputDir)
+		} else {
+			fmt.Printf("Generating %s SDK in %s", lang, outputDir)
 
-		installationURL := getInstallationURL(lang, dir)
+			installationURL := getInstallationURL(lang, dir)
+			if override := environment.GetLanguageOverrides()[lang]; override.InstallationURL != nil {
+				installationURL = *override.InstallationURL
+			}
 
-		AddTargetPublishOutputs(target, outputs, &installationURL)
+			AddTargetPublishOutputs(target, outputs, &installationURL)
 
-		if installationURL != "" {
-			installationURLs[targetID] = installationURL
+			if url := cliInstallationURL(lang, installationURL); url != "" {
+				installationURLs[targetID] = url
+			}
 		}
 		if dir != "." {
 			repoSubdirectories[targetID] = filepath.Clean(dir)
@@ -129,19 +251,112 @@ func Run(g Git, pr *github.PullRequest, wf *workflow.Workflow) (*RunResult, map[
 		}
 	}
 
+	targetEnvironments, err := specEnvironmentTargets(wf)
+	if err != nil {
+		return nil, outputs, err
+	}
+	if err := checkPackageNameCollisions(packageNames); err != nil {
+		return nil, outputs, err
+	}
+
+	namespaces, err := namespacedTargets(wf)
+	if err != nil {
+		return nil, outputs, err
+	}
+	webhookGroups, err := webhookTargetGroups(wf)
+	if err != nil {
+		return nil, outputs, err
+	}
+	webhookTargets := environment.GetWebhookTargets()
+	webhookDirectories := map[string]string{}
+
+	// Namespaced targets make up a single SDK and webhook targets are released with their SDK targets, so each group is
+	// released together under a single version
+	releaseGroups := append([][]string{}, webhookGroups...)
+	if len(namespaces) > 0 {
+		releaseGroups = append(releaseGroups, namespaces)
+	}
+
+	if environment.GetAction() == environment.ActionGraduate && environment.SetVersion() == "" {
+		graduateVersion, err := graduationVersion(targetDirs, previousManagementInfos)
+		if err != nil {
+			return nil, outputs, err
+		}
+		if err := environment.SetVersionOverride(graduateVersion); err != nil {
+			return nil, outputs, fmt.Errorf("failed to set graduation version: %w", err)
+		}
+	}
+
 	// Run the workflow
 	var runRes *cli.RunResults
 	var changereport *versioning.MergedVersionReport
+	failedTargets := map[string]error{}
 
-	changereport, runRes, err = versioning.WithVersionReportCapture[*cli.RunResults](context.Background(), func(ctx context.Context) (*cli.RunResults, error) {
-		return cli.Run(wf.Targets == nil || len(wf.Targets) == 0, installationURLs, repoURL, repoSubdirectories, manualVersioningBump)
-	})
+	sourcesOnly := wf.Targets == nil || len(wf.Targets) == 0
+
+	// targetVersions are the versions to generate targets at in place of the versions the CLI bumps them to, which
+	// requires generating them individually
+	generate := func(versionBump *versioning.BumpType, targetVersions map[string]string) error {
+		if err := cleanOutputDirs(outputDirs, cleanFiles); err != nil {
+			return err
+		}
+
+		isolateFailures := len(targetDirs) > 1 && environment.GetLanguageFailurePolicy() != environment.LanguageFailureFail
+		// Targets whose language pins a CLI version are generated separately with that version
+		if sourcesOnly || (!isolateFailures && !hasLanguageCLIVersions(targetLangs) && len(targetVersions) == 0) {
+			target := environment.SpecifiedTarget()
+			if target == "" {
+				target = "all"
+			}
+
+			changereport, runRes, err = versioning.WithVersionReportCapture[*cli.RunResults](context.Background(), func(ctx context.Context) (*cli.RunResults, error) {
+				return cli.Run(sourcesOnly, target, installationURLs, repoURL, repoSubdirectories, versionBump, "")
+			})
+		} else {
+			changereport, runRes, failedTargets, err = runTargetsIndividually(g, targetDirs, targetLangs, isolateFailures, installationURLs, repoURL, repoSubdirectories, versionBump, targetVersions)
+		}
+		if err != nil || sourcesOnly {
+			return err
+		}
+		return overrideDocVersion(targetDirs, failedTargets)
+	}
+
+	stopTimer := metrics.Time("generation")
+	err = generate(manualVersioningBump, nil)
+	if err == nil && !sourcesOnly {
+		manualBump := manualVersioningBump != nil || environment.SetVersion() != ""
+		// Release tags that already exist are only avoided by bump-patch, the other strategies apply when releasing
+		var tags map[string]string
+		if environment.GetTagConflictStrategy() == environment.TagConflictBumpPatch {
+			tags, err = g.RemoteTags()
+		}
+		var targetVersions map[string]string
+		if err == nil {
+			targetVersions, err = plannedVersions(targetDirs, targetLangs, failedTargets, previousManagementInfos, previousSurfaces, releaseGroups, tags, manualBump)
+		}
+		if err == nil && len(targetVersions) > 0 {
+			for _, dir := range targetDirs {
+				if err = g.DiscardChanges(dir); err != nil {
+					break
+				}
+			}
+			if err == nil {
+				err = generate(manualVersioningBump, targetVersions)
+			}
+		}
+	}
+	stopTimer()
 	if err != nil {
 		return nil, outputs, err
 	}
 	if len(changereport.Reports) == 0 {
 		// Assume it's not yet enabled (e.g. CLI version too old)
 		changereport = nil
+		if !sourcesOnly {
+			if err := cli.FeatureUnavailable(cli.FeatureVersioningReports, "so generation isn't skipped when nothing changed and version bumps aren't described or checked against the version bump policies"); err != nil {
+				return nil, outputs, err
+			}
+		}
 	}
 	if changereport != nil && !changereport.MustGenerate() && !environment.ForceGeneration() && pr == nil {
 		// no further steps
@@ -152,9 +367,13 @@ func Run(g Git, pr *github.PullRequest, wf *workflow.Workflow) (*RunResult, map[
 				VersionReport: changereport,
 				ManualBump:    versionbumps.ManualBumpWasUsed(manualVersioningBump, changereport),
 			},
-			OpenAPIChangeSummary: runRes.OpenAPIChangeSummary,
-			LintingReportURL:     runRes.LintingReportURL,
-			ChangesReportURL:     runRes.ChangesReportURL,
+			OpenAPIChangeSummary:   runRes.OpenAPIChangeSummary,
+			LintingReportURL:       runRes.LintingReportURL,
+			ChangesReportURL:       runRes.ChangesReportURL,
+			FailedTargets:          failedTargets,
+			ModifiedGeneratedFiles: modifiedFiles,
+			GenerationWarnings:     runRes.GenerationWarnings,
+			ValidationIssues:       runRes.ValidationIssues,
 		}, outputs, nil
 	}
 
@@ -165,12 +384,48 @@ func Run(g Git, pr *github.PullRequest, wf *workflow.Workflow) (*RunResult, map[
 		}
 	}
 
+	if environment.UpdateWorkspaces() {
+		langDirs := map[string][]string{}
+		for targetID, target := range wf.Targets {
+			if _, ok := targetDirs[targetID]; !ok {
+				continue
+			}
+			if _, failed := failedTargets[targetID]; failed {
+				continue
+			}
+			// Workspace manifests are found in the working directory, which target directories include
+			dir, err := filepath.Rel(environment.GetWorkingDirectory(), targetDirs[targetID])
+			if err != nil {
+				return nil, outputs, err
+			}
+			langDirs[target.Target] = append(langDirs[target.Target], dir)
+		}
+
+		if _, err := workspaces.Update(filepath.Join(environment.GetRepoDir(), environment.GetWorkingDirectory()), langDirs); err != nil {
+			return nil, outputs, err
+		}
+	}
+
+	mergeConflicts := []string{}
+	dirtyTargets := map[string]bool{}
+	serverStubGenInfo := map[string]ServerStubGenInfo{}
+	artifactPaths := []string{}
+	envTargets := []environmentTarget{}
+	orphanedFiles := []string{}
+	// generatorOnly is cleared by any target regenerated for reasons other than a new generator version
+	generatorOnly := true
+	previousGenerationVersion := ""
+
 	// Legacy logic: check for changes + dirty-check
 	for targetID, target := range wf.Targets {
 		if environment.SpecifiedTarget() != "" && environment.SpecifiedTarget() != "all" && environment.SpecifiedTarget() != targetID {
 			continue
 		}
 
+		if _, failed := failedTargets[targetID]; failed {
+			continue
+		}
+
 		lang := target.Target
 		dir, outputDir := getDirAndOutputDir(target)
 
@@ -179,25 +434,131 @@ func Run(g Git, pr *github.PullRequest, wf *workflow.Workflow) (*RunResult, map[
 		if err != nil {
 			return nil, outputs, err
 		}
-		currentManagementInfo := loadedCfg.LockFile.Management
 		langCfg := loadedCfg.Config.Languages[lang]
-		langConfigs[lang] = &langCfg
+		// The language's outputs and release describe the SDK rather than a webhook target released with it or server
+		// stubs, which are versioned separately
+		if _, ok := webhookTargets[targetID]; ok {
+			webhookDirectories[targetID] = dir
+			if langConfigs[lang] == nil {
+				langConfigs[lang] = &langCfg
+			}
+		} else if !serverStubs[targetID] {
+			langConfigs[lang] = &langCfg
+			outputs[fmt.Sprintf("%s_directory", lang)] = dir
+		}
 
-		outputs[fmt.Sprintf("%s_directory", lang)] = dir
+		if lang == "csharp" {
+			if err := updateUnityManifest(dir, &langCfg); err != nil {
+				return nil, outputs, err
+			}
+		}
 
-		previousManagementInfo := previousManagementInfos[targetID]
-		dirty, dirtyMsg, err := g.CheckDirDirty(dir, map[string]string{
-			previousManagementInfo.ReleaseVersion:    currentManagementInfo.ReleaseVersion,
-			previousManagementInfo.GenerationVersion: currentManagementInfo.GenerationVersion,
-			previousManagementInfo.ConfigChecksum:    currentManagementInfo.ConfigChecksum,
-			previousManagementInfo.DocVersion:        currentManagementInfo.DocVersion,
-			previousManagementInfo.DocChecksum:       currentManagementInfo.DocChecksum,
-		})
+		conflicts, err := g.MergeUserExtendedFiles(dir)
+		if err != nil {
+			return nil, outputs, err
+		}
+		for _, file := range conflicts {
+			ci.FileWarning(file, "merge conflict", "Regenerating "+file+" conflicted with changes made to it")
+		}
+		// Direct mode pushes without review, so conflict markers are never committed
+		if len(conflicts) > 0 && environment.GetMode() == environment.ModeDirect {
+			return nil, outputs, fmt.Errorf("regenerating %s conflicted with changes made to it, run in pr mode to resolve the conflicts in the PR", strings.Join(conflicts, ", "))
+		}
+		mergeConflicts = append(mergeConflicts, conflicts...)
+
+		if err := verify.Target(lang, outputDir); err != nil {
+			return nil, outputs, fmt.Errorf("failed to verify %s SDK: %w", lang, err)
+		}
+
+		// Orphaned files are deleted before checking for changes, so deleting them alone regenerates the target
+		orphaned, err := pruneOrphanedFiles(dir, outputDir, previousGeneratedFiles[targetID], loadedCfg.LockFile)
 		if err != nil {
 			return nil, outputs, err
 		}
+		for _, file := range orphaned {
+			ci.FileWarning(file, "orphaned generated file", file+" is no longer generated, so was deleted")
+		}
+		orphanedFiles = append(orphanedFiles, orphaned...)
+
+		// Provenance is recorded once the output is final, so the checksums recorded match the files committed
+		if err := recordProvenance(wf, targetID, outputDir, loadedCfg.LockFile); err != nil {
+			return nil, outputs, err
+		}
+		currentManagementInfo := loadedCfg.LockFile.Management
+
+		previousManagementInfo := previousManagementInfos[targetID]
+		if err := checkPackageGrowth(targetID, previousManagementInfo, currentManagementInfo); err != nil {
+			return nil, outputs, err
+		}
+
+		var dirty bool
+		var dirtyMsg string
+		if artifacts[targetID] {
+			artifactPath, err := filepath.Rel(environment.GetWorkspace(), outputDir)
+			if err != nil {
+				return nil, outputs, err
+			}
+			artifactPaths = append(artifactPaths, artifactPath)
+
+			dirty, dirtyMsg = artifactTargetChanged(previousManagementInfo, currentManagementInfo)
+		} else {
+			dirty, dirtyMsg, err = g.CheckDirDirty(dir, map[string]string{
+				previousManagementInfo.ReleaseVersion:    currentManagementInfo.ReleaseVersion,
+				previousManagementInfo.GenerationVersion: currentManagementInfo.GenerationVersion,
+				previousManagementInfo.ConfigChecksum:    currentManagementInfo.ConfigChecksum,
+				previousManagementInfo.DocVersion:        currentManagementInfo.DocVersion,
+				previousManagementInfo.DocChecksum:       currentManagementInfo.DocChecksum,
+			})
+			if err != nil {
+				return nil, outputs, err
+			}
+		}
 
 		if dirty {
+			if generatorOnlyChange(previousManagementInfo, currentManagementInfo) {
+				previousGenerationVersion = previousManagementInfo.GenerationVersion
+			} else {
+				generatorOnly = false
+			}
+		}
+
+		if dirty && serverStubs[targetID] {
+			serverStubGenInfo[targetID] = ServerStubGenInfo{
+				Path:    dir,
+				Version: langCfg.Version,
+			}
+
+			fmt.Printf("Regenerating %s server stubs resulted in significant changes %s\n", lang, dirtyMsg)
+		} else if dirty {
+			dirtyTargets[targetID] = true
+			addAPISurfaceDiff(apiSurfaceDiffs, lang, outputDir, previousSurfaces[targetID])
+
+			// Only the lockfiles of regenerated SDKs are refreshed, so new dependency versions alone don't regenerate them
+			if environment.RefreshLockfiles() && !artifacts[targetID] {
+				refreshed, err := lockfiles.Refresh(lang, outputDir)
+				if err != nil {
+					return nil, outputs, fmt.Errorf("failed to refresh %s SDK lockfile: %w", lang, err)
+				}
+				// Generated lockfiles are recorded again so refreshing them isn't reported as modifying them
+				if refreshed != "" && slices.Contains(loadedCfg.LockFile.GeneratedFiles, refreshed) {
+					if err := recordProvenance(wf, targetID, outputDir, loadedCfg.LockFile); err != nil {
+						return nil, outputs, err
+					}
+				}
+			}
+
+			if environment.RunMockServerTests() {
+				if err := cli.Test(targetID); err != nil {
+					return nil, outputs, fmt.Errorf("%s SDK failed its tests against the mock server: %w", lang, err)
+				}
+			}
+
+			if target.IsPublished() && environment.RegistryPreflight() {
+				if err := registries.Preflight(lang, utils.GetPackageName(lang, &langCfg), langCfg.Version, environment.GetRegistryOwners()); err != nil {
+					return nil, outputs, err
+				}
+			}
+
 			langGenerated[lang] = true
 			// Set speakeasy version and generation version to what was used by the CLI
 			if currentManagementInfo.SpeakeasyVersion != "" {
@@ -206,16 +567,67 @@ func Run(g Git, pr *github.PullRequest, wf *workflow.Workflow) (*RunResult, map[
 			if currentManagementInfo.GenerationVersion != "" {
 				generationVersion = currentManagementInfo.GenerationVersion
 			}
+			if currentManagementInfo.DocVersion != "" {
+				docVersion = currentManagementInfo.DocVersion
+			}
+			if currentManagementInfo.DocChecksum != "" {
+				docChecksum = currentManagementInfo.DocChecksum
+			}
 
 			fmt.Printf("Regenerating %s SDK resulted in significant changes %s\n", lang, dirtyMsg)
 		} else {
 			fmt.Printf("Regenerating %s SDK did not result in any changes\n", lang)
 		}
+
+		if name, ok := targetEnvironments[targetID]; ok && !serverStubs[targetID] && !artifacts[targetID] {
+			installationURL := installationURLs[targetID]
+			envTargets = append(envTargets, newEnvironmentTarget(name, targetID, lang, dir, langCfg.Version, dirtyTargets[targetID], isTargetPublished(target, &installationURL)))
+		}
+	}
+
+	// Namespaced targets make up a single SDK so are released together when any of them changed, at the version they
+	// were regenerated at
+	if slices.ContainsFunc(namespaces, func(targetID string) bool { return dirtyTargets[targetID] }) {
+		langGenerated[targetLangs[namespaces[0]]] = true
+	}
+
+	// Webhook targets are released together with the SDK they handle the webhooks of when either changed, at the
+	// version they were regenerated at
+	for _, group := range webhookGroups {
+		if slices.ContainsFunc(group, func(targetID string) bool { return dirtyTargets[targetID] }) {
+			langGenerated[targetLangs[group[0]]] = true
+		}
+	}
+
+	if len(targetEnvironments) > 0 {
+		if err := addEnvironmentTargetsOutput(outputs, envTargets); err != nil {
+			return nil, outputs, err
+		}
+	}
+
+	if len(artifactPaths) > 0 {
+		sort.Strings(artifactPaths)
+		outputs["artifact_paths"] = strings.Join(artifactPaths, "\n")
+	}
+
+	if len(webhookDirectories) > 0 {
+		webhookDirectoriesJSON, err := json.Marshal(webhookDirectories)
+		if err != nil {
+			return nil, outputs, fmt.Errorf("failed to marshal webhook directories: %w", err)
+		}
+		outputs["webhook_directories"] = string(webhookDirectoriesJSON)
 	}
 
 	outputs["previous_gen_version"] = globalPreviousGenVersion
 
-	regenerated := false
+	regenerated := len(serverStubGenInfo) > 0
+	if regenerated {
+		serverStubsJSON, err := json.Marshal(serverStubGenInfo)
+		if err != nil {
+			return nil, outputs, fmt.Errorf("failed to marshal server stubs: %w", err)
+		}
+		outputs["server_stubs"] = string(serverStubsJSON)
+	}
 
 	langGenInfo := map[string]LanguageGenInfo{}
 
@@ -223,6 +635,7 @@ func Run(g Git, pr *github.PullRequest, wf *workflow.Workflow) (*RunResult, map[
 		outputs[lang+"_regenerated"] = "true"
 
 		langCfg := langConfigs[lang]
+		outputs[lang+"_version"] = langCfg.Version
 
 		langGenInfo[lang] = LanguageGenInfo{
 			PackageName: utils.GetPackageName(lang, langCfg),
@@ -236,10 +649,12 @@ func Run(g Git, pr *github.PullRequest, wf *workflow.Workflow) (*RunResult, map[
 
 	if regenerated {
 		genInfo = &GenerationInfo{
-			SpeakeasyVersion:  speakeasyVersion,
-			GenerationVersion: generationVersion,
-			// OpenAPIDocVersion: docVersion, //TODO
-			Languages: langGenInfo,
+			SpeakeasyVersion:   speakeasyVersion,
+			GenerationVersion:  generationVersion,
+			OpenAPIDocVersion:  docVersion,
+			OpenAPIDocChecksum: docChecksum,
+			Languages:          langGenInfo,
+			ServerStubs:        serverStubGenInfo,
 		}
 	}
 
@@ -249,12 +664,89 @@ func Run(g Git, pr *github.PullRequest, wf *workflow.Workflow) (*RunResult, map[
 			VersionReport: changereport,
 			ManualBump:    versionbumps.ManualBumpWasUsed(manualVersioningBump, changereport),
 		},
-		OpenAPIChangeSummary: runRes.OpenAPIChangeSummary,
-		LintingReportURL:     runRes.LintingReportURL,
-		ChangesReportURL:     runRes.ChangesReportURL,
+		OpenAPIChangeSummary:      runRes.OpenAPIChangeSummary,
+		LintingReportURL:          runRes.LintingReportURL,
+		ChangesReportURL:          runRes.ChangesReportURL,
+		FailedTargets:             failedTargets,
+		MergeConflicts:            mergeConflicts,
+		ModifiedGeneratedFiles:    modifiedFiles,
+		OrphanedFiles:             orphanedFiles,
+		GenerationWarnings:        runRes.GenerationWarnings,
+		ValidationIssues:          runRes.ValidationIssues,
+		APISurfaceDiffs:           apiSurfaceDiffs,
+		GeneratorOnly:             regenerated && generatorOnly,
+		PreviousGenerationVersion: previousGenerationVersion,
 	}, outputs, nil
 }
 
+// runTargetsIndividually runs the workflow for each target separately, with the CLI version pinned for its language if
+// any. When isolating failures one target failing to generate doesn't prevent the others from being generated, and any
+// partial changes made by the failed targets are discarded. Targets in targetVersions are generated at their version.
+func runTargetsIndividually(g Git, targetDirs, targetLangs map[string]string, isolateFailures bool, installationURLs map[string]string, repoURL string, repoSubdirectories map[string]string, manualVersioningBump *versioning.BumpType, targetVersions map[string]string) (*versioning.MergedVersionReport, *cli.RunResults, map[string]error, error) {
+	targetIDs := make([]string, 0, len(targetDirs))
+	for targetID := range targetDirs {
+		targetIDs = append(targetIDs, targetID)
+	}
+	sort.Strings(targetIDs)
+
+	changereport := &versioning.MergedVersionReport{}
+	runRes := &cli.RunResults{}
+	failedTargets := map[string]error{}
+
+	for _, targetID := range targetIDs {
+		restoreCLIVersion, err := useLanguageCLIVersion(targetLangs[targetID])
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		stopTimer := metrics.Time("generation_" + targetID)
+		report, res, err := versioning.WithVersionReportCapture[*cli.RunResults](context.Background(), func(ctx context.Context) (*cli.RunResults, error) {
+			return cli.Run(false, targetID, installationURLs, repoURL, repoSubdirectories, manualVersioningBump, targetVersions[targetID])
+		})
+		stopTimer()
+		if restoreErr := restoreCLIVersion(); restoreErr != nil {
+			return nil, nil, nil, restoreErr
+		}
+		if err != nil && !isolateFailures {
+			return nil, nil, nil, err
+		}
+		if err != nil {
+			ci.Error(targetID+" failed to generate", err.Error())
+			failedTargets[targetID] = err
+
+			if err := g.DiscardChanges(targetDirs[targetID]); err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to discard changes for failed target %s: %w", targetID, err)
+			}
+			continue
+		}
+
+		if report != nil {
+			changereport.Reports = append(changereport.Reports, report.Reports...)
+		}
+		if runRes.LintingReportURL == "" {
+			runRes.LintingReportURL = res.LintingReportURL
+		}
+		if runRes.ChangesReportURL == "" {
+			runRes.ChangesReportURL = res.ChangesReportURL
+		}
+		if runRes.OpenAPIChangeSummary == "" {
+			runRes.OpenAPIChangeSummary = res.OpenAPIChangeSummary
+		}
+		runRes.GenerationWarnings = append(runRes.GenerationWarnings, res.GenerationWarnings...)
+		// Targets generated from the same source validate it again
+		for _, issue := range res.ValidationIssues {
+			if !slices.Contains(runRes.ValidationIssues, issue) {
+				runRes.ValidationIssues = append(runRes.ValidationIssues, issue)
+			}
+		}
+	}
+
+	if len(failedTargets) == len(targetIDs) {
+		return nil, nil, nil, fmt.Errorf("every target failed to generate: %w", failedTargets[targetIDs[0]])
+	}
+
+	return changereport, runRes, failedTargets, nil
+}
+
 func getPreviousGenVersion(lockFile *config.LockFile, lang, globalPreviousGenVersion string) (string, error) {
 	previousFeatureVersions, ok := lockFile.Features[lang]
 	if !ok {
@@ -278,6 +770,17 @@ func getPreviousGenVersion(lockFile *config.LockFile, lang, globalPreviousGenVer
 	return globalPreviousGenVersion, nil
 }
 
+// cliInstallationURL returns the installation URL passed to the CLI, which renders installation instructions from the
+// repo rather than the registry for targets with one. Languages published through language_overrides are installed
+// from their registry so have none.
+func cliInstallationURL(lang, installationURL string) string {
+	if override := environment.GetLanguageOverrides()[lang]; override.Published != nil && *override.Published {
+		return ""
+	}
+
+	return installationURL
+}
+
 func getInstallationURL(lang, subdirectory string) string {
 	subdirectory = filepath.Clean(subdirectory)
 
@@ -323,11 +826,36 @@ func getInstallationURL(lang, subdirectory string) string {
 	return ""
 }
 
+// getTriggeringPRVersionBump returns the bump signalled by semver: labels on the pull request changing the OpenAPI doc
+// when the run was triggered by one
+func getTriggeringPRVersionBump() versioning.BumpType {
+	labels, err := environment.GetPullRequestLabels()
+	if err != nil {
+		fmt.Printf("Failed to read the triggering pull request's labels: %s\n", err.Error())
+		return versioning.BumpNone
+	}
+
+	return versionbumps.GetSemverLabelVersionBump(labels)
+}
+
 func getRepoURL() string {
 	return fmt.Sprintf("%s/%s.git", environment.GetGithubServerURL(), environment.GetRepo())
 }
 
 func AddTargetPublishOutputs(target workflow.Target, outputs map[string]string, installationURL *string) {
+	lang := target.Target
+	published := isTargetPublished(target, installationURL)
+
+	outputs[fmt.Sprintf("publish_%s", lang)] = fmt.Sprintf("%t", published)
+
+	if published && lang == "java" && target.Publishing.Java != nil {
+		outputs["use_sonatype_legacy"] = strconv.FormatBool(target.Publishing.Java.UseSonatypeLegacy)
+	}
+}
+
+// isTargetPublished returns whether the target's SDK is published, treating targets without an installation URL as
+// published
+func isTargetPublished(target workflow.Target, installationURL *string) bool {
 	lang := target.Target
 	published := target.IsPublished() || lang == "go"
 
@@ -336,9 +864,9 @@ func AddTargetPublishOutputs(target workflow.Target, outputs map[string]string,
 		published = true // Treat as published if we don't have an installation URL
 	}
 
-	outputs[fmt.Sprintf("publish_%s", lang)] = fmt.Sprintf("%t", published)
-
-	if published && lang == "java" && target.Publishing.Java != nil {
-		outputs["use_sonatype_legacy"] = strconv.FormatBool(target.Publishing.Java.UseSonatypeLegacy)
+	if override := environment.GetLanguageOverrides()[lang]; override.Published != nil {
+		published = *override.Published
 	}
+
+	return published
 }
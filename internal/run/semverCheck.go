@@ -0,0 +1,140 @@
+package run
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-generation-action/internal/apisurface"
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/versionbumps"
+	"github.com/speakeasy-api/versioning-reports/versioning"
+)
+
+// checkSemver enforces semver_check against the versions the targets are released at, which removing symbols from
+// their public API must bump breakingly, returning the versions to regenerate the targets that removed symbols at when
+// escalating
+func checkSemver(targetDirs, targetLangs map[string]string, previousManagementInfos map[string]config.Management, versions map[string]string, previousSurfaces map[string]apisurface.Surface, manualBump bool) (map[string]string, error) {
+	policy := environment.GetSemverCheckPolicy()
+	if policy == environment.SemverCheckOff {
+		return nil, nil
+	}
+
+	escalated := map[string]string{}
+	violations := []string{}
+
+	for _, targetID := range slices.Sorted(maps.Keys(versions)) {
+		previous := previousSurfaces[targetID]
+		if len(previous) == 0 {
+			continue
+		}
+
+		outputDir := path.Join(environment.GetRepoDir(), targetDirs[targetID])
+		current := extractAPISurface(targetLangs[targetID], outputDir)
+		if current == nil {
+			continue
+		}
+
+		removed := apisurface.Compare(previous, current).Removed
+		if len(removed) == 0 {
+			continue
+		}
+
+		previousVersion := previousManagementInfos[targetID].ReleaseVersion
+		currentVersion := versions[targetID]
+		required, ok := semverViolation(previousVersion, currentVersion)
+		if !ok {
+			continue
+		}
+
+		violation := fmt.Sprintf("%s removed %s from its public API, which requires a %s version bump but it was bumped from %s to %s", targetID, describeRemovedSymbols(removed), required, previousVersion, currentVersion)
+		violations = append(violations, violation)
+
+		escalatedVersion, err := bumpVersion(previousVersion, required)
+		if err != nil {
+			return nil, err
+		}
+		escalated[targetID] = escalatedVersion
+	}
+
+	if len(violations) == 0 {
+		return nil, nil
+	}
+
+	switch {
+	case policy == environment.SemverCheckWarn:
+		for _, violation := range violations {
+			ci.Warning("semver check", violation)
+		}
+		return nil, nil
+	case policy == environment.SemverCheckEscalate && !manualBump:
+		return escalated, nil
+	default:
+		return nil, fmt.Errorf("semver check failed, set the version_bump input to apply a breaking version bump: %s", strings.Join(violations, "; "))
+	}
+}
+
+// semverViolation returns the bump required by removing symbols from an SDK's public API and whether the bump from
+// previousVersion to currentVersion falls short of it. Removals are breaking, so bump the major version, or the minor
+// version below 1.0.0. Prereleases and versions that can't be compared are never violations.
+func semverViolation(previousVersion, currentVersion string) (versioning.BumpType, bool) {
+	previous, err := version.NewVersion(previousVersion)
+	if err != nil || previous.Prerelease() != "" {
+		return "", false
+	}
+	current, err := version.NewVersion(currentVersion)
+	if err != nil {
+		return "", false
+	}
+
+	required := versioning.BumpMajor
+	if previous.Segments()[0] < 1 {
+		required = versioning.BumpMinor
+	}
+
+	return required, versionbumps.ExceedsBump(required, appliedBump(previous, current))
+}
+
+// appliedBump returns the bump from previous to current
+func appliedBump(previous, current *version.Version) versioning.BumpType {
+	prevSegments, curSegments := previous.Segments(), current.Segments()
+
+	switch {
+	case !current.GreaterThan(previous):
+		return versioning.BumpNone
+	case curSegments[0] > prevSegments[0]:
+		return versioning.BumpMajor
+	case curSegments[0] == prevSegments[0] && curSegments[1] > prevSegments[1]:
+		return versioning.BumpMinor
+	case curSegments[0] == prevSegments[0] && curSegments[1] == prevSegments[1] && curSegments[2] > prevSegments[2]:
+		return versioning.BumpPatch
+	default:
+		return versioning.BumpPrerelease
+	}
+}
+
+// describeRemovedSymbols names the first few removed symbols for the run's annotations
+func describeRemovedSymbols(removed []string) string {
+	const maxNamed = 3
+
+	named := make([]string, 0, maxNamed)
+	for i, symbol := range removed {
+		if i == maxNamed {
+			break
+		}
+		named = append(named, fmt.Sprintf("`%s`", symbol))
+	}
+
+	description := strings.Join(named, ", ")
+	if len(removed) > maxNamed {
+		description += fmt.Sprintf(" and %d more", len(removed)-maxNamed)
+	}
+
+	return description
+}
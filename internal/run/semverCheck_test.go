@@ -0,0 +1,69 @@
+package run
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-generation-action/internal/apisurface"
+	"github.com/speakeasy-api/versioning-reports/versioning"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSemverViolation(t *testing.T) {
+	tests := []struct {
+		name             string
+		previousVersion  string
+		currentVersion   string
+		expectedRequired versioning.BumpType
+		expectedViolated bool
+	}{
+		{name: "patch bump", previousVersion: "1.2.3", currentVersion: "1.2.4", expectedRequired: versioning.BumpMajor, expectedViolated: true},
+		{name: "minor bump", previousVersion: "1.2.3", currentVersion: "1.3.0", expectedRequired: versioning.BumpMajor, expectedViolated: true},
+		{name: "major bump", previousVersion: "1.2.3", currentVersion: "2.0.0", expectedRequired: versioning.BumpMajor, expectedViolated: false},
+		{name: "no bump", previousVersion: "1.2.3", currentVersion: "1.2.3", expectedRequired: versioning.BumpMajor, expectedViolated: true},
+		{name: "patch bump below 1.0.0", previousVersion: "0.4.1", currentVersion: "0.4.2", expectedRequired: versioning.BumpMinor, expectedViolated: true},
+		{name: "minor bump below 1.0.0", previousVersion: "0.4.1", currentVersion: "0.5.0", expectedRequired: versioning.BumpMinor, expectedViolated: false},
+		{name: "prerelease", previousVersion: "2.0.0-beta.1", currentVersion: "2.0.0-beta.2", expectedViolated: false},
+		{name: "first generation", previousVersion: "", currentVersion: "0.0.1", expectedViolated: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			required, violated := semverViolation(tt.previousVersion, tt.currentVersion)
+			assert.Equal(t, tt.expectedViolated, violated)
+			if tt.expectedRequired != "" {
+				assert.Equal(t, tt.expectedRequired, required)
+			}
+		})
+	}
+}
+
+func TestDescribeRemovedSymbols(t *testing.T) {
+	assert.Equal(t, "`a`, `b`", describeRemovedSymbols([]string{"a", "b"}))
+	assert.Equal(t, "`a`, `b`, `c` and 2 more", describeRemovedSymbols([]string{"a", "b", "c", "d", "e"}))
+}
+
+func TestCheckSemver_EscalatesPerTarget(t *testing.T) {
+	repoDir := t.TempDir()
+	t.Setenv("INPUT_REPO_DIR", repoDir)
+	t.Setenv("INPUT_SEMVER_CHECK", "escalate")
+
+	for _, dir := range []string{"billing", "identity"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(repoDir, dir), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, dir, "sdk.go"), []byte("package sdk\n\ntype Client struct{}\n"), 0o644))
+	}
+
+	targetDirs := map[string]string{"billing": "billing", "identity": "identity"}
+	targetLangs := map[string]string{"billing": "go", "identity": "go"}
+	previous := map[string]config.Management{"billing": {ReleaseVersion: "1.2.0"}, "identity": {ReleaseVersion: "0.3.0"}}
+	previousSurfaces := map[string]apisurface.Surface{
+		"billing":  {"sdk.Client": true, "sdk.Invoices": true},
+		"identity": {"sdk.Client": true},
+	}
+
+	escalated, err := checkSemver(targetDirs, targetLangs, previous, map[string]string{"billing": "1.2.1", "identity": "0.3.1"}, previousSurfaces, false)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"billing": "2.0.0"}, escalated)
+}
@@ -0,0 +1,52 @@
+package run
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// serverStubTargets returns the targets generating server stubs, which must exist in the workflow and be generated into
+// a directory of their own as they're versioned separately from the SDKs and never published
+func serverStubTargets(wf *workflow.Workflow) (map[string]bool, error) {
+	targetIDs := environment.GetServerStubTargets()
+	if len(targetIDs) == 0 {
+		return nil, nil
+	}
+
+	webhookTargets := environment.GetWebhookTargets()
+	namespaces := environment.GetNamespacedTargets()
+
+	serverStubs := map[string]bool{}
+	for _, targetID := range targetIDs {
+		target, ok := wf.Targets[targetID]
+		if !ok {
+			return nil, fmt.Errorf("server stub target %s not found in the workflow", targetID)
+		}
+		if _, ok := webhookTargets[targetID]; ok || slices.Contains(namespaces, targetID) {
+			return nil, fmt.Errorf("server stub target %s can't be released with SDK targets", targetID)
+		}
+
+		dir := targetOutputDir(target)
+		for otherID, other := range wf.Targets {
+			if otherID != targetID && targetOutputDir(other) == dir {
+				return nil, fmt.Errorf("server stub target %s must be generated into a separate directory, %s is shared with target %s", targetID, dir, otherID)
+			}
+		}
+
+		serverStubs[targetID] = true
+	}
+
+	return serverStubs, nil
+}
+
+func targetOutputDir(target workflow.Target) string {
+	if target.Output == nil {
+		return "."
+	}
+
+	return filepath.Clean(*target.Output)
+}
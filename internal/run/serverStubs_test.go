@@ -0,0 +1,35 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerStubTargets(t *testing.T) {
+	sdkDir, serverDir, sharedDir := "sdk", "./server/", "server"
+	wf := &workflow.Workflow{Targets: map[string]workflow.Target{
+		"go-sdk":    {Target: "go", Output: &sdkDir},
+		"go-server": {Target: "go", Output: &serverDir},
+	}}
+
+	t.Setenv("INPUT_SERVER_STUB_TARGETS", "go-server")
+	serverStubs, err := serverStubTargets(wf)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"go-server": true}, serverStubs)
+
+	t.Setenv("INPUT_WEBHOOK_TARGETS", `{"go-server": "go-sdk"}`)
+	_, err = serverStubTargets(wf)
+	assert.ErrorContains(t, err, "can't be released with SDK targets")
+	t.Setenv("INPUT_WEBHOOK_TARGETS", "")
+
+	wf.Targets["go-docs"] = workflow.Target{Target: "docs", Output: &sharedDir}
+	_, err = serverStubTargets(wf)
+	assert.ErrorContains(t, err, "server is shared with target go-docs")
+
+	t.Setenv("INPUT_SERVER_STUB_TARGETS", "ts-server")
+	_, err = serverStubTargets(wf)
+	assert.ErrorContains(t, err, "ts-server not found")
+}
@@ -0,0 +1,48 @@
+package run
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// updateUnityManifest maintains a Unity Package Manager manifest alongside a generated C# SDK so it can be consumed
+// from Unity, keeping its name and version in sync with the SDK while preserving any other fields
+func updateUnityManifest(dir string, langCfg *config.LanguageConfig) error {
+	packageName := environment.GetUnityPackageName()
+	if packageName == "" {
+		return nil
+	}
+
+	manifestPath := filepath.Join(environment.GetRepoDir(), dir, "package.json")
+
+	manifest := map[string]any{}
+	if data, err := os.ReadFile(manifestPath); err == nil {
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return fmt.Errorf("failed to parse unity package manifest: %w", err)
+		}
+	}
+
+	manifest["name"] = packageName
+	manifest["version"] = langCfg.Version
+	if _, ok := manifest["displayName"]; !ok {
+		if displayName, ok := langCfg.Cfg["packageName"].(string); ok && displayName != "" {
+			manifest["displayName"] = displayName
+		}
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal unity package manifest: %w", err)
+	}
+
+	if err := os.WriteFile(manifestPath, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write unity package manifest: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package run
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-generation-action/internal/apisurface"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/git"
+	"github.com/speakeasy-api/sdk-generation-action/internal/versionbumps"
+	"github.com/speakeasy-api/versioning-reports/versioning"
+)
+
+// plannedVersions returns the versions to regenerate targets at in place of those the CLI generated them at, so their
+// version bumps satisfy the version bump policies and semver_check, the groups of targets released together share a
+// version and, given the origin's tags, none is released under an existing tag
+func plannedVersions(targetDirs, targetLangs map[string]string, failedTargets map[string]error, previousManagementInfos map[string]config.Management, previousSurfaces map[string]apisurface.Surface, groups [][]string, tags map[string]string, manualBump bool) (map[string]string, error) {
+	currentManagementInfos, err := generatedManagementInfos(targetDirs, failedTargets)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := map[string]string{}
+	for targetID, info := range currentManagementInfos {
+		versions[targetID] = info.ReleaseVersion
+	}
+
+	// The bump policies only apply to automatically determined bumps
+	if !manualBump {
+		policyVersions, err := checkVersionBumpPolicy(previousManagementInfos, currentManagementInfos)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(versions, policyVersions)
+	}
+
+	semverVersions, err := checkSemver(targetDirs, targetLangs, previousManagementInfos, versions, previousSurfaces, manualBump)
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(versions, semverVersions)
+
+	// Targets set to a version manually already share it
+	if environment.SetVersion() == "" {
+		groupVersions, err := alignedGroupVersions(groups, previousManagementInfos, versions)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(versions, groupVersions)
+	}
+
+	if tags != nil {
+		freeVersions, err := freeReleaseVersions(targetDirs, groups, previousManagementInfos, versions, tags)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(versions, freeVersions)
+	}
+
+	planned := map[string]string{}
+	for _, targetID := range slices.Sorted(maps.Keys(versions)) {
+		if generated := currentManagementInfos[targetID].ReleaseVersion; versions[targetID] != generated {
+			fmt.Printf("Regenerating %s at version %s rather than %s\n", targetID, versions[targetID], generated)
+			planned[targetID] = versions[targetID]
+		}
+	}
+
+	return planned, nil
+}
+
+// alignedGroupVersions returns the single version of each group of targets released together, e.g. namespaced targets
+// or an SDK and its webhook targets, for each of their generated targets. It's the highest version any of them was
+// previously released at, bumped by the largest bump any of them is released with, so it's never one already released.
+// Groups none of whose targets were bumped are left as generated.
+func alignedGroupVersions(groups [][]string, previousManagementInfos map[string]config.Management, versions map[string]string) (map[string]string, error) {
+	aligned := map[string]string{}
+	for _, group := range groups {
+		var highest *version.Version
+		bump := versioning.BumpNone
+		generated := []string{}
+
+		for _, targetID := range group {
+			v, ok := versions[targetID]
+			if !ok {
+				continue
+			}
+			generated = append(generated, targetID)
+
+			previousVersion := previousManagementInfos[targetID].ReleaseVersion
+			if previous, err := version.NewVersion(previousVersion); err == nil && (highest == nil || previous.GreaterThan(highest)) {
+				highest = previous
+			}
+			if applied, ok := releaseBump(previousVersion, v); ok && versionbumps.ExceedsBump(applied, bump) {
+				bump = applied
+			}
+		}
+
+		if highest == nil || bump == versioning.BumpNone {
+			continue
+		}
+
+		combined, err := bumpVersion(highest.Original(), bump)
+		if err != nil {
+			return nil, err
+		}
+		for _, targetID := range generated {
+			aligned[targetID] = combined
+		}
+	}
+
+	return aligned, nil
+}
+
+// freeReleaseVersions returns the next patch version whose release tag doesn't exist for each target that would be
+// released under an existing tag. The targets of a group released together move to the same version.
+func freeReleaseVersions(targetDirs map[string]string, groups [][]string, previousManagementInfos map[string]config.Management, versions, tags map[string]string) (map[string]string, error) {
+	units := [][]string{}
+	grouped := map[string]bool{}
+	for _, group := range groups {
+		units = append(units, group)
+		for _, targetID := range group {
+			grouped[targetID] = true
+		}
+	}
+	for _, targetID := range slices.Sorted(maps.Keys(versions)) {
+		if !grouped[targetID] {
+			units = append(units, []string{targetID})
+		}
+	}
+
+	free := map[string]string{}
+	for _, unit := range units {
+		// Targets at their previous release version aren't released again
+		released := []string{}
+		var highest *version.Version
+		for _, targetID := range unit {
+			v, ok := versions[targetID]
+			if !ok || v == previousManagementInfos[targetID].ReleaseVersion {
+				continue
+			}
+			released = append(released, targetID)
+			if parsed, err := version.NewVersion(v); err == nil && (highest == nil || parsed.GreaterThan(highest)) {
+				highest = parsed
+			}
+		}
+		if highest == nil {
+			continue
+		}
+
+		taken := func(v string) bool {
+			return slices.ContainsFunc(released, func(targetID string) bool {
+				_, ok := tags[git.ReleaseTag(v, targetDirs[targetID])]
+				return ok
+			})
+		}
+		if !taken(highest.Original()) {
+			continue
+		}
+
+		next, err := nextFreePatchVersion(highest.Original(), taken)
+		if err != nil {
+			return nil, err
+		}
+		for _, targetID := range released {
+			fmt.Printf("The release tag of %s at version %s already exists, releasing it at %s instead\n", targetID, versions[targetID], next)
+			free[targetID] = next
+		}
+	}
+
+	return free, nil
+}
+
+// nextFreePatchVersion returns the lowest patch version after releaseVersion that isn't taken
+func nextFreePatchVersion(releaseVersion string, taken func(v string) bool) (string, error) {
+	v, err := version.NewSemver(releaseVersion)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse version %s: %w", releaseVersion, err)
+	}
+	if v.Prerelease() != "" {
+		return "", fmt.Errorf("cannot bump the patch version of prerelease %s", releaseVersion)
+	}
+
+	segments := v.Segments()
+	for patch := segments[2] + 1; ; patch++ {
+		next := fmt.Sprintf("%d.%d.%d", segments[0], segments[1], patch)
+		if !taken(next) {
+			return next, nil
+		}
+	}
+}
+
+// generatedManagementInfos loads the management info of the targets that were generated, to compare the versions they
+// were generated at with their previous release versions
+func generatedManagementInfos(targetDirs map[string]string, failedTargets map[string]error) (map[string]config.Management, error) {
+	infos := map[string]config.Management{}
+	for targetID, dir := range targetDirs {
+		if _, failed := failedTargets[targetID]; failed {
+			continue
+		}
+
+		cfg, err := config.Load(path.Join(environment.GetRepoDir(), dir))
+		if err != nil {
+			return nil, err
+		}
+		if cfg.LockFile != nil {
+			infos[targetID] = cfg.LockFile.Management
+		}
+	}
+
+	return infos, nil
+}
+
+// releaseBump returns the bump from the previous release version to the current one, false when either can't be
+// compared, e.g. on the first generation
+func releaseBump(previousVersion, currentVersion string) (versioning.BumpType, bool) {
+	previous, err := version.NewVersion(previousVersion)
+	if err != nil {
+		return "", false
+	}
+	current, err := version.NewVersion(currentVersion)
+	if err != nil {
+		return "", false
+	}
+
+	return appliedBump(previous, current), true
+}
+
+// bumpVersion applies the bump to the version, as the CLI does when bumping it automatically
+func bumpVersion(previousVersion string, bump versioning.BumpType) (string, error) {
+	v, err := version.NewSemver(previousVersion)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse version %s: %w", previousVersion, err)
+	}
+	segments := v.Segments()
+
+	switch bump {
+	case versioning.BumpMajor:
+		return fmt.Sprintf("%d.0.0", segments[0]+1), nil
+	case versioning.BumpMinor:
+		return fmt.Sprintf("%d.%d.0", segments[0], segments[1]+1), nil
+	case versioning.BumpPatch:
+		return fmt.Sprintf("%d.%d.%d", segments[0], segments[1], segments[2]+1), nil
+	case versioning.BumpPrerelease:
+		if v.Prerelease() == "" {
+			return "", fmt.Errorf("cannot apply a prerelease bump to %s", previousVersion)
+		}
+		identifiers := strings.Split(v.Prerelease(), ".")
+		if n, err := strconv.Atoi(identifiers[len(identifiers)-1]); err == nil {
+			identifiers[len(identifiers)-1] = strconv.Itoa(n + 1)
+		} else {
+			identifiers = append(identifiers, "1")
+		}
+		return fmt.Sprintf("%s-%s", v.Core(), strings.Join(identifiers, ".")), nil
+	default:
+		return previousVersion, nil
+	}
+}
@@ -0,0 +1,74 @@
+package run
+
+import (
+	"testing"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/versioning-reports/versioning"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBumpVersion(t *testing.T) {
+	tests := []struct {
+		previous string
+		bump     versioning.BumpType
+		want     string
+	}{
+		{previous: "1.2.3", bump: versioning.BumpMajor, want: "2.0.0"},
+		{previous: "1.2.3", bump: versioning.BumpMinor, want: "1.3.0"},
+		{previous: "1.2.3", bump: versioning.BumpPatch, want: "1.2.4"},
+		{previous: "1.2.3", bump: versioning.BumpNone, want: "1.2.3"},
+		{previous: "2.0.0-beta.1", bump: versioning.BumpPrerelease, want: "2.0.0-beta.2"},
+		{previous: "2.0.0-beta", bump: versioning.BumpPrerelease, want: "2.0.0-beta.1"},
+	}
+	for _, tt := range tests {
+		got, err := bumpVersion(tt.previous, tt.bump)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, "%s bump of %s", tt.bump, tt.previous)
+	}
+
+	_, err := bumpVersion("1.2.3", versioning.BumpPrerelease)
+	assert.ErrorContains(t, err, "cannot apply a prerelease bump to 1.2.3")
+}
+
+func TestAlignedGroupVersions(t *testing.T) {
+	previous := map[string]config.Management{
+		"billing":  {ReleaseVersion: "1.3.0"},
+		"identity": {ReleaseVersion: "1.2.1"},
+		"payments": {ReleaseVersion: "1.3.0"},
+	}
+
+	aligned, err := alignedGroupVersions([][]string{{"billing", "identity"}, {"payments"}}, previous, map[string]string{
+		"billing":  "1.3.0",
+		"identity": "1.3.0",
+		"payments": "1.3.0",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"billing": "1.4.0", "identity": "1.4.0"}, aligned)
+
+	aligned, err = alignedGroupVersions([][]string{{"billing", "identity"}}, previous, map[string]string{"billing": "1.3.0", "identity": "1.2.1"})
+	require.NoError(t, err)
+	assert.Empty(t, aligned)
+}
+
+func TestFreeReleaseVersions(t *testing.T) {
+	targetDirs := map[string]string{"billing": "billing", "identity": "identity", "cli": "cli"}
+	previous := map[string]config.Management{
+		"billing":  {ReleaseVersion: "1.3.0"},
+		"identity": {ReleaseVersion: "1.3.0"},
+		"cli":      {ReleaseVersion: "0.2.0"},
+	}
+	tags := map[string]string{"identity/v1.4.0": "abc", "billing/v1.4.1": "def", "cli/v0.2.0": "123"}
+
+	free, err := freeReleaseVersions(targetDirs, [][]string{{"billing", "identity"}}, previous, map[string]string{
+		"billing":  "1.4.0",
+		"identity": "1.4.0",
+		"cli":      "0.2.0",
+	}, tags)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"billing": "1.4.2", "identity": "1.4.2"}, free)
+
+	_, err = nextFreePatchVersion("1.2.0-beta.1", func(string) bool { return false })
+	assert.ErrorContains(t, err, "cannot bump the patch version of prerelease 1.2.0-beta.1")
+}
@@ -0,0 +1,59 @@
+package run
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// webhookTargetGroups returns each SDK target with the webhook targets released together with it, which must exist in
+// the workflow and share the SDK target's language. The SDK target is first in each group.
+func webhookTargetGroups(wf *workflow.Workflow) ([][]string, error) {
+	webhookTargets := environment.GetWebhookTargets()
+	if len(webhookTargets) == 0 {
+		return nil, nil
+	}
+
+	webhookTargetIDs := make([]string, 0, len(webhookTargets))
+	for webhookTargetID := range webhookTargets {
+		webhookTargetIDs = append(webhookTargetIDs, webhookTargetID)
+	}
+	sort.Strings(webhookTargetIDs)
+
+	groups := map[string][]string{}
+	sdkTargetIDs := []string{}
+	for _, webhookTargetID := range webhookTargetIDs {
+		sdkTargetID := webhookTargets[webhookTargetID]
+
+		webhookTarget, ok := wf.Targets[webhookTargetID]
+		if !ok {
+			return nil, fmt.Errorf("webhook target %s not found in the workflow", webhookTargetID)
+		}
+		sdkTarget, ok := wf.Targets[sdkTargetID]
+		if !ok {
+			return nil, fmt.Errorf("SDK target %s of webhook target %s not found in the workflow", sdkTargetID, webhookTargetID)
+		}
+		if _, ok := webhookTargets[sdkTargetID]; ok {
+			return nil, fmt.Errorf("webhook target %s must be released with an SDK target, not webhook target %s", webhookTargetID, sdkTargetID)
+		}
+		if webhookTarget.Target != sdkTarget.Target {
+			return nil, fmt.Errorf("webhook target %s must share the language of its SDK target %s, found %s and %s", webhookTargetID, sdkTargetID, webhookTarget.Target, sdkTarget.Target)
+		}
+
+		if _, ok := groups[sdkTargetID]; !ok {
+			groups[sdkTargetID] = []string{sdkTargetID}
+			sdkTargetIDs = append(sdkTargetIDs, sdkTargetID)
+		}
+		groups[sdkTargetID] = append(groups[sdkTargetID], webhookTargetID)
+	}
+	sort.Strings(sdkTargetIDs)
+
+	sorted := make([][]string, 0, len(sdkTargetIDs))
+	for _, sdkTargetID := range sdkTargetIDs {
+		sorted = append(sorted, groups[sdkTargetID])
+	}
+
+	return sorted, nil
+}
@@ -0,0 +1,37 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/speakeasy-api/sdk-gen-config/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookTargetGroups(t *testing.T) {
+	wf := &workflow.Workflow{Targets: map[string]workflow.Target{
+		"go-sdk":         {Target: "go"},
+		"go-webhooks":    {Target: "go"},
+		"go-events":      {Target: "go"},
+		"ts-sdk":         {Target: "typescript"},
+		"ts-webhooks":    {Target: "typescript"},
+		"python-webhook": {Target: "python"},
+	}}
+
+	t.Setenv("INPUT_WEBHOOK_TARGETS", `{"go-webhooks": "go-sdk", "go-events": "go-sdk", "ts-webhooks": "ts-sdk"}`)
+	groups, err := webhookTargetGroups(wf)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"go-sdk", "go-events", "go-webhooks"}, {"ts-sdk", "ts-webhooks"}}, groups)
+
+	t.Setenv("INPUT_WEBHOOK_TARGETS", `{"python-webhook": "go-sdk"}`)
+	_, err = webhookTargetGroups(wf)
+	assert.ErrorContains(t, err, "must share the language of its SDK target go-sdk")
+
+	t.Setenv("INPUT_WEBHOOK_TARGETS", `{"go-webhooks": "go-events", "go-events": "go-sdk"}`)
+	_, err = webhookTargetGroups(wf)
+	assert.ErrorContains(t, err, "not webhook target go-events")
+
+	t.Setenv("INPUT_WEBHOOK_TARGETS", `{"ts-webhooks": "ts-client"}`)
+	_, err = webhookTargetGroups(wf)
+	assert.ErrorContains(t, err, "SDK target ts-client of webhook target ts-webhooks not found")
+}
@@ -80,6 +80,10 @@ func enrichHostName(event *shared.CliEvent) {
 }
 
 func Track(ctx context.Context, exec shared.InteractionType, fn func(ctx context.Context, event *shared.CliEvent) error) error {
+	if environment.TelemetryDisabled() {
+		return fn(ctx, &shared.CliEvent{InteractionType: exec})
+	}
+
 	// Generate a unique ID for this event
 	id, err := uuid.NewV7()
 	if err != nil {
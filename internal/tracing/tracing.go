@@ -0,0 +1,93 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/speakeasy-api/sdk-generation-action"
+
+// The pipeline doesn't thread a context through, so phases are parented to the root span via this context
+var rootCtx = context.Background()
+
+// Init exports spans to the OTLP/HTTP endpoint if one is configured, parenting them to the W3C traceparent if provided
+// so runs can be correlated with the pipeline that triggered them. The returned func flushes any pending spans.
+func Init(endpoint, traceparent string) (func(), error) {
+	if endpoint == "" {
+		return func() {}, nil
+	}
+
+	ctx := context.Background()
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return func() {}, fmt.Errorf("failed to create otlp exporter: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "sdk-generation-action"))),
+	)
+	otel.SetTracerProvider(tp)
+
+	if traceparent != "" {
+		rootCtx = propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{"traceparent": traceparent})
+	}
+
+	return func() {
+		if err := tp.Shutdown(ctx); err != nil {
+			fmt.Printf("Failed to flush traces: %v\n", err)
+		}
+	}, nil
+}
+
+// StartRoot starts the span covering the whole run, the returned func ends it recording the run's error if any
+func StartRoot(name string, attrs map[string]string) func(error) {
+	ctx, span := otel.Tracer(tracerName).Start(rootCtx, name, trace.WithAttributes(toAttributes(attrs)...))
+	rootCtx = ctx
+
+	return func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// Start starts a span for a phase of the run, the returned func ends it
+func Start(name string) func() {
+	_, span := otel.Tracer(tracerName).Start(rootCtx, name)
+
+	return func() {
+		span.End()
+	}
+}
+
+// TraceID returns the ID of the run's trace, empty if tracing isn't enabled
+func TraceID() string {
+	spanCtx := trace.SpanContextFromContext(rootCtx)
+	if !spanCtx.IsValid() || !spanCtx.IsSampled() {
+		return ""
+	}
+
+	return spanCtx.TraceID().String()
+}
+
+func toAttributes(attrs map[string]string) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for k, v := range attrs {
+		kvs = append(kvs, attribute.String(k, v))
+	}
+
+	return kvs
+}
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	config "github.com/speakeasy-api/sdk-gen-config"
 )
@@ -40,3 +42,82 @@ func GetRegistryName(lang string) string {
 	}
 	return registryName
 }
+
+var (
+	npmPackageNameRegex      = regexp.MustCompile(`^(@[a-z0-9-~][a-z0-9-._~]*/)?[a-z0-9-~][a-z0-9-._~]*$`)
+	pypiPackageNameRegex     = regexp.MustCompile(`(?i)^([a-z0-9]|[a-z0-9][a-z0-9._-]*[a-z0-9])$`)
+	pypiSeparatorsRegex      = regexp.MustCompile(`[-_.]+`)
+	nugetPackageNameRegex    = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+	gemNameRegex             = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+	composerPackageNameRegex = regexp.MustCompile(`^[a-z0-9]([_.-]?[a-z0-9]+)*/[a-z0-9](([_.]?|-{0,2})[a-z0-9]+)*$`)
+	mavenPackageNameRegex    = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)+$`)
+)
+
+// ValidatePackageName checks the package name follows the naming rules of the language's registry
+func ValidatePackageName(lang, name string) error {
+	var valid bool
+	var rule string
+
+	switch lang {
+	case "typescript":
+		valid = len(name) <= 214 && npmPackageNameRegex.MatchString(name)
+		rule = "lowercase, URL safe and optionally scoped as @scope/name"
+	case "python":
+		valid = pypiPackageNameRegex.MatchString(name)
+		rule = "letters, digits, '.', '_' and '-', starting and ending with a letter or digit"
+	case "csharp":
+		valid = len(name) <= 100 && nugetPackageNameRegex.MatchString(name)
+		rule = "letters, digits, '.', '_' and '-'"
+	case "ruby":
+		valid = gemNameRegex.MatchString(name)
+		rule = "letters, digits, '_' and '-'"
+	case "php":
+		valid = composerPackageNameRegex.MatchString(name)
+		rule = "lowercase and formatted as vendor/package"
+	case "java":
+		valid = mavenPackageNameRegex.MatchString(name)
+		rule = "formatted as groupID.artifactID"
+	default:
+		valid = name != "" && !strings.ContainsAny(name, " \t\n")
+		rule = "non-empty without whitespace"
+	}
+
+	if !valid {
+		return fmt.Errorf("%s package name %q must be %s", lang, name, rule)
+	}
+
+	return nil
+}
+
+// NormalizePackageName returns the name a registry considers the package name equivalent to, e.g. PyPI treats
+// My_Package and my-package as the same project
+func NormalizePackageName(lang, name string) string {
+	switch lang {
+	case "python":
+		return strings.ToLower(pypiSeparatorsRegex.ReplaceAllString(name, "-"))
+	case "csharp":
+		return strings.ToLower(name)
+	}
+
+	return name
+}
+
+// SetPackageName sets the package name in the language's config, splitting it into the fields languages configure it by
+func SetPackageName(lang string, cfg *config.LanguageConfig, name string) {
+	switch lang {
+	case "java":
+		lastDotIndex := strings.LastIndex(name, ".")
+		cfg.Cfg["groupID"] = name[:lastDotIndex]
+		cfg.Cfg["artifactID"] = name[lastDotIndex+1:]
+	case "terraform":
+		author, packageName, found := strings.Cut(name, "/")
+		if found {
+			cfg.Cfg["author"] = author
+			cfg.Cfg["packageName"] = packageName
+		} else {
+			cfg.Cfg["packageName"] = name
+		}
+	default:
+		cfg.Cfg["packageName"] = name
+	}
+}
@@ -38,3 +38,20 @@ func TestGetRegistryName(t *testing.T) {
 	require.Equal(t, GetRegistryName("terraform"), "terraform")
 	require.Equal(t, GetRegistryName("go"), "go")
 }
+
+func TestValidatePackageName(t *testing.T) {
+	require.NoError(t, ValidatePackageName("typescript", "@acme/sdk"))
+	require.Error(t, ValidatePackageName("typescript", "@Acme/SDK"))
+	require.NoError(t, ValidatePackageName("python", "acme_sdk.client"))
+	require.Error(t, ValidatePackageName("python", "-acme"))
+	require.NoError(t, ValidatePackageName("php", "acme/sdk"))
+	require.Error(t, ValidatePackageName("php", "sdk"))
+	require.NoError(t, ValidatePackageName("java", "com.acme.sdk"))
+	require.Error(t, ValidatePackageName("java", "sdk"))
+}
+
+func TestNormalizePackageName(t *testing.T) {
+	require.Equal(t, "acme-sdk", NormalizePackageName("python", "Acme__SDK"))
+	require.Equal(t, "acme.sdk", NormalizePackageName("csharp", "Acme.Sdk"))
+	require.Equal(t, "@acme/sdk", NormalizePackageName("typescript", "@acme/sdk"))
+}
@@ -0,0 +1,151 @@
+package verify
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// exampleCheckDir is where go examples are written within the module while they are compiled
+const exampleCheckDir = "speakeasy_example_check"
+
+var codeBlockRegex = regexp.MustCompile("(?ms)^```(\\w+)[^\\n]*\\n(.*?)^```")
+
+type example struct {
+	// Source is the markdown file, relative to the target directory, the example was found in
+	Source string
+	Code   string
+}
+
+// examples compiles the usage examples the generator emitted in the target's markdown docs, so broken examples never
+// ship in READMEs or release notes. Only go and python examples are checked.
+func examples(lang, dir string) error {
+	switch lang {
+	case "go":
+		return goExamples(dir)
+	case "python":
+		return pythonExamples(dir)
+	}
+
+	return nil
+}
+
+// goExamples builds the examples that are complete programs within the module so they compile against the generated SDK
+func goExamples(dir string) error {
+	found, err := findExamples(dir, "go")
+	if err != nil {
+		return err
+	}
+
+	checkDir := filepath.Join(dir, exampleCheckDir)
+	defer os.RemoveAll(checkDir)
+
+	programs := 0
+	for _, ex := range found {
+		if !strings.Contains(ex.Code, "package main") {
+			continue
+		}
+
+		exampleDir := filepath.Join(checkDir, fmt.Sprint(programs))
+		if err := os.MkdirAll(exampleDir, 0o755); err != nil {
+			return err
+		}
+		code := fmt.Sprintf("// Example from %s\n%s", ex.Source, ex.Code)
+		if err := os.WriteFile(filepath.Join(exampleDir, "main.go"), []byte(code), 0o644); err != nil {
+			return err
+		}
+		programs++
+	}
+
+	if programs == 0 {
+		return nil
+	}
+
+	if _, err := run(dir, "go", "build", "-o", os.DevNull, "./"+exampleCheckDir+"/..."); err != nil {
+		return fmt.Errorf("generated go usage examples failed to compile: %w", err)
+	}
+
+	return nil
+}
+
+// pythonExamples checks the examples are valid python
+func pythonExamples(dir string) error {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		logging.Info("python3 not found, skipping checking the python usage examples")
+		return nil
+	}
+
+	found, err := findExamples(dir, "python")
+	if err != nil {
+		return err
+	}
+
+	checkDir, err := os.MkdirTemp("", "speakeasy-example-check")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(checkDir)
+
+	for i, ex := range found {
+		file := filepath.Join(checkDir, fmt.Sprintf("example_%d.py", i))
+		if err := os.WriteFile(file, []byte(ex.Code), 0o644); err != nil {
+			return err
+		}
+
+		if _, err := run(checkDir, python, "-m", "py_compile", file); err != nil {
+			return fmt.Errorf("generated python usage example from %s failed to compile: %w", ex.Source, err)
+		}
+	}
+
+	return nil
+}
+
+// findExamples returns the code blocks of the language in the target's README.md, USAGE.md and docs
+func findExamples(dir, lang string) ([]example, error) {
+	files := []string{"README.md", "USAGE.md"}
+	err := filepath.WalkDir(filepath.Join(dir, "docs"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".md") {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find usage examples: %w", err)
+	}
+
+	found := []example{}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		for _, match := range codeBlockRegex.FindAllStringSubmatch(string(data), -1) {
+			if match[1] == lang {
+				found = append(found, example{Source: file, Code: match[2]})
+			}
+		}
+	}
+
+	return found, nil
+}
@@ -0,0 +1,24 @@
+package verify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindExamples(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "docs", "sdks", "pets"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# SDK\n\n```bash\ngo get example.com/sdk\n```\n\n```go\npackage main\n\nfunc main() {}\n```\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docs", "sdks", "pets", "README.md"), []byte("```go\npackage main\n\nfunc main() {\n\tlistPets()\n}\n```\n"), 0o644))
+
+	found, err := findExamples(dir, "go")
+	require.NoError(t, err)
+	assert.Equal(t, []example{
+		{Source: "README.md", Code: "package main\n\nfunc main() {}\n"},
+		{Source: filepath.Join("docs", "sdks", "pets", "README.md"), Code: "package main\n\nfunc main() {\n\tlistPets()\n}\n"},
+	}, found)
+}
@@ -0,0 +1,24 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// goModule tidies the module so go.sum matches its dependencies and vets it
+func goModule(dir string) error {
+	if !environment.GoModTidy() {
+		return nil
+	}
+
+	if _, err := run(dir, "go", "mod", "tidy"); err != nil {
+		return fmt.Errorf("failed to tidy go module: %w", err)
+	}
+
+	if _, err := run(dir, "go", "vet", "./..."); err != nil {
+		return fmt.Errorf("generated go module failed vetting: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package verify
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/hashicorp/go-version"
+	"github.com/speakeasy-api/sdk-generation-action/internal/command"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+var phpVersionRegex = regexp.MustCompile(`\d+\.\d+(\.\d+)?`)
+
+// phpPackage validates composer.json and checks the package's dependencies resolve on the minimum PHP version it declares
+func phpPackage(dir string) error {
+	if !environment.ComposerValidate() {
+		return nil
+	}
+
+	if _, err := run(dir, "composer", "validate", "--strict", "--no-check-publish"); err != nil {
+		return fmt.Errorf("composer.json failed validation: %w", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "composer.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read composer.json: %w", err)
+	}
+
+	var composer map[string]any
+	if err := json.Unmarshal(data, &composer); err != nil {
+		return fmt.Errorf("failed to parse composer.json: %w", err)
+	}
+
+	require, _ := composer["require"].(map[string]any)
+	constraint, _ := require["php"].(string)
+	minPHP := minimumPHPVersion(constraint)
+	if minPHP == "" {
+		return nil
+	}
+
+	// Resolve against a copy of composer.json with the platform pinned to the minimum PHP version, so the package's own
+	// config is untouched
+	config, _ := composer["config"].(map[string]any)
+	if config == nil {
+		config = map[string]any{}
+	}
+	platform, _ := config["platform"].(map[string]any)
+	if platform == nil {
+		platform = map[string]any{}
+	}
+	platform["php"] = minPHP
+	config["platform"] = platform
+	composer["config"] = config
+
+	tmpDir, err := os.MkdirTemp("", "speakeasy-composer")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	pinned, err := json.Marshal(composer)
+	if err != nil {
+		return fmt.Errorf("failed to marshal composer.json: %w", err)
+	}
+	pinnedPath := filepath.Join(tmpDir, "composer.json")
+	if err := os.WriteFile(pinnedPath, pinned, 0o644); err != nil {
+		return fmt.Errorf("failed to write composer.json: %w", err)
+	}
+
+	if _, err := command.Run(dir, []string{"COMPOSER=" + pinnedPath}, "composer", "update", "--dry-run", "--no-scripts", "--no-plugins", "--no-interaction"); err != nil {
+		return fmt.Errorf("php package dependencies aren't installable on its minimum PHP version %s: %w", minPHP, err)
+	}
+
+	return nil
+}
+
+// minimumPHPVersion returns the lowest version referenced by a composer PHP constraint such as ^8.1 or ^7.4 || ^8.0
+func minimumPHPVersion(constraint string) string {
+	var minVersion *version.Version
+	minimum := ""
+
+	for _, match := range phpVersionRegex.FindAllString(constraint, -1) {
+		v, err := version.NewVersion(match)
+		if err != nil {
+			continue
+		}
+		if minVersion == nil || v.LessThan(minVersion) {
+			minVersion = v
+			minimum = match
+		}
+	}
+
+	return minimum
+}
@@ -0,0 +1,14 @@
+package verify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinimumPHPVersion(t *testing.T) {
+	require.Equal(t, "8.1", minimumPHPVersion("^8.1"))
+	require.Equal(t, "7.4", minimumPHPVersion("^8.0 || ^7.4"))
+	require.Equal(t, "8.2.0", minimumPHPVersion(">=8.2.0 <9.0"))
+	require.Equal(t, "", minimumPHPVersion("*"))
+}
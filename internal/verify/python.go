@@ -0,0 +1,98 @@
+package verify
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+)
+
+// pythonPackage installs a copy of the package into a virtual environment for each of the configured Python versions
+// available on the runner, then compiles and imports it. The copy keeps build artifacts such as build/, *.egg-info and
+// __pycache__ out of the output.
+func pythonPackage(dir string) error {
+	versions := environment.GetPythonSmokeTestVersions()
+	if len(versions) == 0 {
+		return nil
+	}
+
+	module, err := pythonModuleName(dir)
+	if err != nil {
+		return err
+	}
+
+	buildDir, cleanup, err := copyToTempDir(dir)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	for _, version := range versions {
+		interpreter, err := exec.LookPath("python" + version)
+		if err != nil {
+			logging.Info("Python %s not found, skipping smoke testing the python package with it", version)
+			continue
+		}
+
+		if err := smokeTestPython(buildDir, interpreter, module); err != nil {
+			return fmt.Errorf("python package failed smoke test with Python %s: %w", version, err)
+		}
+	}
+
+	return nil
+}
+
+func smokeTestPython(dir, interpreter, module string) error {
+	venvDir, err := os.MkdirTemp("", "speakeasy-python-smoke-test")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(venvDir)
+
+	if _, err := run(dir, interpreter, "-m", "venv", venvDir); err != nil {
+		return err
+	}
+
+	python := filepath.Join(venvDir, "bin", "python")
+
+	if _, err := run(dir, python, "-m", "pip", "install", "--quiet", "--disable-pip-version-check", "."); err != nil {
+		return err
+	}
+	if _, err := run(dir, python, "-m", "compileall", "-q", dir); err != nil {
+		return err
+	}
+	// Import from outside the package directory so the installed package is used rather than the sources
+	if _, err := run(venvDir, python, "-c", "import "+module); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func pythonModuleName(dir string) (string, error) {
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to load python config: %w", err)
+	}
+
+	langCfg, ok := cfg.Config.Languages["python"]
+	if !ok {
+		return "", fmt.Errorf("python config not found in %s", dir)
+	}
+
+	if moduleName, ok := langCfg.Cfg["moduleName"].(string); ok && moduleName != "" {
+		return moduleName, nil
+	}
+
+	packageName, _ := langCfg.Cfg["packageName"].(string)
+	if packageName == "" {
+		return "", fmt.Errorf("python packageName not configured in %s", dir)
+	}
+
+	return strings.ReplaceAll(packageName, "-", "_"), nil
+}
@@ -0,0 +1,125 @@
+package verify
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	config "github.com/speakeasy-api/sdk-gen-config"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+type packageJSON struct {
+	Scripts map[string]string `json:"scripts"`
+	Exports any               `json:"exports"`
+}
+
+// typescriptPackage builds a copy of the package, which produces each of its configured module formats, and checks
+// every file its exports map points to was built
+func typescriptPackage(dir string) error {
+	if !environment.VerifyTypeScriptBuild() {
+		return nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read package.json: %w", err)
+	}
+
+	var pkg packageJSON
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	if pkg.Exports == nil && pkg.Scripts["build"] == "" {
+		return nil
+	}
+
+	buildDir, cleanup, err := copyToTempDir(dir)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if _, ok := pkg.Scripts["build"]; ok {
+		if _, err := run(buildDir, "npm", "install", "--ignore-scripts", "--no-audit", "--no-fund"); err != nil {
+			return fmt.Errorf("failed to install typescript package dependencies: %w", err)
+		}
+		if _, err := run(buildDir, "npm", "run", "build"); err != nil {
+			return fmt.Errorf("failed to build typescript package: %w", err)
+		}
+	}
+
+	if pkg.Exports == nil {
+		return nil
+	}
+
+	targets, conditions := exportTargets(pkg.Exports)
+
+	missing := []string{}
+	for _, target := range targets {
+		// Subpath patterns can't be resolved without an import to match them against
+		if strings.Contains(target, "*") {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(buildDir, target)); err != nil {
+			missing = append(missing, target)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("package.json exports files that weren't built: %s", strings.Join(missing, ", "))
+	}
+
+	if moduleFormat(dir) == "dual" && (!conditions["import"] || !conditions["require"]) {
+		return fmt.Errorf("package.json exports don't provide both import and require conditions for a dual module format package")
+	}
+
+	return nil
+}
+
+// exportTargets returns the file targets of an exports map along with the conditions used within it
+func exportTargets(exports any) ([]string, map[string]bool) {
+	targets := []string{}
+	conditions := map[string]bool{}
+
+	var walk func(value any)
+	walk = func(value any) {
+		switch v := value.(type) {
+		case string:
+			targets = append(targets, v)
+		case []any:
+			for _, item := range v {
+				walk(item)
+			}
+		case map[string]any:
+			for key, item := range v {
+				if !strings.HasPrefix(key, ".") {
+					conditions[key] = true
+				}
+				walk(item)
+			}
+		}
+	}
+	walk(exports)
+
+	return targets, conditions
+}
+
+func moduleFormat(dir string) string {
+	cfg, err := config.Load(dir)
+	if err != nil || cfg.Config == nil {
+		return ""
+	}
+
+	langCfg, ok := cfg.Config.Languages["typescript"]
+	if !ok {
+		return ""
+	}
+
+	format, _ := langCfg.Cfg["moduleFormat"].(string)
+	return format
+}
@@ -0,0 +1,27 @@
+package verify
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportTargets(t *testing.T) {
+	var exports any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		".": {
+			"import": {"types": "./esm/index.d.ts", "default": "./esm/index.js"},
+			"require": {"types": "./commonjs/index.d.ts", "default": "./commonjs/index.js"}
+		},
+		"./models/*": "./esm/models/*.js",
+		"./package.json": "./package.json"
+	}`), &exports))
+
+	targets, conditions := exportTargets(exports)
+	sort.Strings(targets)
+
+	require.Equal(t, []string{"./commonjs/index.d.ts", "./commonjs/index.js", "./esm/index.d.ts", "./esm/index.js", "./esm/models/*.js", "./package.json"}, targets)
+	require.Equal(t, map[string]bool{"import": true, "require": true, "types": true, "default": true}, conditions)
+}
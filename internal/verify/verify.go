@@ -0,0 +1,112 @@
+package verify
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/command"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// Target runs the checks configured for the target's language against its generated output in dir, failing if the
+// generated SDK wouldn't be usable by consumers. Checks may update the output, e.g. tidying dependencies, so it should
+// run before checking for changes.
+func Target(lang, dir string) error {
+	if environment.VerifyExamples() {
+		if err := examples(lang, dir); err != nil {
+			return err
+		}
+	}
+
+	switch lang {
+	case "go":
+		return goModule(dir)
+	case "typescript":
+		return typescriptPackage(dir)
+	case "python":
+		return pythonPackage(dir)
+	case "php":
+		return phpPackage(dir)
+	}
+
+	return nil
+}
+
+func run(dir string, args ...string) (string, error) {
+	return command.Run(dir, nil, args...)
+}
+
+// uncopiedDirs are skipped when copying a generated SDK, as they aren't part of its sources
+var uncopiedDirs = []string{".git", "node_modules"}
+
+// copyToTempDir copies the generated SDK in dir to a temporary directory, so checks that build or install it don't leave
+// artifacts in the output. The returned func removes the copy.
+func copyToTempDir(dir string) (string, func(), error) {
+	tempDir, err := os.MkdirTemp("", "speakeasy-verify")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(tempDir) }
+
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(tempDir, rel)
+
+		switch {
+		case d.IsDir():
+			if rel != "." && slices.Contains(uncopiedDirs, d.Name()) {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, 0o755)
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.Type().IsRegular():
+			return copyFile(path, target)
+		}
+
+		return nil
+	})
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to copy %s: %w", dir, err)
+	}
+
+	return tempDir, cleanup, nil
+}
+
+func copyFile(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
@@ -0,0 +1,34 @@
+package verify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyToTempDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "src"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "node_modules", "dep"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name": "sdk"}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src", "index.ts"), []byte("export {};\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "node_modules", "dep", "index.js"), []byte(""), 0o644))
+
+	copied, cleanup, err := copyToTempDir(dir)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(copied, "src", "index.ts"))
+	require.NoError(t, err)
+	assert.Equal(t, "export {};\n", string(data))
+	assert.FileExists(t, filepath.Join(copied, "package.json"))
+	assert.NoDirExists(t, filepath.Join(copied, "node_modules"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(copied, "src", "index.js"), []byte(""), 0o644))
+	assert.NoFileExists(t, filepath.Join(dir, "src", "index.js"))
+
+	cleanup()
+	assert.NoDirExists(t, copied)
+}
@@ -0,0 +1,62 @@
+package versionbumps
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// docVersionDateLayouts are the formats of date based OpenAPI doc versions, tried in order
+var docVersionDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"2006.01.02",
+	"2006/01/02",
+	"20060102",
+	"2006-01",
+	"2006.01",
+}
+
+// DocVersionAdvanced returns whether the OpenAPI doc advanced between two generations according to the comparison.
+// Versions that can't be compared as semver or dates advance whenever they differ, so changes are never missed.
+func DocVersionAdvanced(comparison environment.DocVersionComparison, previousVersion, currentVersion, previousChecksum, currentChecksum string) bool {
+	if comparison == environment.DocVersionChecksum {
+		return previousChecksum != currentChecksum
+	}
+	if previousVersion == currentVersion {
+		return false
+	}
+
+	switch comparison {
+	case environment.DocVersionLexicographic:
+		return currentVersion > previousVersion
+	case environment.DocVersionDate:
+		previous, previousOK := parseDocVersionDate(previousVersion)
+		current, currentOK := parseDocVersionDate(currentVersion)
+		if previousOK && currentOK {
+			return current.After(previous)
+		}
+	default:
+		previous, previousErr := version.NewSemver(previousVersion)
+		current, currentErr := version.NewSemver(currentVersion)
+		if previousErr == nil && currentErr == nil {
+			return current.GreaterThan(previous)
+		}
+	}
+
+	return true
+}
+
+func parseDocVersionDate(v string) (time.Time, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	for _, layout := range docVersionDateLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
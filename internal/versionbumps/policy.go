@@ -0,0 +1,56 @@
+package versionbumps
+
+import "github.com/speakeasy-api/versioning-reports/versioning"
+
+// bumpRanks orders the bumps that can be determined automatically from smallest to largest, graduating a prerelease
+// and custom versions are only ever applied manually
+var bumpRanks = map[versioning.BumpType]int{
+	versioning.BumpNone:       0,
+	versioning.BumpPrerelease: 1,
+	versioning.BumpPatch:      2,
+	versioning.BumpMinor:      3,
+	versioning.BumpMajor:      4,
+}
+
+// HighestBump returns the largest bump applied to any of the targets in the report
+func HighestBump(report *versioning.MergedVersionReport) versioning.BumpType {
+	highest := versioning.BumpNone
+	if report == nil {
+		return highest
+	}
+
+	for _, r := range report.Reports {
+		if rank, ok := bumpRanks[r.BumpType]; ok && rank > bumpRanks[highest] {
+			highest = r.BumpType
+		}
+	}
+
+	return highest
+}
+
+// ShiftPreOneBump returns the bump to apply to an SDK below 1.0.0, where by convention breaking changes bump the
+// minor version and features bump the patch version
+func ShiftPreOneBump(bump versioning.BumpType) versioning.BumpType {
+	switch bump {
+	case versioning.BumpMajor:
+		return versioning.BumpMinor
+	case versioning.BumpMinor:
+		return versioning.BumpPatch
+	default:
+		return bump
+	}
+}
+
+// ExceedsBump returns whether bump is larger than limit, bumps that are only applied manually never exceed a limit
+func ExceedsBump(bump, limit versioning.BumpType) bool {
+	bumpRank, ok := bumpRanks[bump]
+	if !ok {
+		return false
+	}
+	limitRank, ok := bumpRanks[limit]
+	if !ok {
+		return false
+	}
+
+	return bumpRank > limitRank
+}
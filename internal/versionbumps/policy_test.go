@@ -0,0 +1,68 @@
+package versionbumps
+
+import (
+	"testing"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/versioning-reports/versioning"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighestBump(t *testing.T) {
+	assert.Equal(t, versioning.BumpNone, HighestBump(nil))
+
+	report := &versioning.MergedVersionReport{Reports: []versioning.VersionReport{
+		{BumpType: versioning.BumpPatch},
+		{BumpType: versioning.BumpMajor},
+		{BumpType: versioning.BumpMinor},
+	}}
+	assert.Equal(t, versioning.BumpMajor, HighestBump(report))
+
+	report = &versioning.MergedVersionReport{Reports: []versioning.VersionReport{
+		{BumpType: versioning.BumpCustom},
+		{BumpType: versioning.BumpPrerelease},
+	}}
+	assert.Equal(t, versioning.BumpPrerelease, HighestBump(report))
+}
+
+func TestShiftPreOneBump(t *testing.T) {
+	assert.Equal(t, versioning.BumpMinor, ShiftPreOneBump(versioning.BumpMajor))
+	assert.Equal(t, versioning.BumpPatch, ShiftPreOneBump(versioning.BumpMinor))
+	assert.Equal(t, versioning.BumpPatch, ShiftPreOneBump(versioning.BumpPatch))
+	assert.Equal(t, versioning.BumpGraduate, ShiftPreOneBump(versioning.BumpGraduate))
+}
+
+func TestExceedsBump(t *testing.T) {
+	assert.True(t, ExceedsBump(versioning.BumpMajor, versioning.BumpMinor))
+	assert.False(t, ExceedsBump(versioning.BumpMinor, versioning.BumpMinor))
+	assert.False(t, ExceedsBump(versioning.BumpPatch, versioning.BumpMinor))
+	assert.True(t, ExceedsBump(versioning.BumpMinor, versioning.BumpNone))
+	assert.False(t, ExceedsBump(versioning.BumpGraduate, versioning.BumpPatch))
+}
+
+func TestDocVersionAdvanced(t *testing.T) {
+	tests := []struct {
+		name       string
+		comparison environment.DocVersionComparison
+		previous   string
+		current    string
+		want       bool
+	}{
+		{name: "semver newer", comparison: environment.DocVersionSemver, previous: "1.2.0", current: "1.10.0", want: true},
+		{name: "semver older", comparison: environment.DocVersionSemver, previous: "1.10.0", current: "1.2.0", want: false},
+		{name: "semver unchanged", comparison: environment.DocVersionSemver, previous: "1.2.0", current: "1.2.0", want: false},
+		{name: "non-semver differs", comparison: environment.DocVersionSemver, previous: "beta", current: "gamma", want: true},
+		{name: "lexicographic", comparison: environment.DocVersionLexicographic, previous: "2024-b", current: "2024-a", want: false},
+		{name: "date newer", comparison: environment.DocVersionDate, previous: "2024.09.30", current: "2024.10.01", want: true},
+		{name: "date older", comparison: environment.DocVersionDate, previous: "20241001", current: "20240930", want: false},
+		{name: "unparseable date", comparison: environment.DocVersionDate, previous: "2024-10-01", current: "next", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DocVersionAdvanced(tt.comparison, tt.previous, tt.current, "abc", "abc"))
+		})
+	}
+
+	assert.True(t, DocVersionAdvanced(environment.DocVersionChecksum, "1.0.0", "1.0.0", "abc", "def"))
+	assert.False(t, DocVersionAdvanced(environment.DocVersionChecksum, "1.0.0", "2.0.0", "abc", "abc"))
+}
@@ -3,8 +3,10 @@ package versionbumps
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
-	"github.com/google/go-github/v63/github"
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
 	"github.com/speakeasy-api/versioning-reports/versioning"
 	"golang.org/x/exp/slices"
 )
@@ -31,26 +33,45 @@ type VersioningInfo struct {
 	VersionReport *versioning.MergedVersionReport
 }
 
+// semverLabels are the labels on a pull request changing the OpenAPI doc that signal the bump its changes require
+var semverLabels = map[string]versioning.BumpType{
+	"semver:major": versioning.BumpMajor,
+	"semver:minor": versioning.BumpMinor,
+	"semver:patch": versioning.BumpPatch,
+}
+
 func GetBumpTypeLabels() map[versioning.BumpType]string {
 	return bumpTypeLabels
 }
 
-func GetLabelBasedVersionBump(pr *github.PullRequest) versioning.BumpType {
+// GetSemverLabelVersionBump returns the bump signalled by semver: labels on the pull request that triggered the run
+func GetSemverLabelVersionBump(labels []string) versioning.BumpType {
+	var bumpLabels []versioning.BumpType
+	for _, label := range labels {
+		if bumpType, ok := semverLabels[strings.ToLower(label)]; ok {
+			bumpLabels = append(bumpLabels, bumpType)
+		}
+	}
+
+	return stackRankBumpLabels(bumpLabels)
+}
+
+func GetLabelBasedVersionBump(pr *ci.PullRequest) versioning.BumpType {
 	if pr == nil {
 		return versioning.BumpNone
 	}
 
 	var bumpLabels []versioning.BumpType
 	for _, label := range pr.Labels {
-		if _, ok := bumpTypeLabels[versioning.BumpType(label.GetName())]; ok {
-			bumpLabels = append(bumpLabels, versioning.BumpType(label.GetName()))
+		if _, ok := bumpTypeLabels[versioning.BumpType(label)]; ok {
+			bumpLabels = append(bumpLabels, versioning.BumpType(label))
 		}
 	}
 
 	if bumpType := stackRankBumpLabels(bumpLabels); bumpType != versioning.BumpNone {
-		currentPRBumpType, currentPRBumpMethod, err := parseBumpFromPRBody(pr.GetBody())
+		currentPRBumpType, currentPRBumpMethod, err := parseBumpFromPRBody(pr.Body)
 		if err != nil {
-			fmt.Errorf("failed to parse bump type and mode from PR body: %w", err)
+			logging.Debug("failed to parse bump type and mode from PR body: %v", err)
 			return versioning.BumpNone
 		}
 
@@ -0,0 +1,27 @@
+package versionbumps
+
+import (
+	"testing"
+
+	"github.com/speakeasy-api/versioning-reports/versioning"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSemverLabelVersionBump(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels []string
+		want   versioning.BumpType
+	}{
+		{name: "no labels", labels: nil, want: versioning.BumpNone},
+		{name: "unrelated labels", labels: []string{"bug", "minor"}, want: versioning.BumpNone},
+		{name: "minor", labels: []string{"semver:minor"}, want: versioning.BumpMinor},
+		{name: "case insensitive", labels: []string{"SemVer:Major"}, want: versioning.BumpMajor},
+		{name: "highest wins", labels: []string{"semver:patch", "semver:major", "semver:minor"}, want: versioning.BumpMajor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetSemverLabelVersionBump(tt.labels))
+		})
+	}
+}
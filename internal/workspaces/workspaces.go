@@ -0,0 +1,262 @@
+package workspaces
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/command"
+	"github.com/speakeasy-api/sdk-generation-action/internal/logging"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	packageJSONFile   = "package.json"
+	pnpmWorkspaceFile = "pnpm-workspace.yaml"
+	goWorkFile        = "go.work"
+)
+
+// Update adds the directories of generated targets to the workspace manifests found in rootDir, so SDKs generated into a
+// workspace monorepo are part of the workspace, then verifies the workspaces still resolve. Target directories are
+// keyed by the target's language and relative to rootDir. The updated manifests are returned.
+func Update(rootDir string, targetDirs map[string][]string) ([]string, error) {
+	updated := []string{}
+
+	for _, dir := range targetDirs["typescript"] {
+		manifest, err := addJSWorkspace(rootDir, cleanDir(dir))
+		if err != nil {
+			return nil, err
+		}
+		if manifest != "" && !slices.Contains(updated, manifest) {
+			updated = append(updated, manifest)
+		}
+	}
+
+	for _, dir := range targetDirs["go"] {
+		added, err := addGoWorkspace(rootDir, cleanDir(dir))
+		if err != nil {
+			return nil, err
+		}
+		if added && !slices.Contains(updated, goWorkFile) {
+			updated = append(updated, goWorkFile)
+		}
+	}
+
+	for _, manifest := range updated {
+		if err := verify(rootDir, manifest); err != nil {
+			return nil, err
+		}
+	}
+
+	return updated, nil
+}
+
+// addJSWorkspace adds the directory to pnpm-workspace.yaml if present, otherwise to the workspaces of package.json,
+// returning the manifest updated if the directory wasn't already part of the workspace
+func addJSWorkspace(rootDir, dir string) (string, error) {
+	if dir == "." {
+		return "", nil
+	}
+
+	pnpmPath := filepath.Join(rootDir, pnpmWorkspaceFile)
+	if data, err := os.ReadFile(pnpmPath); err == nil {
+		added, updatedData, err := addPnpmWorkspace(data, dir)
+		if err != nil || !added {
+			return "", err
+		}
+
+		if err := os.WriteFile(pnpmPath, updatedData, 0o644); err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", pnpmWorkspaceFile, err)
+		}
+
+		logging.Info("Added %s to %s", dir, pnpmWorkspaceFile)
+		return pnpmWorkspaceFile, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(rootDir, packageJSONFile))
+	if err != nil {
+		return "", nil
+	}
+
+	var pkg struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", packageJSONFile, err)
+	}
+	if len(pkg.Workspaces) == 0 {
+		return "", nil
+	}
+
+	// Workspaces are either a list of patterns or, for yarn, an object with a list of package patterns
+	key := "workspaces"
+	var patterns []string
+	if err := json.Unmarshal(pkg.Workspaces, &patterns); err != nil {
+		var yarnWorkspaces struct {
+			Packages []string `json:"packages"`
+		}
+		if err := json.Unmarshal(pkg.Workspaces, &yarnWorkspaces); err != nil {
+			return "", fmt.Errorf("failed to parse workspaces of %s: %w", packageJSONFile, err)
+		}
+		key = "workspaces.packages"
+		patterns = yarnWorkspaces.Packages
+	}
+
+	if matchesAny(patterns, dir) {
+		return "", nil
+	}
+
+	// npm pkg preserves the formatting of package.json
+	if _, err := run(rootDir, "npm", "pkg", "set", fmt.Sprintf("%s[%d]=%s", key, len(patterns), dir)); err != nil {
+		return "", fmt.Errorf("failed to add %s to %s: %w", dir, packageJSONFile, err)
+	}
+
+	logging.Info("Added %s to %s workspaces", dir, packageJSONFile)
+	return packageJSONFile, nil
+}
+
+func addPnpmWorkspace(data []byte, dir string) (bool, []byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return false, nil, fmt.Errorf("failed to parse %s: %w", pnpmWorkspaceFile, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return false, nil, nil
+	}
+
+	root := doc.Content[0]
+
+	var packages *yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "packages" {
+			packages = root.Content[i+1]
+		}
+	}
+	if packages == nil {
+		packages = &yaml.Node{Kind: yaml.SequenceNode}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "packages"}, packages)
+	}
+
+	patterns := []string{}
+	for _, node := range packages.Content {
+		patterns = append(patterns, node.Value)
+	}
+	if matchesAny(patterns, dir) {
+		return false, nil, nil
+	}
+
+	packages.Content = append(packages.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: dir})
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return false, nil, fmt.Errorf("failed to marshal %s: %w", pnpmWorkspaceFile, err)
+	}
+
+	return true, buf.Bytes(), nil
+}
+
+// addGoWorkspace adds the directory to go.work if present, returning whether it wasn't already used
+func addGoWorkspace(rootDir, dir string) (bool, error) {
+	goWorkPath := filepath.Join(rootDir, goWorkFile)
+
+	before, err := os.ReadFile(goWorkPath)
+	if err != nil {
+		return false, nil
+	}
+
+	if _, err := run(rootDir, "go", "work", "use", "./"+dir); err != nil {
+		return false, fmt.Errorf("failed to add %s to %s: %w", dir, goWorkFile, err)
+	}
+
+	after, err := os.ReadFile(goWorkPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", goWorkFile, err)
+	}
+
+	if bytes.Equal(before, after) {
+		return false, nil
+	}
+
+	logging.Info("Added %s to %s", dir, goWorkFile)
+	return true, nil
+}
+
+// verify checks the updated workspace still resolves, without installing any dependencies
+func verify(rootDir, manifest string) error {
+	var args []string
+	switch manifest {
+	case goWorkFile:
+		args = []string{"go", "list", "-m", "all"}
+	case pnpmWorkspaceFile:
+		args = []string{"pnpm", "install", "--lockfile-only", "--ignore-scripts"}
+	case packageJSONFile:
+		if _, err := os.Stat(filepath.Join(rootDir, "yarn.lock")); err == nil {
+			args = yarnVerifyArgs(rootDir)
+		} else {
+			args = []string{"npm", "install", "--package-lock-only", "--ignore-scripts", "--no-audit", "--no-fund"}
+		}
+	}
+
+	if _, err := exec.LookPath(args[0]); err != nil {
+		logging.Info("%s not found, skipping verifying the workspace in %s resolves", args[0], manifest)
+		return nil
+	}
+
+	logging.Info("Verifying the workspace in %s resolves", manifest)
+
+	if _, err := run(rootDir, args...); err != nil {
+		return fmt.Errorf("workspace in %s no longer resolves: %w", manifest, err)
+	}
+
+	return nil
+}
+
+// yarnVerifyArgs returns the command checking a yarn workspace resolves. Yarn 1 can't update its lockfile without
+// installing, so its workspaces are only listed, which fails when they don't resolve.
+func yarnVerifyArgs(rootDir string) []string {
+	if output, err := run(rootDir, "yarn", "--version"); err == nil && isYarnClassic(output) {
+		return []string{"yarn", "workspaces", "info"}
+	}
+
+	return []string{"yarn", "install", "--mode=update-lockfile"}
+}
+
+// isYarnClassic returns whether the output of yarn --version is a Yarn 1 version
+func isYarnClassic(version string) bool {
+	return strings.HasPrefix(strings.TrimSpace(version), "1.")
+}
+
+func matchesAny(patterns []string, dir string) bool {
+	for _, pattern := range patterns {
+		// Directories explicitly excluded from the workspace are left excluded
+		pattern = cleanDir(strings.TrimPrefix(pattern, "!"))
+
+		if pattern == dir {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "/**"); ok && strings.HasPrefix(dir, prefix+"/") {
+			return true
+		}
+		if matched, _ := path.Match(pattern, dir); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func cleanDir(dir string) string {
+	return path.Clean(filepath.ToSlash(dir))
+}
+
+func run(dir string, args ...string) (string, error) {
+	return command.Run(dir, nil, args...)
+}
@@ -0,0 +1,31 @@
+package workspaces
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchesAny(t *testing.T) {
+	require.True(t, matchesAny([]string{"packages/*"}, "packages/sdk"))
+	require.True(t, matchesAny([]string{"./sdks/**"}, "sdks/typescript/client"))
+	require.True(t, matchesAny([]string{"apps/web", "sdk"}, "sdk"))
+	require.False(t, matchesAny([]string{"packages/*"}, "sdks/typescript"))
+}
+
+func TestAddPnpmWorkspace(t *testing.T) {
+	added, data, err := addPnpmWorkspace([]byte("packages:\n  - apps/*\n"), "sdks/typescript")
+	require.NoError(t, err)
+	require.True(t, added)
+	require.Equal(t, "packages:\n  - apps/*\n  - sdks/typescript\n", string(data))
+
+	added, _, err = addPnpmWorkspace([]byte("packages:\n  - sdks/*\n"), "sdks/typescript")
+	require.NoError(t, err)
+	require.False(t, added)
+}
+
+func TestIsYarnClassic(t *testing.T) {
+	require.True(t, isYarnClassic("1.22.19\n"))
+	require.False(t, isYarnClassic("4.1.0\n"))
+	require.False(t, isYarnClassic("3.6.4"))
+}
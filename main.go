@@ -6,11 +6,14 @@ import (
 	"os"
 	"strings"
 
+	"github.com/speakeasy-api/sdk-generation-action/internal/ci"
 	"github.com/speakeasy-api/sdk-generation-action/internal/telemetry"
 	"github.com/speakeasy-api/speakeasy-client-sdk-go/v3/pkg/models/shared"
 
 	"github.com/speakeasy-api/sdk-generation-action/internal/actions"
 	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+	"github.com/speakeasy-api/sdk-generation-action/internal/metrics"
+	"github.com/speakeasy-api/sdk-generation-action/internal/tracing"
 	"golang.org/x/exp/slices"
 )
 
@@ -35,7 +38,27 @@ func main() {
 		}
 	}
 
-	var err error
+	if err := ci.Current().Init(); err != nil {
+		ci.Error("failed", err.Error())
+		os.Exit(1)
+	}
+
+	if err := environment.ValidateInputs(); err != nil {
+		ci.Error("invalid inputs", err.Error())
+		os.Exit(1)
+	}
+
+	flushTraces, err := tracing.Init(environment.GetOTLPEndpoint(), environment.GetTraceparent())
+	if err != nil {
+		fmt.Printf("Failed to initialize tracing: %v\n", err)
+	}
+	endRootSpan := tracing.StartRoot(fmt.Sprintf("sdk-generation-action %s", environment.GetAction()), map[string]string{
+		"github.repository": environment.GetRepo(),
+		"github.run_id":     environment.GetRunID(),
+		"github.workflow":   environment.GetWorkflowName(),
+		"action.mode":       string(environment.GetMode()),
+	})
+
 	// Don't fire CI_Exec telemetry on actions where we are only sending specific telemetry back.
 	if environment.GetAction() == environment.ActionLog {
 		err = actions.LogActionResult()
@@ -54,14 +77,29 @@ func main() {
 				return actions.Release()
 			case environment.ActionTag:
 				return actions.Tag()
+			case environment.ActionChangelog:
+				return actions.Changelog()
+			case environment.ActionYank:
+				return actions.Yank()
+			case environment.ActionGraduate:
+				return actions.Graduate()
 			default:
 				return fmt.Errorf("unknown action: %s", environment.GetAction())
 			}
 		})
 	}
 
+	endRootSpan(err)
+	flushTraces()
+
+	if address := environment.GetStatsDAddress(); address != "" {
+		if err := metrics.PushStatsD(address, map[string]string{"repo": environment.GetRepo(), "action": string(environment.GetAction())}); err != nil {
+			fmt.Printf("Failed to push metrics: %v\n", err)
+		}
+	}
+
 	if err != nil {
-		fmt.Printf("::error title=failed::%v\n", err)
+		ci.Error("failed", err.Error())
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,126 @@
+package releases
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// PendingReleasesFile stores the RELEASES.md entries deferred by release_after_publish until the SDKs they release are
+// published, relative to the directory of RELEASES.md
+const PendingReleasesFile = ".speakeasy/pending-releases.json"
+
+// PendingRelease is a RELEASES.md entry waiting for the publishing of its Pending languages
+type PendingRelease struct {
+	ReleasesInfo
+	Pending []string `json:"pending"`
+}
+
+// GetPendingReleases returns the entries waiting for publishing, oldest first
+func GetPendingReleases(dir string) ([]PendingRelease, error) {
+	data, err := os.ReadFile(GetPendingReleasesPath(dir))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading pending releases file: %w", err)
+	}
+
+	var pending []PendingRelease
+	if err := json.Unmarshal(data, &pending); err != nil {
+		return nil, fmt.Errorf("error parsing pending releases file: %w", err)
+	}
+
+	return pending, nil
+}
+
+// AddPendingRelease defers the RELEASES.md entry of the release until the given languages are published
+func AddPendingRelease(releaseInfo ReleasesInfo, languages []string, dir string) error {
+	pending, err := GetPendingReleases(dir)
+	if err != nil {
+		return err
+	}
+
+	return savePendingReleases(append(pending, PendingRelease{ReleasesInfo: releaseInfo, Pending: languages}), dir)
+}
+
+// PublishPendingRelease marks the SDK released at the version from the directory as published, adding the entry of its
+// release to RELEASES.md once all of its languages are. It returns whether a pending release was updated.
+func PublishPendingRelease(version, directory, dir string) (bool, error) {
+	pending, err := GetPendingReleases(dir)
+	if err != nil {
+		return false, err
+	}
+
+	for i, release := range pending {
+		index := slices.IndexFunc(release.Pending, func(lang string) bool {
+			info, ok := release.Languages[lang]
+			return ok && info.Version == version && path.Clean(info.Path) == path.Clean(directory)
+		})
+		if index == -1 {
+			continue
+		}
+
+		release.Pending = slices.Delete(release.Pending, index, index+1)
+		if len(release.Pending) > 0 {
+			pending[i] = release
+			return true, savePendingReleases(pending, dir)
+		}
+
+		if err := UpdateReleasesFile(release.ReleasesInfo, dir); err != nil {
+			return false, err
+		}
+		return true, savePendingReleases(slices.Delete(pending, i, i+1), dir)
+	}
+
+	return false, nil
+}
+
+// GetLatestReleaseInfo returns the latest release, including those whose RELEASES.md entry is pending publishing
+func GetLatestReleaseInfo(dir string) (*ReleasesInfo, error) {
+	pending, err := GetPendingReleases(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) > 0 {
+		return &pending[len(pending)-1].ReleasesInfo, nil
+	}
+
+	return GetLastReleaseInfo(dir)
+}
+
+func GetPendingReleasesPath(dir string) string {
+	return path.Join(environment.GetRepoDir(), dir, PendingReleasesFile)
+}
+
+func savePendingReleases(pending []PendingRelease, dir string) error {
+	pendingPath := GetPendingReleasesPath(dir)
+
+	// The file is removed once nothing is pending so it doesn't linger in repos
+	if len(pending) == 0 {
+		if err := os.Remove(pendingPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error removing pending releases file: %w", err)
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(pending, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling pending releases: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(pendingPath), 0o755); err != nil {
+		return fmt.Errorf("error creating pending releases directory: %w", err)
+	}
+	if err := os.WriteFile(pendingPath, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("error writing pending releases file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package releases_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/speakeasy-api/sdk-generation-action/pkg/releases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishPendingRelease(t *testing.T) {
+	repoDir := t.TempDir()
+	t.Setenv("INPUT_REPO_DIR", repoDir)
+	t.Setenv("GITHUB_REPOSITORY", "test/repo")
+
+	releaseInfo := releases.ReleasesInfo{
+		ReleaseTitle:      "2026-10-15 00:00:00",
+		DocVersion:        "1.0.0",
+		SpeakeasyVersion:  "1.400.0",
+		GenerationVersion: "2.500.0",
+		Languages: map[string]releases.LanguageReleaseInfo{
+			"typescript": {PackageName: "@org/package", Path: "typescript", Version: "1.2.3"},
+			"python":     {PackageName: "org-package", Path: "python", Version: "1.2.3"},
+		},
+	}
+	require.NoError(t, releases.AddPendingRelease(releaseInfo, []string{"python", "typescript"}, "."))
+
+	latest, err := releases.GetLatestReleaseInfo(".")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", latest.DocVersion)
+
+	updated, err := releases.PublishPendingRelease("1.2.3", "./typescript", ".")
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.NoFileExists(t, filepath.Join(repoDir, "RELEASES.md"))
+
+	pending, err := releases.GetPendingReleases(".")
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, []string{"python"}, pending[0].Pending)
+
+	// A version that isn't pending is left alone
+	updated, err = releases.PublishPendingRelease("1.2.2", "python", ".")
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	updated, err = releases.PublishPendingRelease("1.2.3", "python", ".")
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.NoFileExists(t, releases.GetPendingReleasesPath("."))
+
+	data, err := os.ReadFile(filepath.Join(repoDir, "RELEASES.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "## 2026-10-15 00:00:00")
+
+	latest, err = releases.GetLatestReleaseInfo(".")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", latest.DocVersion)
+	assert.Equal(t, "1.2.3", latest.Languages["python"].Version)
+}
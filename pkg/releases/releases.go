@@ -35,17 +35,41 @@ type ReleasesInfo struct {
 	DocLocation        string
 	Languages          map[string]LanguageReleaseInfo
 	LanguagesGenerated map[string]GenerationInfo
+	// Contributors are credited for the changes to the OpenAPI doc in this release
+	Contributors []string
+	// APIChanges are the changes to the public API of the generated SDKs
+	APIChanges []APIChange
 }
 
+// APIChange is a symbol added to or removed from the public API of a generated SDK
+type APIChange struct {
+	Lang string
+	// Change is either "added" or "removed"
+	Change string
+	Symbol string
+}
+
+// String renders the release in the default release notes format
 func (r ReleasesInfo) String() string {
+	return r.Format(environment.ReleaseNotesSpeakeasyDefault)
+}
+
+// Format renders the release in the given release notes format, each of which can be read back by ParseReleases
+func (r ReleasesInfo) Format(format environment.ReleaseNotesFormat) string {
+	bullet := "-"
+	if format == environment.ReleaseNotesConventional {
+		bullet = "*"
+	}
+
 	generationOutput := []string{}
 	releasesOutput := []string{}
 
 	for lang, info := range r.LanguagesGenerated {
-		generationOutput = append(generationOutput, fmt.Sprintf("- [%s v%s] %s", lang, info.Version, info.Path))
+		generationOutput = append(generationOutput, fmt.Sprintf("%s [%s v%s] %s", bullet, lang, info.Version, info.Path))
 	}
 
-	if len(generationOutput) > 0 {
+	// keep-a-changelog lists the generated targets as part of the Changed section
+	if len(generationOutput) > 0 && format != environment.ReleaseNotesKeepAChangelog {
 		generationOutput = append([]string{"\n### Generated"}, generationOutput...)
 	}
 
@@ -101,19 +125,46 @@ func (r ReleasesInfo) String() string {
 		}
 
 		if pkgID != "" {
-			releasesOutput = append(releasesOutput, fmt.Sprintf("- [%s v%s] %s - %s", pkgID, info.Version, pkgURL, info.Path))
+			releasesOutput = append(releasesOutput, fmt.Sprintf("%s [%s v%s] %s - %s", bullet, pkgID, info.Version, pkgURL, info.Path))
 		}
 	}
 
 	if len(releasesOutput) > 0 {
-		releasesOutput = append([]string{"\n### Releases"}, releasesOutput...)
+		releasesHeading := "\n### Releases"
+		if format == environment.ReleaseNotesKeepAChangelog {
+			releasesHeading = "\n### Added"
+		}
+		releasesOutput = append([]string{releasesHeading}, releasesOutput...)
 	}
 
-	return fmt.Sprintf(`%s## %s
+	if len(r.Contributors) > 0 {
+		releasesOutput = append(releasesOutput, "\n### Contributors", "Thanks to "+strings.Join(r.Contributors, ", ")+" for the changes to the OpenAPI doc in this release")
+	}
+
+	var header string
+	switch format {
+	case environment.ReleaseNotesConventional:
+		header = fmt.Sprintf("## %s\n### Features\n* regenerated from OpenAPI Doc %s %s with Speakeasy CLI %s (%s)", r.ReleaseTitle, r.DocVersion, r.DocLocation, r.SpeakeasyVersion, r.GenerationVersion)
+	case environment.ReleaseNotesKeepAChangelog:
+		header = fmt.Sprintf("## [%s]\n### Changed\n- Regenerated from OpenAPI Doc %s %s with Speakeasy CLI %s (%s)", r.ReleaseTitle, r.DocVersion, r.DocLocation, r.SpeakeasyVersion, r.GenerationVersion)
+	default:
+		header = fmt.Sprintf(`## %s
 ### Changes
 Based on:
 - OpenAPI Doc %s %s
-- Speakeasy CLI %s (%s) https://github.com/speakeasy-api/speakeasy%s%s`, "\n\n", r.ReleaseTitle, r.DocVersion, r.DocLocation, r.SpeakeasyVersion, r.GenerationVersion, strings.Join(generationOutput, "\n"), strings.Join(releasesOutput, "\n"))
+- Speakeasy CLI %s (%s) https://github.com/speakeasy-api/speakeasy`, r.ReleaseTitle, r.DocVersion, r.DocLocation, r.SpeakeasyVersion, r.GenerationVersion)
+	}
+
+	apiOutput := []string{}
+	if len(r.APIChanges) > 0 {
+		apiOutput = append(apiOutput, "\n### API changes")
+		for _, change := range r.APIChanges {
+			apiOutput = append(apiOutput, fmt.Sprintf("%s %s: %s `%s`", bullet, change.Lang, change.Change, change.Symbol))
+		}
+	}
+
+	// Releases are separated by a blank line so must not contain any themselves
+	return fmt.Sprintf("\n\n%s%s%s%s", header, strings.Join(generationOutput, "\n"), strings.Join(releasesOutput, "\n"), strings.Join(apiOutput, "\n"))
 }
 
 func UpdateReleasesFile(releaseInfo ReleasesInfo, dir string) error {
@@ -127,7 +178,7 @@ func UpdateReleasesFile(releaseInfo ReleasesInfo, dir string) error {
 	}
 	defer f.Close()
 
-	_, err = f.WriteString(releaseInfo.String())
+	_, err = f.WriteString(releaseInfo.Format(environment.GetReleaseNotesFormat()))
 	if err != nil {
 		return fmt.Errorf("error writing to releases file: %w", err)
 	}
@@ -136,17 +187,23 @@ func UpdateReleasesFile(releaseInfo ReleasesInfo, dir string) error {
 }
 
 var (
-	releaseInfoRegex        = regexp.MustCompile(`(?s)## (.*?)\n### Changes\nBased on:\n- OpenAPI Doc (.*?) (.*?)\n- Speakeasy CLI (.*?) (\((.*?)\))?.*?`)
-	generatedLanguagesRegex = regexp.MustCompile(`- \[([a-z]+) v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (.*)`)
-	npmReleaseRegex         = regexp.MustCompile(`- \[NPM v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (https:\/\/www\.npmjs\.com\/package\/(.*?)\/v\/\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?) - (.*)`)
-	pypiReleaseRegex        = regexp.MustCompile(`- \[PyPI v(\d+\.\d+\.\d+(?:-?\w+(?:\.\w+)*)?)] (https:\/\/pypi\.org\/project\/(.*?)\/\d+\.\d+\.\d+(?:-?\w+(?:\.\w+)*)?) - (.*)`)
-	goReleaseRegex          = regexp.MustCompile(`- \[Go v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (https:\/\/(github.com\/.*?)\/releases\/tag\/.*?\/?v\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?) - (.*)`)
-	composerReleaseRegex    = regexp.MustCompile(`- \[Composer v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (https:\/\/packagist\.org\/packages\/(.*?)#v\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?) - (.*)`)
-	mavenReleaseRegex       = regexp.MustCompile(`- \[Maven Central v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (https:\/\/central\.sonatype\.com\/artifact\/(.*?)\/(.*?)\/.*?) - (.*)`)
-	terraformReleaseRegex   = regexp.MustCompile(`- \[Terraform v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (https:\/\/registry\.terraform\.io\/providers\/(.*?)\/(.*?)\/.*?) - (.*)`)
-	rubyGemReleaseRegex     = regexp.MustCompile(`- \[Ruby Gems v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (https:\/\/rubygems\.org\/gems\/(.*?)\/versions\/.*?) - (.*)`)
-	nugetReleaseRegex       = regexp.MustCompile(`- \[NuGet v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (https:\/\/www\.nuget\.org\/packages\/(.*?)\/\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?) - (.*)`)
-	swiftReleaseRegex       = regexp.MustCompile(`- \[Swift Package Manager v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (https:\/\/(github.com\/.*?)\/releases\/tag\/.*?\/?v\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?) - (.*)`)
+	// releaseInfoRegexes match the header of each release notes format, capturing the same groups
+	releaseInfoRegexes = []*regexp.Regexp{
+		regexp.MustCompile(`(?s)## (.*?)\n### Changes\nBased on:\n- OpenAPI Doc (.*?) (.*?)\n- Speakeasy CLI (.*?) (\((.*?)\))?.*?`),
+		regexp.MustCompile(`(?s)## (.*?)\n### Features\n\* regenerated from OpenAPI Doc (.*?) (.*?) with Speakeasy CLI (.*?) (\((.*?)\))?`),
+		regexp.MustCompile(`(?s)## \[(.*?)\]\n### Changed\n- Regenerated from OpenAPI Doc (.*?) (.*?) with Speakeasy CLI (.*?) (\((.*?)\))?`),
+	}
+	apiChangeRegex          = regexp.MustCompile("(?m)^[-*] ([a-z]+): (added|removed) `(.*)`$")
+	generatedLanguagesRegex = regexp.MustCompile(`[-*] \[([a-z]+) v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (.*)`)
+	npmReleaseRegex         = regexp.MustCompile(`[-*] \[NPM v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (https:\/\/www\.npmjs\.com\/package\/(.*?)\/v\/\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?) - (.*)`)
+	pypiReleaseRegex        = regexp.MustCompile(`[-*] \[PyPI v(\d+\.\d+\.\d+(?:-?\w+(?:\.\w+)*)?)] (https:\/\/pypi\.org\/project\/(.*?)\/\d+\.\d+\.\d+(?:-?\w+(?:\.\w+)*)?) - (.*)`)
+	goReleaseRegex          = regexp.MustCompile(`[-*] \[Go v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (https:\/\/(github.com\/.*?)\/releases\/tag\/.*?\/?v\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?) - (.*)`)
+	composerReleaseRegex    = regexp.MustCompile(`[-*] \[Composer v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (https:\/\/packagist\.org\/packages\/(.*?)#v\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?) - (.*)`)
+	mavenReleaseRegex       = regexp.MustCompile(`[-*] \[Maven Central v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (https:\/\/central\.sonatype\.com\/artifact\/(.*?)\/(.*?)\/.*?) - (.*)`)
+	terraformReleaseRegex   = regexp.MustCompile(`[-*] \[Terraform v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (https:\/\/registry\.terraform\.io\/providers\/(.*?)\/(.*?)\/.*?) - (.*)`)
+	rubyGemReleaseRegex     = regexp.MustCompile(`[-*] \[Ruby Gems v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (https:\/\/rubygems\.org\/gems\/(.*?)\/versions\/.*?) - (.*)`)
+	nugetReleaseRegex       = regexp.MustCompile(`[-*] \[NuGet v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (https:\/\/www\.nuget\.org\/packages\/(.*?)\/\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?) - (.*)`)
+	swiftReleaseRegex       = regexp.MustCompile(`[-*] \[Swift Package Manager v(\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?)] (https:\/\/(github.com\/.*?)\/releases\/tag\/.*?\/?v\d+\.\d+\.\d+(?:-\w+(?:\.\w+)*)?) - (.*)`)
 )
 
 func GetLastReleaseInfo(dir string) (*ReleasesInfo, error) {
@@ -163,7 +220,7 @@ func GetLastReleaseInfo(dir string) (*ReleasesInfo, error) {
 }
 
 func GetReleaseInfoFromGenerationFiles(path string) (*ReleasesInfo, error) {
-	cfg, err := config.Load(filepath.Join(environment.GetWorkspace(), "repo", path))
+	cfg, err := config.Load(filepath.Join(environment.GetRepoDir(), path))
 	if err != nil {
 		return nil, err
 	}
@@ -208,7 +265,12 @@ func ParseReleases(data string) (*ReleasesInfo, error) {
 		previousRelease = &releases[len(releases)-2]
 	}
 
-	matches := releaseInfoRegex.FindStringSubmatch(lastRelease)
+	var matches []string
+	for _, regex := range releaseInfoRegexes {
+		if matches = regex.FindStringSubmatch(lastRelease); matches != nil {
+			break
+		}
+	}
 
 	if len(matches) < 5 {
 		return nil, fmt.Errorf("error parsing last release info")
@@ -241,6 +303,10 @@ func ParseReleases(data string) (*ReleasesInfo, error) {
 		}
 	}
 
+	for _, subMatch := range apiChangeRegex.FindAllStringSubmatch(lastRelease, -1) {
+		info.APIChanges = append(info.APIChanges, APIChange{Lang: subMatch[1], Change: subMatch[2], Symbol: subMatch[3]})
+	}
+
 	npmMatches := npmReleaseRegex.FindStringSubmatch(lastRelease)
 
 	if len(npmMatches) == 5 {
@@ -367,5 +433,5 @@ func ParseReleases(data string) (*ReleasesInfo, error) {
 }
 
 func GetReleasesPath(dir string) string {
-	return path.Join(environment.GetWorkspace(), "repo", dir, "RELEASES.md")
+	return path.Join(environment.GetRepoDir(), dir, "RELEASES.md")
 }
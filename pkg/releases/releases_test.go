@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
 	"github.com/speakeasy-api/sdk-generation-action/pkg/releases"
 	"github.com/stretchr/testify/assert"
 )
@@ -142,6 +143,55 @@ func TestReleases_GoPackageNameConstruction_Success(t *testing.T) {
 	assert.Equal(t, r, *info)
 }
 
+func TestReleases_ReversableSerializationFormats_Success(t *testing.T) {
+	os.Setenv("GITHUB_REPOSITORY", "test/repo")
+
+	r := releases.ReleasesInfo{
+		ReleaseTitle:      "2023-02-22",
+		DocVersion:        "9.8.7",
+		DocLocation:       "https://example.com",
+		SpeakeasyVersion:  "6.6.6",
+		GenerationVersion: "v7.7.7",
+		Languages: map[string]releases.LanguageReleaseInfo{
+			"typescript": {
+				PackageName: "@org/package",
+				Path:        "typescript",
+				Version:     "1.2.3",
+				URL:         "https://www.npmjs.com/package/@org/package/v/1.2.3",
+			},
+			"go": {
+				PackageName: "github.com/test/repo/go",
+				Path:        "go",
+				Version:     "1.2.3",
+				URL:         "https://github.com/test/repo/releases/tag/go/v1.2.3",
+			},
+		},
+		LanguagesGenerated: map[string]releases.GenerationInfo{
+			"typescript": {
+				Path:    "typescript",
+				Version: "1.2.3",
+			},
+			"go": {
+				Path:    "go",
+				Version: "1.2.3",
+			},
+		},
+		APIChanges: []releases.APIChange{
+			{Lang: "go", Change: "added", Symbol: "sdk.Pets.Create func(ctx context.Context, request components.Pet) (*operations.CreatePetResponse, error)"},
+			{Lang: "typescript", Change: "removed", Symbol: "sdk/pets.Pets"},
+		},
+	}
+
+	for _, format := range []environment.ReleaseNotesFormat{environment.ReleaseNotesSpeakeasyDefault, environment.ReleaseNotesConventional, environment.ReleaseNotesKeepAChangelog} {
+		t.Run(string(format), func(t *testing.T) {
+			// Parse the last of several releases as it would be read back from RELEASES.md
+			info, err := releases.ParseReleases(r.Format(format) + r.Format(format))
+			assert.NoError(t, err)
+			assert.Equal(t, r, *info)
+		})
+	}
+}
+
 func TestReleases_ReversableSerializationMultiple_Success(t *testing.T) {
 	os.Setenv("GITHUB_REPOSITORY", "test/repo")
 
@@ -0,0 +1,95 @@
+package releases
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/speakeasy-api/sdk-generation-action/internal/environment"
+)
+
+// SpecChangesFile stores the OpenAPI doc changes of the last generation so they are available to release notes
+// templates when releasing, relative to the working directory
+const SpecChangesFile = ".speakeasy/openapi-changes.json"
+
+type SpecChanges struct {
+	Summary   string `json:"summary,omitempty"`
+	ReportURL string `json:"report_url,omitempty"`
+}
+
+// TemplateData is the data available to a release notes template
+type TemplateData struct {
+	ReleasesInfo
+	// Notes is the release rendered in the configured release_notes_format
+	Notes string
+	// Changelog describes the generator changes since the previous release
+	Changelog   string
+	SpecChanges SpecChanges
+}
+
+var templateFuncs = template.FuncMap{
+	"join": strings.Join,
+	"trim": strings.TrimSpace,
+}
+
+// RenderTemplate renders the Go template at the given path relative to the repo as the body of a release
+func RenderTemplate(templatePath string, data TemplateData) (string, error) {
+	contents, err := os.ReadFile(filepath.Join(environment.GetRepoDir(), templatePath))
+	if err != nil {
+		return "", fmt.Errorf("failed to read release notes template: %w", err)
+	}
+
+	tmpl, err := template.New(filepath.Base(templatePath)).Funcs(templateFuncs).Option("missingkey=error").Parse(string(contents))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse release notes template %s: %w", templatePath, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render release notes template %s: %w", templatePath, err)
+	}
+
+	return buf.String(), nil
+}
+
+// SaveSpecChanges stores the OpenAPI doc changes alongside the workflow file so they are committed with the generated changes
+func SaveSpecChanges(changes SpecChanges) error {
+	data, err := json.MarshalIndent(changes, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal spec changes: %w", err)
+	}
+
+	if err := os.WriteFile(specChangesPath(), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write spec changes: %w", err)
+	}
+
+	return nil
+}
+
+// GetSpecChanges returns the OpenAPI doc changes stored by the last generation, which may not have stored any
+func GetSpecChanges() (SpecChanges, error) {
+	var changes SpecChanges
+
+	data, err := os.ReadFile(specChangesPath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return changes, nil
+		}
+		return changes, fmt.Errorf("failed to read spec changes: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &changes); err != nil {
+		return changes, fmt.Errorf("failed to parse spec changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+func specChangesPath() string {
+	return filepath.Join(environment.GetRepoDir(), environment.GetWorkingDirectory(), SpecChangesFile)
+}
@@ -0,0 +1,46 @@
+package releases_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/speakeasy-api/sdk-generation-action/pkg/releases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderTemplate_Success(t *testing.T) {
+	repoDir := t.TempDir()
+	t.Setenv("INPUT_REPO_DIR", repoDir)
+
+	tmpl := `## {{ .ReleaseTitle }}
+{{ range $lang, $info := .Languages }}- {{ $lang }} {{ $info.Version }}
+{{ end }}{{ with .SpecChanges.Summary }}{{ trim . }}
+{{ end }}Thanks {{ join .Contributors ", " }}`
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "release.tmpl"), []byte(tmpl), 0o644))
+
+	body, err := releases.RenderTemplate("release.tmpl", releases.TemplateData{
+		ReleasesInfo: releases.ReleasesInfo{
+			ReleaseTitle: "2023-02-22",
+			Languages: map[string]releases.LanguageReleaseInfo{
+				"python":     {Version: "1.2.3"},
+				"typescript": {Version: "2.0.0"},
+			},
+			Contributors: []string{"@a", "@b"},
+		},
+		SpecChanges: releases.SpecChanges{Summary: "  Added GET /pets\n"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "## 2023-02-22\n- python 1.2.3\n- typescript 2.0.0\nAdded GET /pets\nThanks @a, @b", body)
+}
+
+func TestRenderTemplate_UnknownField(t *testing.T) {
+	repoDir := t.TempDir()
+	t.Setenv("INPUT_REPO_DIR", repoDir)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "release.tmpl"), []byte("{{ .Unknown }}"), 0o644))
+
+	_, err := releases.RenderTemplate("release.tmpl", releases.TemplateData{})
+	assert.Error(t, err)
+}